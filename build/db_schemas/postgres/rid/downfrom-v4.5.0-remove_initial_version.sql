@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS job_leases;
+DROP TABLE IF EXISTS token_revocations;
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS notification_outbox;
+DROP TABLE IF EXISTS identification_service_areas;
+DROP TABLE IF EXISTS subscriptions;
+DROP TABLE IF EXISTS schema_versions;
@@ -0,0 +1,99 @@
+-- This migration is equivalent to rid v4.5.0 schema for CockroachDB. The inverted indices on cells are
+-- replaced by GIN indices, which are available in vanilla PostgreSQL without any extension.
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY,
+    owner TEXT NOT NULL,
+    url TEXT NOT NULL,
+    notification_index INT4 DEFAULT 0,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL,
+    cells BIGINT[] NOT NULL,
+    writer TEXT,
+    footprint JSONB,
+    altitude_lower REAL,
+    altitude_upper REAL,
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at),
+    CHECK (array_length(cells, 1) IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS s_owner_idx ON subscriptions (owner);
+CREATE INDEX IF NOT EXISTS s_starts_at_idx ON subscriptions (starts_at);
+CREATE INDEX IF NOT EXISTS s_ends_at_idx ON subscriptions (ends_at);
+CREATE INDEX IF NOT EXISTS s_cell_idx ON subscriptions USING GIN (cells);
+CREATE INDEX IF NOT EXISTS subs_by_time_with_owner ON subscriptions (ends_at) INCLUDE (owner);
+
+CREATE TABLE IF NOT EXISTS identification_service_areas (
+    id UUID PRIMARY KEY,
+    owner TEXT NOT NULL,
+    url TEXT NOT NULL,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL,
+    cells BIGINT[] NOT NULL,
+    writer TEXT,
+    footprint JSONB,
+    altitude_lower REAL,
+    altitude_upper REAL,
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at),
+    CHECK (array_length(cells, 1) IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS isa_owner_idx ON identification_service_areas (owner);
+CREATE INDEX IF NOT EXISTS isa_starts_at_idx ON identification_service_areas (starts_at);
+CREATE INDEX IF NOT EXISTS isa_ends_at_idx ON identification_service_areas (ends_at);
+CREATE INDEX IF NOT EXISTS isa_updated_at_idx ON identification_service_areas (updated_at);
+CREATE INDEX IF NOT EXISTS isa_cell_idx ON identification_service_areas USING GIN (cells);
+
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id UUID PRIMARY KEY,
+    url TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT4 NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS notification_outbox_next_attempt_at_idx ON notification_outbox (next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+    operation TEXT NOT NULL,
+    manager TEXT NOT NULL,
+    old_version TEXT,
+    new_version TEXT,
+    cells BIGINT[],
+    recorded_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_log_entity_id_idx ON audit_log (entity_id, recorded_at);
+CREATE INDEX IF NOT EXISTS audit_log_manager_idx ON audit_log (manager, recorded_at);
+CREATE INDEX IF NOT EXISTS audit_log_recorded_at_idx ON audit_log (recorded_at);
+
+CREATE TABLE IF NOT EXISTS token_revocations (
+    id UUID PRIMARY KEY,
+    issuer TEXT,
+    jti TEXT,
+    subject TEXT,
+    reason TEXT NOT NULL,
+    revoked_by TEXT NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL,
+    CHECK ((jti IS NULL) != (subject IS NULL))
+);
+CREATE INDEX IF NOT EXISTS token_revocations_jti_idx ON token_revocations (jti);
+CREATE INDEX IF NOT EXISTS token_revocations_subject_idx ON token_revocations (subject, revoked_at);
+CREATE INDEX IF NOT EXISTS token_revocations_revoked_at_idx ON token_revocations (revoked_at);
+
+CREATE TABLE IF NOT EXISTS job_leases (
+    name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS schema_versions (
+	onerow_enforcer bool PRIMARY KEY DEFAULT TRUE CHECK(onerow_enforcer),
+	schema_version TEXT NOT NULL
+);
+
+INSERT INTO schema_versions (schema_version) VALUES ('v4.5.0');
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS schema_versions;
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS notification_outbox;
+DROP TABLE IF EXISTS scd_dss_reports;
+DROP TABLE IF EXISTS scd_uss_availability;
+DROP TABLE IF EXISTS scd_constraint_volumes;
+DROP TABLE IF EXISTS scd_operation_volumes;
+DROP TABLE IF EXISTS scd_constraints;
+DROP TABLE IF EXISTS scd_operations;
+DROP TABLE IF EXISTS scd_subscriptions;
+DROP TYPE IF EXISTS operational_intent_state;
@@ -0,0 +1,155 @@
+-- This migration is equivalent to scd v3.8.0 schema for CockroachDB. The inverted indices on cells are
+-- replaced by GIN indices, which are available in vanilla PostgreSQL without any extension.
+
+CREATE TABLE IF NOT EXISTS scd_subscriptions (
+  id UUID PRIMARY KEY,
+  owner TEXT NOT NULL,
+  version INT4 NOT NULL DEFAULT 0,
+  url TEXT NOT NULL,
+  notification_index INT4 DEFAULT 0,
+  notify_for_operations BOOL DEFAULT false,
+  notify_for_constraints BOOL DEFAULT false,
+  implicit BOOL DEFAULT false,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ NOT NULL,
+  cells BIGINT[],
+  altitude_lower REAL,
+  altitude_upper REAL,
+  footprint JSONB,
+  CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at),
+  CHECK (notify_for_operations OR notify_for_constraints)
+);
+CREATE INDEX IF NOT EXISTS ss_owner_idx ON scd_subscriptions (owner);
+CREATE INDEX IF NOT EXISTS ss_starts_at_idx ON scd_subscriptions (starts_at);
+CREATE INDEX IF NOT EXISTS ss_ends_at_idx ON scd_subscriptions (ends_at);
+CREATE INDEX IF NOT EXISTS ss_cells_idx ON scd_subscriptions USING GIN (cells);
+
+CREATE TYPE operational_intent_state AS ENUM ('Unknown', 'Accepted', 'Activated', 'Nonconforming', 'Contingent');
+
+CREATE TABLE IF NOT EXISTS scd_operations (
+  id UUID PRIMARY KEY,
+  owner TEXT NOT NULL,
+  version INT4 NOT NULL DEFAULT 0,
+  url TEXT NOT NULL,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  subscription_id UUID REFERENCES scd_subscriptions(id) ON DELETE CASCADE,
+  updated_at TIMESTAMPTZ NOT NULL,
+  state operational_intent_state NOT NULL DEFAULT 'Unknown',
+  cells BIGINT[],
+  uss_requested_ovn TEXT,
+  past_ovns TEXT[] NOT NULL DEFAULT ARRAY []::TEXT[],
+  CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at),
+  CHECK (uss_requested_ovn != ''),
+  CHECK (
+      array_position(past_ovns, NULL) IS NULL AND
+      array_position(past_ovns, '') IS NULL AND
+      array_position(past_ovns, uss_requested_ovn) IS NULL
+      )
+);
+CREATE INDEX IF NOT EXISTS so_owner_idx ON scd_operations (owner);
+CREATE INDEX IF NOT EXISTS so_altitude_lower_idx ON scd_operations (altitude_lower);
+CREATE INDEX IF NOT EXISTS so_altitude_upper_idx ON scd_operations (altitude_upper);
+CREATE INDEX IF NOT EXISTS so_starts_at_idx ON scd_operations (starts_at);
+CREATE INDEX IF NOT EXISTS so_ends_at_idx ON scd_operations (ends_at);
+CREATE INDEX IF NOT EXISTS so_updated_at_idx ON scd_operations (updated_at);
+CREATE INDEX IF NOT EXISTS so_subscription_id_idx ON scd_operations (subscription_id);
+CREATE INDEX IF NOT EXISTS so_cells_idx ON scd_operations USING GIN (cells);
+
+CREATE TABLE IF NOT EXISTS scd_constraints (
+  id UUID PRIMARY KEY,
+  owner TEXT NOT NULL,
+  version INT4 NOT NULL DEFAULT 0,
+  url TEXT NOT NULL,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ NOT NULL,
+  cells BIGINT[] NOT NULL CHECK (array_length(cells, 1) IS NOT NULL),
+  CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at)
+);
+CREATE INDEX IF NOT EXISTS sc_owner_idx ON scd_constraints (owner);
+CREATE INDEX IF NOT EXISTS sc_starts_at_idx ON scd_constraints (starts_at);
+CREATE INDEX IF NOT EXISTS sc_ends_at_idx ON scd_constraints (ends_at);
+CREATE INDEX IF NOT EXISTS sc_cells_idx ON scd_constraints USING GIN (cells);
+
+CREATE TABLE IF NOT EXISTS scd_operation_volumes (
+  operation_id UUID NOT NULL REFERENCES scd_operations (id) ON DELETE CASCADE,
+  volume_index INT4 NOT NULL,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  cells BIGINT[] NOT NULL,
+  footprint JSONB,
+  PRIMARY KEY (operation_id, volume_index),
+  CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at <= ends_at)
+);
+CREATE INDEX IF NOT EXISTS sov_cells_idx ON scd_operation_volumes USING GIN (cells);
+
+CREATE TABLE IF NOT EXISTS scd_constraint_volumes (
+  constraint_id UUID NOT NULL REFERENCES scd_constraints (id) ON DELETE CASCADE,
+  volume_index INT4 NOT NULL,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  cells BIGINT[] NOT NULL,
+  footprint JSONB,
+  PRIMARY KEY (constraint_id, volume_index),
+  CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at <= ends_at)
+);
+CREATE INDEX IF NOT EXISTS scv_cells_idx ON scd_constraint_volumes USING GIN (cells);
+
+CREATE TABLE IF NOT EXISTS scd_uss_availability (
+  id TEXT PRIMARY KEY,
+  availability TEXT NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS scd_dss_reports (
+  id UUID PRIMARY KEY,
+  reporter TEXT NOT NULL,
+  report JSONB NOT NULL,
+  received_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS scd_dss_reports_received_at_idx ON scd_dss_reports (received_at);
+CREATE INDEX IF NOT EXISTS scd_dss_reports_reporter_idx ON scd_dss_reports (reporter, received_at);
+
+CREATE TABLE IF NOT EXISTS notification_outbox (
+  id UUID PRIMARY KEY,
+  url TEXT NOT NULL,
+  scope TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  attempts INT4 NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL,
+  last_error TEXT,
+  created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS notification_outbox_next_attempt_at_idx ON notification_outbox (next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  entity_type TEXT NOT NULL,
+  entity_id UUID NOT NULL,
+  operation TEXT NOT NULL,
+  manager TEXT NOT NULL,
+  old_version TEXT,
+  new_version TEXT,
+  cells BIGINT[],
+  recorded_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_log_entity_id_idx ON audit_log (entity_id, recorded_at);
+CREATE INDEX IF NOT EXISTS audit_log_manager_idx ON audit_log (manager, recorded_at);
+CREATE INDEX IF NOT EXISTS audit_log_recorded_at_idx ON audit_log (recorded_at);
+
+CREATE TABLE IF NOT EXISTS schema_versions (
+  onerow_enforcer bool PRIMARY KEY DEFAULT TRUE CHECK(onerow_enforcer),
+  schema_version TEXT NOT NULL
+);
+
+INSERT INTO schema_versions (schema_version) VALUES ('v3.8.0');
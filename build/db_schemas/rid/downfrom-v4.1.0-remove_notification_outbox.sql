@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_outbox;
+UPDATE schema_versions set schema_version = 'v4.0.0' WHERE onerow_enforcer = TRUE;
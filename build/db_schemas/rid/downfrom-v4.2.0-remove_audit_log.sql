@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_log;
+UPDATE schema_versions set schema_version = 'v4.1.0' WHERE onerow_enforcer = TRUE;
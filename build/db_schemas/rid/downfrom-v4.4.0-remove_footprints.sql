@@ -0,0 +1,8 @@
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS altitude_upper;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS altitude_lower;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS footprint;
+ALTER TABLE identification_service_areas DROP COLUMN IF EXISTS altitude_upper;
+ALTER TABLE identification_service_areas DROP COLUMN IF EXISTS altitude_lower;
+ALTER TABLE identification_service_areas DROP COLUMN IF EXISTS footprint;
+
+UPDATE schema_versions set schema_version = 'v4.3.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS job_leases;
+UPDATE schema_versions set schema_version = 'v4.4.0' WHERE onerow_enforcer = TRUE;
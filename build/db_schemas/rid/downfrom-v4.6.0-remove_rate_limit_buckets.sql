@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS rate_limit_buckets;
+UPDATE schema_versions set schema_version = 'v4.5.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS notification_outbox (
+  id UUID PRIMARY KEY,
+  url STRING NOT NULL,
+  scope STRING NOT NULL,
+  payload JSONB NOT NULL,
+  attempts INT4 NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL,
+  last_error STRING,
+  created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS notification_outbox_next_attempt_at_idx ON notification_outbox (next_attempt_at);
+
+UPDATE schema_versions set schema_version = 'v4.1.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS token_revocations (
+  id UUID PRIMARY KEY,
+  issuer STRING,
+  jti STRING,
+  subject STRING,
+  reason STRING NOT NULL,
+  revoked_by STRING NOT NULL,
+  revoked_at TIMESTAMPTZ NOT NULL,
+  CHECK ((jti IS NULL) != (subject IS NULL))
+);
+CREATE INDEX IF NOT EXISTS token_revocations_jti_idx ON token_revocations (jti);
+CREATE INDEX IF NOT EXISTS token_revocations_subject_idx ON token_revocations (subject, revoked_at);
+CREATE INDEX IF NOT EXISTS token_revocations_revoked_at_idx ON token_revocations (revoked_at);
+
+UPDATE schema_versions set schema_version = 'v4.3.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,8 @@
+ALTER TABLE identification_service_areas ADD COLUMN IF NOT EXISTS footprint JSONB;
+ALTER TABLE identification_service_areas ADD COLUMN IF NOT EXISTS altitude_lower REAL;
+ALTER TABLE identification_service_areas ADD COLUMN IF NOT EXISTS altitude_upper REAL;
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS footprint JSONB;
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS altitude_lower REAL;
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS altitude_upper REAL;
+
+UPDATE schema_versions set schema_version = 'v4.4.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS job_leases (
+  name STRING PRIMARY KEY,
+  holder STRING NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+UPDATE schema_versions set schema_version = 'v4.5.0' WHERE onerow_enforcer = TRUE;
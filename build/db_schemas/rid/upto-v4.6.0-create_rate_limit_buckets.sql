@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+  name STRING PRIMARY KEY,
+  tokens FLOAT8 NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  INDEX updated_at_idx (updated_at)
+);
+
+UPDATE schema_versions set schema_version = 'v4.6.0' WHERE onerow_enforcer = TRUE;
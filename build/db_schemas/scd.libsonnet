@@ -5,6 +5,18 @@
     "upto-v3.0.0-add_inverted_indices.sql": importstr "scd/upto-v3.0.0-add_inverted_indices.sql",
     "upto-v3.1.0-create_uss_availability.sql": importstr "scd/upto-v3.1.0-create_uss_availability.sql",
     "upto-v3.2.0-add_ovn_columns.sql": importstr "scd/upto-v3.2.0-add_ovn_columns.sql",
+    "upto-v3.3.0-create_notification_outbox.sql": importstr "scd/upto-v3.3.0-create_notification_outbox.sql",
+    "upto-v3.4.0-create_audit_log.sql": importstr "scd/upto-v3.4.0-create_audit_log.sql",
+    "upto-v3.5.0-create_dss_reports.sql": importstr "scd/upto-v3.5.0-create_dss_reports.sql",
+    "upto-v3.6.0-create_volumes.sql": importstr "scd/upto-v3.6.0-create_volumes.sql",
+    "upto-v3.7.0-add_subscription_altitudes.sql": importstr "scd/upto-v3.7.0-add_subscription_altitudes.sql",
+    "upto-v3.8.0-add_footprints.sql": importstr "scd/upto-v3.8.0-add_footprints.sql",
+    "downfrom-v3.8.0-remove_footprints.sql": importstr "scd/downfrom-v3.8.0-remove_footprints.sql",
+    "downfrom-v3.7.0-remove_subscription_altitudes.sql": importstr "scd/downfrom-v3.7.0-remove_subscription_altitudes.sql",
+    "downfrom-v3.6.0-remove_volumes.sql": importstr "scd/downfrom-v3.6.0-remove_volumes.sql",
+    "downfrom-v3.5.0-remove_dss_reports.sql": importstr "scd/downfrom-v3.5.0-remove_dss_reports.sql",
+    "downfrom-v3.4.0-remove_audit_log.sql": importstr "scd/downfrom-v3.4.0-remove_audit_log.sql",
+    "downfrom-v3.3.0-remove_notification_outbox.sql": importstr "scd/downfrom-v3.3.0-remove_notification_outbox.sql",
     "downfrom-v3.2.0-remove_ovn_columns.sql": importstr "scd/downfrom-v3.2.0-remove_ovn_columns.sql",
     "downfrom-v3.1.0-remove_uss_availability.sql": importstr "scd/downfrom-v3.1.0-remove_uss_availability.sql",
     "downfrom-v3.0.0-remove_inverted_indices.sql": importstr "scd/downfrom-v3.0.0-remove_inverted_indices.sql",
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_outbox;
+UPDATE schema_versions set schema_version = 'v3.2.0' WHERE onerow_enforcer = TRUE;
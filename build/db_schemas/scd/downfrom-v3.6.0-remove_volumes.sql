@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS scd_constraint_volumes;
+DROP TABLE IF EXISTS scd_operation_volumes;
+UPDATE schema_versions set schema_version = 'v3.5.0' WHERE onerow_enforcer = TRUE;
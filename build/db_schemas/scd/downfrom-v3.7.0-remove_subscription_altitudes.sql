@@ -0,0 +1,4 @@
+ALTER TABLE scd_subscriptions DROP COLUMN IF EXISTS altitude_upper;
+ALTER TABLE scd_subscriptions DROP COLUMN IF EXISTS altitude_lower;
+
+UPDATE schema_versions set schema_version = 'v3.6.0' WHERE onerow_enforcer = TRUE;
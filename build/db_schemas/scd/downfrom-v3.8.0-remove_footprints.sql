@@ -0,0 +1,5 @@
+ALTER TABLE scd_subscriptions DROP COLUMN IF EXISTS footprint;
+ALTER TABLE scd_constraint_volumes DROP COLUMN IF EXISTS footprint;
+ALTER TABLE scd_operation_volumes DROP COLUMN IF EXISTS footprint;
+
+UPDATE schema_versions set schema_version = 'v3.7.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  entity_type STRING NOT NULL,
+  entity_id UUID NOT NULL,
+  operation STRING NOT NULL,
+  manager STRING NOT NULL,
+  old_version STRING,
+  new_version STRING,
+  cells INT64[],
+  recorded_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_log_entity_id_idx ON audit_log (entity_id, recorded_at);
+CREATE INDEX IF NOT EXISTS audit_log_manager_idx ON audit_log (manager, recorded_at);
+CREATE INDEX IF NOT EXISTS audit_log_recorded_at_idx ON audit_log (recorded_at);
+
+UPDATE schema_versions set schema_version = 'v3.4.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS scd_dss_reports (
+  id UUID PRIMARY KEY,
+  reporter STRING NOT NULL,
+  report JSONB NOT NULL,
+  received_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS scd_dss_reports_received_at_idx ON scd_dss_reports (received_at);
+CREATE INDEX IF NOT EXISTS scd_dss_reports_reporter_idx ON scd_dss_reports (reporter, received_at);
+
+UPDATE schema_versions set schema_version = 'v3.5.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS scd_operation_volumes (
+  operation_id UUID NOT NULL REFERENCES scd_operations (id) ON DELETE CASCADE,
+  volume_index INT4 NOT NULL,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  cells INT64[] NOT NULL,
+  PRIMARY KEY (operation_id, volume_index),
+  INVERTED INDEX cells_idx (cells),
+  CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at <= ends_at)
+);
+CREATE TABLE IF NOT EXISTS scd_constraint_volumes (
+  constraint_id UUID NOT NULL REFERENCES scd_constraints (id) ON DELETE CASCADE,
+  volume_index INT4 NOT NULL,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  cells INT64[] NOT NULL,
+  PRIMARY KEY (constraint_id, volume_index),
+  INVERTED INDEX cells_idx (cells),
+  CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at <= ends_at)
+);
+
+UPDATE schema_versions set schema_version = 'v3.6.0' WHERE onerow_enforcer = TRUE;
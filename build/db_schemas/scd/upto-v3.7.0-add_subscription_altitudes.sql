@@ -0,0 +1,4 @@
+ALTER TABLE scd_subscriptions ADD COLUMN IF NOT EXISTS altitude_lower REAL;
+ALTER TABLE scd_subscriptions ADD COLUMN IF NOT EXISTS altitude_upper REAL;
+
+UPDATE schema_versions set schema_version = 'v3.7.0' WHERE onerow_enforcer = TRUE;
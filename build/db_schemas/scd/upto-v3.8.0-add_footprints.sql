@@ -0,0 +1,5 @@
+ALTER TABLE scd_operation_volumes ADD COLUMN IF NOT EXISTS footprint JSONB;
+ALTER TABLE scd_constraint_volumes ADD COLUMN IF NOT EXISTS footprint JSONB;
+ALTER TABLE scd_subscriptions ADD COLUMN IF NOT EXISTS footprint JSONB;
+
+UPDATE schema_versions set schema_version = 'v3.8.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/dss/pkg/auth"
+	"github.com/interuss/dss/pkg/config"
+	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/metrics"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// adminHandler serves the runtime controls of core-service on the admin
+// listener, which must not be exposed publicly as it requires no
+// authorization.
+type adminHandler struct {
+	logger *zap.Logger
+	mux    *http.ServeMux
+	// authorizer is set once access token verification is configured.
+	authorizer *auth.Authorizer
+
+	mu sync.Mutex
+	// garbageCollectors are the garbage collector jobs scheduled by this
+	// instance, indexed by name.
+	garbageCollectors map[string]cron.Job
+}
+
+func newAdminHandler(logger *zap.Logger) *adminHandler {
+	a := &adminHandler{
+		logger:            logger,
+		mux:               http.NewServeMux(),
+		garbageCollectors: map[string]cron.Job{},
+	}
+	a.mux.HandleFunc("/debug/pprof/", pprof.Index)
+	a.mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	a.mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	a.mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	a.mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	a.mux.HandleFunc("/config", a.serveConfig)
+	a.mux.Handle("/metrics", metrics.Handler())
+	// The level handler reads the level with GET, and sets it with PUT and a
+	// body such as {"level":"debug"}.
+	a.mux.Handle("/log_level", logging.DefaultLevel)
+	a.mux.HandleFunc("/garbage_collection", a.serveGarbageCollection)
+	a.mux.HandleFunc("/keys/refresh", a.serveKeyRefresh)
+	return a
+}
+
+// addGarbageCollector registers the garbage collector job, so that it may be
+// triggered manually. job should be wrapped like its scheduled runs, so that
+// it is skipped while this instance is not the leader of the job or while the
+// job is still running. a may be nil if the admin listener is disabled.
+func (a *adminHandler) addGarbageCollector(name string, job cron.Job) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.garbageCollectors[name] = job
+}
+
+// ServeHTTP implements http.Handler.
+func (a *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mux.ServeHTTP(w, r)
+}
+
+// serveConfig responds with the effective value of every command line flag,
+// whether set on the command line, by environment variable, by the
+// configuration file or by default.
+func (a *adminHandler) serveConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		api.WriteJSON(w, http.StatusMethodNotAllowed, errorBody("Only GET is supported"))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, config.FlagValues(flag.CommandLine))
+}
+
+// serveGarbageCollection runs all the garbage collectors of this instance, and
+// responds once they have run. Like scheduled runs, their outcome is logged
+// and recorded in the metrics, and they are skipped while this instance is not
+// their leader or while they are still running.
+func (a *adminHandler) serveGarbageCollection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		api.WriteJSON(w, http.StatusMethodNotAllowed, errorBody("Only POST is supported"))
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ran := make([]string, 0, len(a.garbageCollectors))
+	for name, job := range a.garbageCollectors {
+		a.logger.Info("Running garbage collector on request", zap.String("job", name))
+		job.Run()
+		ran = append(ran, name)
+	}
+	api.WriteJSON(w, http.StatusOK, map[string][]string{"ran": ran})
+}
+
+// serveKeyRefresh resolves again the keys verifying access tokens.
+func (a *adminHandler) serveKeyRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		api.WriteJSON(w, http.StatusMethodNotAllowed, errorBody("Only POST is supported"))
+		return
+	}
+	if a.authorizer == nil {
+		api.WriteJSON(w, http.StatusServiceUnavailable, errorBody("Access tokens are not verified yet"))
+		return
+	}
+	if err := a.authorizer.RefreshKeys(r.Context()); err != nil {
+		a.logger.Warn("Failed to refresh keys on request", zap.Error(err))
+		api.WriteJSON(w, http.StatusBadGateway, errorBody(err.Error()))
+		return
+	}
+	a.logger.Info("Refreshed keys on request")
+	api.WriteJSON(w, http.StatusOK, api.EmptyResponseBody{})
+}
+
+func errorBody(message string) api.InternalServerErrorBody {
+	return api.InternalServerErrorBody{ErrorMessage: message}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/leader"
+	"github.com/interuss/dss/pkg/leader/memory"
+	"github.com/interuss/dss/pkg/logging"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func serveAdmin(a *adminHandler, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	a.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestAdminConfig(t *testing.T) {
+	a := newAdminHandler(zap.NewNop())
+
+	w := serveAdmin(a, http.MethodGet, "/config", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var config map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &config))
+	require.Equal(t, ":8080", config["addr"])
+
+	w = serveAdmin(a, http.MethodPost, "/config", "")
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestAdminMetrics(t *testing.T) {
+	a := newAdminHandler(zap.NewNop())
+
+	w := serveAdmin(a, http.MethodGet, "/metrics", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "go_goroutines")
+}
+
+func TestAdminLogLevel(t *testing.T) {
+	a := newAdminHandler(zap.NewNop())
+	defer logging.DefaultLevel.SetLevel(logging.DefaultLevel.Level())
+
+	w := serveAdmin(a, http.MethodPut, "/log_level", `{"level":"debug"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, logging.Logger.Core().Enabled(zapcore.DebugLevel))
+
+	w = serveAdmin(a, http.MethodPut, "/log_level", `{"level":"warn"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.False(t, logging.Logger.Core().Enabled(zapcore.InfoLevel))
+}
+
+func TestAdminGarbageCollection(t *testing.T) {
+	a := newAdminHandler(zap.NewNop())
+	runs := 0
+	a.addGarbageCollector("test_garbage_collector", cron.FuncJob(func() { runs++ }))
+
+	w := serveAdmin(a, http.MethodGet, "/garbage_collection", "")
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	require.Equal(t, 0, runs)
+
+	w = serveAdmin(a, http.MethodPost, "/garbage_collection", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 1, runs)
+	require.JSONEq(t, `{"ran": ["test_garbage_collector"]}`, w.Body.String())
+
+	// Garbage collectors are not registered when the admin listener is
+	// disabled.
+	var disabled *adminHandler
+	disabled.addGarbageCollector("test_garbage_collector", cron.FuncJob(func() { runs++ }))
+}
+
+func TestAdminGarbageCollectionOnlyIfLeader(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		store   = memory.NewLeaseStore()
+		elector = &leader.Elector{Store: store, Holder: "instance", LeaseDuration: time.Hour}
+		a       = newAdminHandler(zap.NewNop())
+		runs    = 0
+	)
+	c := cron.New()
+	require.NoError(t, scheduleGarbageCollector(ctx, c, "@hourly", "test_garbage_collector", cron.FuncJob(func() { runs++ }), elector, a, cron.DiscardLogger))
+
+	held, err := store.AcquireLease(ctx, "test_garbage_collector", "other instance", time.Hour)
+	require.NoError(t, err)
+	require.True(t, held)
+	w := serveAdmin(a, http.MethodPost, "/garbage_collection", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 0, runs)
+
+	require.NoError(t, store.ReleaseLease(ctx, "test_garbage_collector", "other instance"))
+	w = serveAdmin(a, http.MethodPost, "/garbage_collection", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 1, runs)
+}
+
+func TestAdminKeyRefreshWithoutAuthorizer(t *testing.T) {
+	a := newAdminHandler(zap.NewNop())
+
+	w := serveAdmin(a, http.MethodPost, "/keys/refresh", "")
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
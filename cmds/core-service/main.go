@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/profiler"
+	"github.com/google/uuid"
 	"github.com/interuss/dss/pkg/api"
 	apiauxv1 "github.com/interuss/dss/pkg/api/auxv1"
 	apiridv1 "github.com/interuss/dss/pkg/api/ridv1"
@@ -24,15 +25,30 @@ import (
 	"github.com/interuss/dss/pkg/auth"
 	aux "github.com/interuss/dss/pkg/aux_"
 	"github.com/interuss/dss/pkg/build"
+	"github.com/interuss/dss/pkg/config"
 	"github.com/interuss/dss/pkg/datastore"
 	"github.com/interuss/dss/pkg/datastore/flags" // Force command line flag registration
+	"github.com/interuss/dss/pkg/leader"
 	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/metrics"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
+	"github.com/interuss/dss/pkg/ratelimit"
+	ratelimitm "github.com/interuss/dss/pkg/ratelimit/memory"
 	"github.com/interuss/dss/pkg/rid/application"
+	ridapiv1 "github.com/interuss/dss/pkg/rid/models/api/v1"
+	ridapiv2 "github.com/interuss/dss/pkg/rid/models/api/v2"
 	rid_v1 "github.com/interuss/dss/pkg/rid/server/v1"
 	rid_v2 "github.com/interuss/dss/pkg/rid/server/v2"
+	ridstore "github.com/interuss/dss/pkg/rid/store"
 	ridc "github.com/interuss/dss/pkg/rid/store/cockroach"
+	ridm "github.com/interuss/dss/pkg/rid/store/memory"
 	"github.com/interuss/dss/pkg/scd"
+	scdstore "github.com/interuss/dss/pkg/scd/store"
 	scdc "github.com/interuss/dss/pkg/scd/store/cockroach"
+	scdm "github.com/interuss/dss/pkg/scd/store/memory"
+	dsstls "github.com/interuss/dss/pkg/tls"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/dss/pkg/version"
 	"github.com/interuss/dss/pkg/versioning"
 	"github.com/interuss/stacktrace"
@@ -41,30 +57,110 @@ import (
 )
 
 var (
+	configFile        = flag.String("config_file", "", "Path to a YAML or JSON file setting any of these flags by name; flags set on the command line or by their DSS_<FLAG NAME> environment variable take precedence")
+	printConfig       = flag.Bool("print_config", false, "Prints the effective configuration in the format of config_file and exits")
 	address           = flag.String("addr", ":8080", "Local address that the service binds to and listens on for incoming connections")
+	adminAddress      = flag.String("admin_addr", "", "Local address of the admin listener serving pprof, the Prometheus metrics, the effective configuration, the log level and manual garbage collection and key refresh triggers without authorization; must not be exposed publicly. Disabled if empty")
+	publicMetrics     = flag.Bool("public_metrics", false, "Also serves the Prometheus metrics at /metrics on the public address, without authorization; they are otherwise only served by the admin listener")
 	enableSCD         = flag.Bool("enable_scd", false, "Enables the Strategic Conflict Detection API")
 	allowHTTPBaseUrls = flag.Bool("allow_http_base_urls", false, "Enables http scheme for Strategic Conflict Detection API")
 	enableHTTP        = flag.Bool("enable_http", false, "DEPRECATED (replaced by allow_http_base_urls): Enables http scheme for Strategic Conflict Detection API")
-	timeout           = flag.Duration("server timeout", 10*time.Second, "Default timeout for server calls")
+	timeout           = flag.Duration("server_timeout", 10*time.Second, "Default timeout for server calls")
 	locality          = flag.String("locality", "", "self-identification string used as CRDB table writer column")
+	datastoreType     = flag.String("datastore", datastoreTypeSQL, "Backing store for remote ID and strategic conflict detection data in {sql, memory}; memory keeps all data in process memory and is intended only for development and testing")
+	maxResultLimit    = flag.Int("max_result_limit", dssmodels.MaxResultLimit, "Maximum number of entities returned by a search; responses to searches exceeding it carry the "+api.TruncatedResultsHeader+" header")
+	exactGeometry     = flag.Bool("exact_geometry", false, "Refines the S2 cell-based searches of ISAs, operational intents and constraints by comparing the outlines submitted for them with the searched area")
+	validateRequests  = flag.String("validate_requests", "", "Comma-separated APIs in {aux_v1, versioning_v1, rid_v1, rid_v2, scd_v1} whose requests are validated against their OpenAPI schema before reaching the handlers; requests violating it are rejected with 400 listing every violation")
 
 	logFormat            = flag.String("log_format", logging.DefaultFormat, "The log format in {json, console}")
 	logLevel             = flag.String("log_level", logging.DefaultLevel.String(), "The log level")
 	dumpRequests         = flag.Bool("dump_requests", false, "Log full HTTP request and response (note: will dump sensitive information to logs; intended only for debugging and/or development)")
+	logRedactedHeaders   = flag.String("log_redacted_headers", strings.Join(logging.DefaultHTTPConfiguration.RedactedHeaders, ","), "Comma-separated names of the request headers whose value is redacted from logs")
+	logRedactedFields    = flag.String("log_redacted_fields", strings.Join(logging.DefaultHTTPConfiguration.RedactedFields, ","), "Comma-separated names of the JSON fields whose value is redacted from the requests and responses logged with dump_requests")
+	logSampleRatios      = flag.String("log_sample_ratios", "", "Comma-separated <route path template>=<ratio> pairs setting the fraction of the requests of a route which are logged, e.g. /rid/v2/dss/identification_service_areas=0.01; the requests of other routes and the requests failing with a server error are all logged")
+	logMinDuration       = flag.Duration("log_min_duration", 0, "Duration below which requests are not logged unless they fail with a server error; 0 logs all requests")
 	profServiceName      = flag.String("gcp_prof_service_name", "", "Service name for the Go profiler")
+	traceExporter        = flag.String("trace_exporter", tracing.ExporterNone, "OpenTelemetry trace exporter in {none, stdout, file, otlp}; otlp is configured with the standard OTEL_EXPORTER_OTLP_* environment variables")
+	traceFile            = flag.String("trace_file", "traces.json", "Path of the file traces are appended to when trace_exporter is file")
+	traceSamplingRatio   = flag.Float64("trace_sampling_ratio", 1, "Fraction of traces started by this service that are sampled, between 0 and 1")
 	garbageCollectorSpec = flag.String("garbage_collector_spec", "@every 30m", "Garbage collector schedule. The value must follow robfig/cron format. See https://godoc.org/github.com/robfig/cron#hdr-Usage for more detail.")
 
+	enableLeaderElection = flag.Bool("enable_leader_election", false, "Elects, through leases in the remote ID database, a single instance of the DSS pool to run the garbage collectors; the elected instance collects the expired remote ID records of all writers")
+	leaderLeaseDuration  = flag.Duration("leader_lease_duration", time.Hour, "Time for which an instance remains the leader of a periodic job after running it; should exceed the period of the jobs")
+
+	rateLimits                 = flag.String("rate_limits", "", "Comma-separated <class>=<rate>[:<burst>] pairs limiting the requests per second of each client to the routes of the classes {read, write, search}, e.g. read=20:40,write=5,search=2; requests beyond the limit are rejected with 429 and the requests of classes without limit are not limited")
+	rateLimitSharedState       = flag.Bool("rate_limit_shared_state", false, "Keeps the rate limit buckets in the remote ID database, so that the DSS pool enforces rate_limits together instead of each instance separately")
+	loadSheddingPoolSaturation = flag.Float64("load_shedding_pool_saturation", 0, "Fraction of the connections of a database pool, between 0 and 1, which once acquired causes requests to be rejected with 503 until connections are released; 0 disables load shedding")
+
 	pkFile            = flag.String("public_key_files", "", "Path to public Keys to use for JWT decoding, separated by commas.")
 	jwksEndpoint      = flag.String("jwks_endpoint", "", "URL pointing to an endpoint serving JWKS")
 	jwksKeyIDs        = flag.String("jwks_key_ids", "", "IDs of a set of key in a JWKS, separated by commas")
 	keyRefreshTimeout = flag.Duration("key_refresh_timeout", 1*time.Minute, "Timeout for refreshing keys for JWT verification")
 	jwtAudiences      = flag.String("accepted_jwt_audiences", "", "comma-separated acceptable JWT `aud` claims")
+	jwtIssuersFile    = flag.String("jwt_issuers_file", "", "Path to a JSON file configuring the keys, accepted audiences and allowed scopes of each accepted JWT issuer")
+
+	enableTokenRevocation          = flag.Bool("enable_token_revocation", false, "Rejects the access tokens revoked through the auxiliary API")
+	tokenRevocationRefreshInterval = flag.Duration("token_revocation_refresh_interval", 10*time.Second, "Period at which the access token revocations are reloaded from the remote ID database")
+	tokenRevocationMaxStaleness    = flag.Duration("token_revocation_max_staleness", 0, "Age of the last successfully reloaded access token revocations beyond which all access tokens are rejected; 0 keeps accepting tokens not revoked as of the last reload")
+
+	tlsCertFile                   = flag.String("tls_cert_file", "", "Path to the PEM certificate chain of the DSS; the DSS serves TLS instead of plain HTTP when set. Reloaded when modified, see tls_reload_interval")
+	tlsKeyFile                    = flag.String("tls_key_file", "", "Path to the PEM private key of the certificate of the DSS. Reloaded when modified, see tls_reload_interval")
+	tlsClientCAFile               = flag.String("tls_client_ca_file", "", "Path to the PEM certificate authorities verifying the client certificates presented to the DSS; client certificates are not requested if empty. Reloaded when modified, see tls_reload_interval")
+	tlsRequireClientCert          = flag.Bool("tls_require_client_cert", false, "Rejects the connections of clients not presenting a certificate verified by the certificate authorities of tls_client_ca_file")
+	tlsReloadInterval             = flag.Duration("tls_reload_interval", dsstls.DefaultReloadInterval, "Period at which the TLS certificate, key and client certificate authorities files are checked for modifications")
+	clientCertificateBindingsFile = flag.String("client_certificate_bindings_file", "", "Path to a JSON file binding access token subjects to the identities of the client certificates they must be presented with")
+
+	enableNotifications           = flag.Bool("enable_notifications", false, "Enables the delivery by the DSS of the notifications of ISA, operational intent and constraint changes to subscribed USSs")
+	notificationDeliverySpec      = flag.String("notification_delivery_spec", "@every 1s", "Schedule of the delivery of pending notifications. The value must follow robfig/cron format.")
+	notificationMaxAttempts       = flag.Int("notification_max_attempts", notifications.DefaultConfiguration.MaxAttempts, "Number of failed attempts after which a notification is dropped")
+	notificationRateLimit         = flag.Float64("notification_rate_limit", notifications.DefaultConfiguration.RateLimit, "Maximum sustained number of notifications per second sent to each USS; 0 disables rate limiting")
+	notificationRateBurst         = flag.Int("notification_rate_burst", notifications.DefaultConfiguration.RateBurst, "Maximum number of notifications sent to a USS at once")
+	notificationTokenEndpoint     = flag.String("notification_token_endpoint", "", "OAuth token endpoint from which the access tokens presented to USSs are obtained with the client credentials grant; notifications are sent without access token if empty")
+	notificationClientID          = flag.String("notification_client_id", "", "OAuth client ID of the DSS for notification access tokens")
+	notificationClientSecretFile  = flag.String("notification_client_secret_file", "", "Path to the file containing the OAuth client secret of the DSS for notification access tokens")
+	notificationAudienceParameter = flag.String("notification_audience_parameter", "audience", "Name of the token request parameter carrying the host of the notified USS")
+
+	scdMaxActiveOperationalIntents  = flag.Int("scd_max_active_operational_intents", 0, "Maximum number of operational intents of a manager which have not ended yet; 0 sets no limit")
+	scdMaxSubscriptionsPerCell      = flag.Int("scd_max_subscriptions_per_cell", 0, "Maximum number of active strategic conflict detection subscriptions of a manager in any single S2 cell; 0 sets no limit")
+	scdMaxOperationalIntentsAreaKm2 = flag.Float64("scd_max_operational_intents_area_km2", 0, "Maximum area in km² covered by the active operational intents of a manager altogether; 0 sets no limit")
+	scdQuotaOverridesFile           = flag.String("scd_quota_overrides_file", "", "Path to a JSON file overriding the strategic conflict detection quotas of specific managers")
+
+	scdGarbageCollectorSpec      = flag.String("scd_garbage_collector_spec", "@every 30m", "Strategic conflict detection garbage collector schedule. The value must follow robfig/cron format; empty disables the garbage collector.")
+	scdGarbageCollectorTTL       = flag.Duration("scd_garbage_collector_ttl", time.Hour*24*112, "Time after their end (or last update if they have no end time) after which operational intents, subscriptions and constraints are deleted by the garbage collector")
+	scdGarbageCollectorBatchSize = flag.Int("scd_garbage_collector_batch_size", 1000, "Maximum number of entities deleted by the strategic conflict detection garbage collector in a single transaction")
 )
 
 const (
 	codeRetryable = stacktrace.ErrorCode(1)
+
+	datastoreTypeSQL    = "sql"
+	datastoreTypeMemory = "memory"
 )
 
+// validatedAPIs are the names of the APIs accepted by --validate_requests.
+var validatedAPIs = []string{"aux_v1", "versioning_v1", "rid_v1", "rid_v2", "scd_v1"}
+
+// parseValidatedAPIs parses the comma-separated API names of
+// --validate_requests.
+func parseValidatedAPIs(s string) (map[string]bool, error) {
+	apis := map[string]bool{}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, validatedAPI := range validatedAPIs {
+			known = known || name == validatedAPI
+		}
+		if !known {
+			return nil, stacktrace.NewError("Unknown API %s, must be one of {%s}", name, strings.Join(validatedAPIs, ", "))
+		}
+		apis[name] = true
+	}
+	return apis, nil
+}
+
 func getDBStats(ctx context.Context, db *datastore.Datastore, databaseName string) {
 	logger := logging.WithValuesFromContext(ctx, logging.Logger)
 	statsPtr := db.Pool.Stat()
@@ -106,16 +202,16 @@ func createKeyResolver() (auth.KeyResolver, error) {
 	}
 }
 
-func createRIDServers(ctx context.Context, locality string, logger *zap.Logger) (*rid_v1.Server, *rid_v2.Server, error) {
+func connectRIDStore(ctx context.Context, logger *zap.Logger, ridCron *cron.Cron) (*ridc.Store, error) {
 	connectParameters := flags.ConnectParameters()
 	connectParameters.DBName = "rid"
 	ridCrdb, err := datastore.Dial(ctx, connectParameters)
 	if err != nil {
 		// TODO: More robustly detect failure to create RID server is due to a problem that may be temporary
 		if strings.Contains(err.Error(), "connect: connection refused") {
-			return nil, nil, stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to CRDB server for remote ID store")
+			return nil, stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to CRDB server for remote ID store")
 		}
-		return nil, nil, stacktrace.Propagate(err, "Failed to connect to remote ID database; verify your database configuration is current with https://github.com/interuss/dss/tree/master/build#upgrading-database-schemas")
+		return nil, stacktrace.Propagate(err, "Failed to connect to remote ID database; verify your database configuration is current with https://github.com/interuss/dss/tree/master/build#upgrading-database-schemas")
 	}
 
 	ridStore, err := ridc.NewStore(ctx, ridCrdb, connectParameters.DBName, logger)
@@ -123,57 +219,123 @@ func createRIDServers(ctx context.Context, locality string, logger *zap.Logger)
 		// try DBName of defaultdb for older versions.
 		ridCrdb.Pool.Close()
 		connectParameters.DBName = "defaultdb"
-		ridCrdb, err := datastore.Dial(ctx, connectParameters)
+		ridCrdb, err = datastore.Dial(ctx, connectParameters)
 		if err != nil {
-			return nil, nil, stacktrace.Propagate(err, "Failed to connect to remote ID database for older version <defaultdb>; verify your database configuration is current with https://github.com/interuss/dss/tree/master/build#upgrading-database-schemas")
+			return nil, stacktrace.Propagate(err, "Failed to connect to remote ID database for older version <defaultdb>; verify your database configuration is current with https://github.com/interuss/dss/tree/master/build#upgrading-database-schemas")
 		}
 		ridStore, err = ridc.NewStore(ctx, ridCrdb, connectParameters.DBName, logger)
 		if err != nil {
 			// TODO: More robustly detect failure to create RID server is due to a problem that may be temporary
 			if strings.Contains(err.Error(), "connect: connection refused") || strings.Contains(err.Error(), "database has not been bootstrapped with Schema Manager") {
 				ridCrdb.Pool.Close()
-				return nil, nil, stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to CRDB server for remote ID store")
+				return nil, stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to CRDB server for remote ID store")
 			}
-			return nil, nil, stacktrace.Propagate(err, "Failed to create remote ID store")
+			return nil, stacktrace.Propagate(err, "Failed to create remote ID store")
 		}
 	}
 
-	repo, err := ridStore.Interact(ctx)
-	if err != nil {
-		return nil, nil, stacktrace.Propagate(err, "Unable to interact with store")
+	metrics.ObservePool(connectParameters.DBName, ridCrdb.Pool)
+	ratelimit.ObservePool(connectParameters.DBName, ridCrdb.Pool)
+
+	// schedule printing of DB connection stats every minute for the underlying storage for RID Server
+	if _, err := ridCron.AddFunc("@every 1m", func() { getDBStats(ctx, ridCrdb, connectParameters.DBName) }); err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to schedule periodic db stat check to %s", connectParameters.DBName)
 	}
-	gc := ridc.NewGarbageCollector(repo, locality)
 
+	return ridStore, nil
+}
+
+// createRIDServers returns the remote ID servers, along with their store which
+// also keeps the access token revocations.
+//
+// If elector is not nil, its lease store is set to the remote ID store and the
+// garbage collector only runs while this instance is its leader.
+func createRIDServers(ctx context.Context, locality string, elector *leader.Elector, admin *adminHandler, logger *zap.Logger) (*rid_v1.Server, *rid_v2.Server, ridstore.Store, error) {
 	// schedule period tasks for RID Server
 	ridCron := cron.New()
-	// schedule printing of DB connection stats every minute for the underlying storage for RID Server
-	if _, err := ridCron.AddFunc("@every 1m", func() { getDBStats(ctx, ridCrdb, connectParameters.DBName) }); err != nil {
-		return nil, nil, stacktrace.Propagate(err, "Failed to schedule periodic db stat check to %s", connectParameters.DBName)
+
+	var ridStore ridstore.Store
+	switch *datastoreType {
+	case datastoreTypeMemory:
+		logger.Warn("remote ID data is kept in memory and will be lost on restart")
+		ridStore = ridm.NewStore()
+	default:
+		ridcStore, err := connectRIDStore(ctx, logger, ridCron)
+		if err != nil {
+			return nil, nil, nil, err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		ridStore = ridcStore
 	}
 
+	repo, err := ridStore.Interact(ctx)
+	if err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Unable to interact with store")
+	}
+	writer := &locality
+	if elector != nil {
+		leases, ok := ridStore.(leader.LeaseStore)
+		if !ok {
+			return nil, nil, nil, stacktrace.NewError("Remote ID store of type %T does not keep job leases", ridStore)
+		}
+		elector.Store = leases
+		writer = nil
+	}
+	gc := ridc.NewGarbageCollector(repo, writer)
+
+	gcJob := RIDGarbageCollectorJob{"delete rid expired records", *gc, ctx}
 	cronLogger := cron.VerbosePrintfLogger(log.New(os.Stdout, "RIDGarbageCollectorJob: ", log.LstdFlags))
-	if _, err = ridCron.AddJob(*garbageCollectorSpec, cron.NewChain(cron.SkipIfStillRunning(cronLogger)).Then(RIDGarbageCollectorJob{"delete rid expired records", *gc, ctx})); err != nil {
-		return nil, nil, stacktrace.Propagate(err, "Failed to schedule periodic delete rid expired records to %s", connectParameters.DBName)
+	if err := scheduleGarbageCollector(ctx, ridCron, *garbageCollectorSpec, "rid_garbage_collector", gcJob, elector, admin, cronLogger); err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Failed to schedule periodic delete rid expired records")
+	}
+
+	if *rateLimitSharedState {
+		buckets, ok := ridStore.(idleBucketStore)
+		if !ok {
+			return nil, nil, nil, stacktrace.NewError("Remote ID store of type %T does not keep rate limit buckets", ridStore)
+		}
+		if err := scheduleIdleBucketDeletion(ctx, ridCron, elector, admin, buckets); err != nil {
+			return nil, nil, nil, stacktrace.Propagate(err, "Failed to schedule deletion of idle rate limit buckets")
+		}
+	}
+
+	var (
+		appV1 = application.NewFromTransactor(ridStore, logger)
+		appV2 = appV1
+	)
+	if *enableNotifications {
+		// Notifications follow the API version used to change the ISA.
+		appV1 = application.NewFromTransactorWithNotifications(ridStore, logger, ridapiv1.MakeISANotifications)
+		appV2 = application.NewFromTransactorWithNotifications(ridStore, logger, ridapiv2.MakeISANotifications)
+		outbox, ok := ridStore.(notifications.Outbox)
+		if !ok {
+			return nil, nil, nil, stacktrace.NewError("Remote ID store of type %T does not keep a notification outbox", ridStore)
+		}
+		if err := scheduleNotificationDelivery(ctx, ridCron, "rid", outbox, logger); err != nil {
+			return nil, nil, nil, stacktrace.Propagate(err, "Failed to schedule delivery of remote ID notifications")
+		}
 	}
 	ridCron.Start()
 
-	app := application.NewFromTransactor(ridStore, logger)
 	return &rid_v1.Server{
-			App:               app,
-			Timeout:           *timeout,
-			Locality:          locality,
-			AllowHTTPBaseUrls: *allowHTTPBaseUrls,
-			Cron:              ridCron,
-		}, &rid_v2.Server{
-			App:               app,
-			Timeout:           *timeout,
-			Locality:          locality,
-			AllowHTTPBaseUrls: *allowHTTPBaseUrls,
-			Cron:              ridCron,
-		}, nil
+		App:               appV1,
+		Timeout:           *timeout,
+		Locality:          locality,
+		AllowHTTPBaseUrls: *allowHTTPBaseUrls,
+		Cron:              ridCron,
+		ExactGeometry:     *exactGeometry,
+		MaxResultLimit:    *maxResultLimit,
+	}, &rid_v2.Server{
+		App:               appV2,
+		Timeout:           *timeout,
+		Locality:          locality,
+		AllowHTTPBaseUrls: *allowHTTPBaseUrls,
+		Cron:              ridCron,
+		ExactGeometry:     *exactGeometry,
+		MaxResultLimit:    *maxResultLimit,
+	}, ridStore, nil
 }
 
-func createSCDServer(ctx context.Context, logger *zap.Logger) (*scd.Server, error) {
+func connectSCDStore(ctx context.Context, scdCron *cron.Cron) (*scdc.Store, error) {
 	connectParameters := flags.ConnectParameters()
 	connectParameters.DBName = scdc.DatabaseName
 	scdCrdb, err := datastore.Dial(ctx, connectParameters)
@@ -191,21 +353,186 @@ func createSCDServer(ctx context.Context, logger *zap.Logger) (*scd.Server, erro
 		return nil, stacktrace.Propagate(err, "Failed to create strategic conflict detection store")
 	}
 
-	// schedule period tasks for SCD Server
-	scdCron := cron.New()
-	// schedule printing of DB connection stats every minute for the underlying storage for RID Server
+	metrics.ObservePool(scdc.DatabaseName, scdCrdb.Pool)
+	ratelimit.ObservePool(scdc.DatabaseName, scdCrdb.Pool)
+
+	// schedule printing of DB connection stats every minute for the underlying storage for SCD Server
 	if _, err := scdCron.AddFunc("@every 1m", func() { getDBStats(ctx, scdCrdb, scdc.DatabaseName) }); err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to schedule periodic db stat check to %s", scdc.DatabaseName)
 	}
 
+	return scdStore, nil
+}
+
+func createSCDServer(ctx context.Context, elector *leader.Elector, admin *adminHandler, logger *zap.Logger) (*scd.Server, *cron.Cron, error) {
+	// schedule period tasks for SCD Server
+	scdCron := cron.New()
+
+	var scdStore scdstore.Store
+	switch *datastoreType {
+	case datastoreTypeMemory:
+		logger.Warn("strategic conflict detection data is kept in memory and will be lost on restart")
+		scdStore = scdm.NewStore()
+	default:
+		scdcStore, err := connectSCDStore(ctx, scdCron)
+		if err != nil {
+			return nil, nil, err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		scdStore = scdcStore
+	}
+
+	if *enableNotifications {
+		outbox, ok := scdStore.(notifications.Outbox)
+		if !ok {
+			return nil, nil, stacktrace.NewError("Strategic conflict detection store of type %T does not keep a notification outbox", scdStore)
+		}
+		if err := scheduleNotificationDelivery(ctx, scdCron, "scd", outbox, logger); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "Failed to schedule delivery of strategic conflict detection notifications")
+		}
+	}
+	if *scdGarbageCollectorSpec != "" {
+		gc := scdc.NewGarbageCollector(scdStore, *scdGarbageCollectorTTL, *scdGarbageCollectorBatchSize)
+
+		gcJob := SCDGarbageCollectorJob{"delete scd expired records", *gc, ctx}
+		cronLogger := cron.VerbosePrintfLogger(log.New(os.Stdout, "SCDGarbageCollectorJob: ", log.LstdFlags))
+		if err := scheduleGarbageCollector(ctx, scdCron, *scdGarbageCollectorSpec, "scd_garbage_collector", gcJob, elector, admin, cronLogger); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "Failed to schedule periodic delete scd expired records")
+		}
+	}
+	quotas, err := loadSCDQuotas()
+	if err != nil {
+		return nil, nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
 	scdCron.Start()
 
 	return &scd.Server{
 		Store:             scdStore,
-		DSSReportHandler:  &scd.JSONLoggingReceivedReportHandler{ReportLogger: logger},
+		DSSReportHandler:  &scd.StoredReceivedReportHandler{Store: scdStore},
 		Timeout:           *timeout,
 		AllowHTTPBaseUrls: *allowHTTPBaseUrls,
-	}, nil
+		NotifySubscribers: *enableNotifications,
+		Quotas:            quotas,
+		ExactGeometry:     *exactGeometry,
+		MaxResultLimit:    *maxResultLimit,
+	}, scdCron, nil
+}
+
+// idleBucketStore is a ratelimit.BucketStore whose buckets must be deleted
+// once idle.
+type idleBucketStore interface {
+	ratelimit.BucketStore
+	DeleteIdleBuckets(ctx context.Context, idle time.Duration) (int64, error)
+}
+
+// scheduleIdleBucketDeletion schedules with the garbage collector the
+// deletion of the rate limit buckets of store which are full again.
+func scheduleIdleBucketDeletion(ctx context.Context, c *cron.Cron, elector *leader.Elector, admin *adminHandler, store idleBucketStore) error {
+	limits, err := ratelimit.ParseLimits(*rateLimits)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error parsing --rate_limits")
+	}
+	var idle time.Duration
+	for _, limit := range limits {
+		if d := limit.RefillDuration(); d > idle {
+			idle = d
+		}
+	}
+
+	var (
+		logger     = logging.WithValuesFromContext(ctx, logging.Logger)
+		cronLogger = cron.VerbosePrintfLogger(log.New(os.Stdout, "RateLimitBucketDeletion: ", log.LstdFlags))
+	)
+	job := cron.FuncJob(func() {
+		deleted, err := store.DeleteIdleBuckets(ctx, idle)
+		metrics.GarbageCollectorRan("rate_limit_buckets", err)
+		if err != nil {
+			logger.Warn("Failed to delete idle rate limit buckets", zap.Error(err))
+		} else {
+			logger.Info("Deleted idle rate limit buckets", zap.Int64("count", deleted))
+		}
+	})
+	return scheduleGarbageCollector(ctx, c, *garbageCollectorSpec, "rate_limit_bucket_deletion", job, elector, admin, cronLogger)
+}
+
+// scheduleGarbageCollector schedules the garbage collector job name on c
+// according to spec, wrapped by jobWrappers, and registers the wrapped job
+// with admin so that manual runs are subject to the same wrappers.
+func scheduleGarbageCollector(ctx context.Context, c *cron.Cron, spec string, name string, job cron.Job, elector *leader.Elector, admin *adminHandler, logger cron.Logger) error {
+	wrapped := cron.NewChain(jobWrappers(ctx, elector, name, logger)...).Then(job)
+	if _, err := c.AddJob(spec, wrapped); err != nil {
+		return stacktrace.Propagate(err, "Failed to schedule job %s", name)
+	}
+	admin.addGarbageCollector(name, wrapped)
+	return nil
+}
+
+// jobWrappers returns the wrappers of the periodic job name, which only runs
+// while this instance is its leader if elector is not nil.
+func jobWrappers(ctx context.Context, elector *leader.Elector, name string, logger cron.Logger) []cron.JobWrapper {
+	wrappers := []cron.JobWrapper{cron.SkipIfStillRunning(logger)}
+	if elector != nil {
+		wrappers = append(wrappers, elector.OnlyIfLeader(ctx, name, logger))
+	}
+	return wrappers
+}
+
+// loadSCDQuotas returns the per-manager quotas configured with the scd_*
+// flags.
+func loadSCDQuotas() (*scd.Quotas, error) {
+	if *scdMaxActiveOperationalIntents < 0 || *scdMaxSubscriptionsPerCell < 0 || *scdMaxOperationalIntentsAreaKm2 < 0 {
+		return nil, stacktrace.NewError("Strategic conflict detection quotas must not be negative")
+	}
+	quotas := &scd.Quotas{
+		Default: scd.Quota{
+			MaxActiveOperationalIntents:  *scdMaxActiveOperationalIntents,
+			MaxSubscriptionsPerCell:      *scdMaxSubscriptionsPerCell,
+			MaxOperationalIntentsAreaKm2: *scdMaxOperationalIntentsAreaKm2,
+		},
+	}
+	if *scdQuotaOverridesFile != "" {
+		overrides, err := scd.LoadQuotasFile(*scdQuotaOverridesFile)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to load strategic conflict detection quota overrides")
+		}
+		quotas.Managers = overrides
+	}
+	return quotas, nil
+}
+
+// scheduleNotificationDelivery schedules on c the delivery of the
+// notifications pending in outbox.
+func scheduleNotificationDelivery(ctx context.Context, c *cron.Cron, name string, outbox notifications.Outbox, logger *zap.Logger) error {
+	var authenticator notifications.Authenticator
+	if *notificationTokenEndpoint != "" {
+		secret, err := os.ReadFile(*notificationClientSecretFile)
+		if err != nil {
+			return stacktrace.Propagate(err, "Failed to read notification client secret")
+		}
+		authenticator = &notifications.ClientCredentialsAuthenticator{
+			TokenURL:          *notificationTokenEndpoint,
+			ClientID:          *notificationClientID,
+			ClientSecret:      strings.TrimSpace(string(secret)),
+			AudienceParameter: *notificationAudienceParameter,
+		}
+	}
+
+	config := notifications.DefaultConfiguration
+	config.MaxAttempts = *notificationMaxAttempts
+	config.RateLimit = *notificationRateLimit
+	config.RateBurst = *notificationRateBurst
+	dispatcher := notifications.NewDispatcher(name, outbox, config, authenticator, logger)
+
+	cronLogger := cron.PrintfLogger(log.New(os.Stdout, "NotificationDelivery: ", log.LstdFlags))
+	deliver := cron.FuncJob(func() {
+		if err := dispatcher.DeliverDue(ctx); err != nil {
+			logger.Warn("Failed to deliver notifications", zap.String("outbox", name), zap.Error(err))
+		}
+	})
+	if _, err := c.AddJob(*notificationDeliverySpec, cron.NewChain(cron.SkipIfStillRunning(cronLogger)).Then(deliver)); err != nil {
+		return stacktrace.Propagate(err, "Failed to schedule notification delivery")
+	}
+	return nil
 }
 
 // RunHTTPServer starts the DSS HTTP server.
@@ -213,9 +540,9 @@ func RunHTTPServer(ctx context.Context, ctxCanceler func(), address, locality st
 	logger := logging.WithValuesFromContext(ctx, logging.Logger).With(zap.String("address", address))
 	logger.Info("version", zap.Any("version", version.Current()))
 	logger.Info("build", zap.Any("description", build.Describe()))
-	logger.Info("config", zap.Bool("scd", *enableSCD))
+	logger.Info("config", zap.Bool("scd", *enableSCD), zap.String("datastore", *datastoreType))
 
-	if len(*jwtAudiences) == 0 {
+	if len(*jwtAudiences) == 0 && *jwtIssuersFile == "" {
 		// TODO: Make this flag required once all parties can set audiences
 		// correctly.
 		logger.Warn("missing required --accepted_jwt_audiences")
@@ -226,65 +553,161 @@ func RunHTTPServer(ctx context.Context, ctxCanceler func(), address, locality st
 		ridV1Server        *rid_v1.Server
 		ridV2Server        *rid_v2.Server
 		scdV1Server        *scd.Server
+		scdCron            *cron.Cron
 		auxV1Server        = &aux.Server{}
 		versioningV1Server = &versioning.Server{}
 	)
 
+	var admin *adminHandler
+	if *adminAddress != "" {
+		admin = newAdminHandler(logger)
+	}
+
+	var elector *leader.Elector
+	if *enableLeaderElection {
+		elector = &leader.Elector{
+			Holder:        fmt.Sprintf("%s/%s", locality, uuid.New().String()),
+			LeaseDuration: *leaderLeaseDuration,
+		}
+		logger.Info("campaigning for the leadership of periodic jobs", zap.String("holder", elector.Holder))
+	}
+
 	// Initialize remote ID
-	ridV1Server, ridV2Server, err = createRIDServers(ctx, locality, logger)
+	ridV1Server, ridV2Server, ridStore, err := createRIDServers(ctx, locality, elector, admin, logger)
 	if err != nil {
 		return stacktrace.Propagate(err, "Failed to create remote ID server")
 	}
 
+	var revocations *auth.RevocationList
+	if *enableTokenRevocation {
+		revocationStore, ok := ridStore.(auth.RevocationStore)
+		if !ok {
+			return stacktrace.NewError("Remote ID store of type %T does not keep access token revocations", ridStore)
+		}
+		revocations, err = auth.NewRevocationList(ctx, revocationStore, *tokenRevocationRefreshInterval, *tokenRevocationMaxStaleness)
+		if err != nil {
+			return stacktrace.Propagate(err, "Error loading access token revocations")
+		}
+		auxV1Server.Revocations = revocations
+	}
+
 	// Initialize access token validation
 	keyResolver, err := createKeyResolver()
 	switch {
 	case err != nil:
-		return stacktrace.Propagate(err, "Error creating RSA authorizer")
-	case keyResolver == nil:
+		return stacktrace.Propagate(err, "Error creating authorizer")
+	case keyResolver == nil && *jwtIssuersFile == "":
 		logger.Warn("operating without authorizing interceptor")
 	}
 
-	authorizer, err := auth.NewRSAAuthorizer(
+	var issuers []auth.IssuerConfiguration
+	if *jwtIssuersFile != "" {
+		issuers, err = auth.LoadIssuersFile(*jwtIssuersFile)
+		if err != nil {
+			return stacktrace.Propagate(err, "Error loading JWT issuers")
+		}
+	}
+
+	var certificateBindings map[string][]string
+	if *clientCertificateBindingsFile != "" {
+		certificateBindings, err = auth.LoadCertificateBindingsFile(*clientCertificateBindingsFile)
+		if err != nil {
+			return stacktrace.Propagate(err, "Error loading client certificate bindings")
+		}
+	}
+
+	authorizer, err := auth.NewAuthorizer(
 		ctx, auth.Configuration{
-			KeyResolver:       keyResolver,
-			KeyRefreshTimeout: *keyRefreshTimeout,
-			AcceptedAudiences: strings.Split(*jwtAudiences, ","),
+			KeyResolver:         keyResolver,
+			KeyRefreshTimeout:   *keyRefreshTimeout,
+			AcceptedAudiences:   strings.Split(*jwtAudiences, ","),
+			Issuers:             issuers,
+			Revocations:         revocations,
+			CertificateBindings: certificateBindings,
 		},
 	)
 	if err != nil {
-		return stacktrace.Propagate(err, "Error creating RSA authorizer")
+		return stacktrace.Propagate(err, "Error creating authorizer")
+	}
+	if admin != nil {
+		admin.authorizer = authorizer
 	}
 
-	auxV1Router := apiauxv1.MakeAPIRouter(auxV1Server, authorizer)
-	versioningV1Router := apiversioningv1.MakeAPIRouter(versioningV1Server, authorizer)
-	ridV1Router := apiridv1.MakeAPIRouter(ridV1Server, authorizer)
-	ridV2Router := apiridv2.MakeAPIRouter(ridV2Server, authorizer)
-	multiRouter := api.MultiRouter{
-		Routers: []api.PartialRouter{
-			&auxV1Router,
-			&versioningV1Router,
-			&ridV1Router,
-			&ridV2Router,
-		}}
+	var apiAuthorizer api.Authorizer = authorizer
+	if *rateLimits != "" {
+		limits, err := ratelimit.ParseLimits(*rateLimits)
+		if err != nil {
+			return stacktrace.Propagate(err, "Error parsing --rate_limits")
+		}
+		var buckets ratelimit.BucketStore = ratelimitm.NewBucketStore()
+		if *rateLimitSharedState {
+			shared, ok := ridStore.(ratelimit.BucketStore)
+			if !ok {
+				return stacktrace.NewError("Remote ID store of type %T does not keep rate limit buckets", ridStore)
+			}
+			buckets = shared
+		}
+		apiAuthorizer = &ratelimit.Authorizer{
+			Authorizer: authorizer,
+			Store:      buckets,
+			Limits:     limits,
+			Logger:     logger,
+		}
+	}
+
+	validated, err := parseValidatedAPIs(*validateRequests)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error parsing --validate_requests")
+	}
+	validation := func(name string) api.RouterOption {
+		return api.WithRequestValidation(validated[name])
+	}
+
+	auxV1Router := apiauxv1.MakeAPIRouter(auxV1Server, apiAuthorizer, validation("aux_v1"))
+	versioningV1Router := apiversioningv1.MakeAPIRouter(versioningV1Server, apiAuthorizer, validation("versioning_v1"))
+	ridV1Router := apiridv1.MakeAPIRouter(ridV1Server, apiAuthorizer, validation("rid_v1"))
+	ridV2Router := apiridv2.MakeAPIRouter(ridV2Server, apiAuthorizer, validation("rid_v2"))
+	// All the APIs share a single router, so that finding the route of a
+	// request does not depend on the number of APIs served.
+	router := api.NewRouter()
+	router.Add(auxV1Router.Routes...)
+	router.Add(versioningV1Router.Routes...)
+	router.Add(ridV1Router.Routes...)
+	router.Add(ridV2Router.Routes...)
 
 	// Initialize strategic conflict detection
 	if *enableSCD {
-		scdV1Server, err = createSCDServer(ctx, logger)
+		scdV1Server, scdCron, err = createSCDServer(ctx, elector, admin, logger)
 		if err != nil {
 			ridV1Server.Cron.Stop()
 			ridV2Server.Cron.Stop()
 			return stacktrace.Propagate(err, "Failed to create strategic conflict detection server")
 		}
 
-		scdV1Router := apiscdv1.MakeAPIRouter(scdV1Server, authorizer)
-		multiRouter.Routers = append(multiRouter.Routers, &scdV1Router)
+		auxV1Server.SCDStore = scdV1Server.Store
+
+		scdV1Router := apiscdv1.MakeAPIRouter(scdV1Server, apiAuthorizer, validation("scd_v1"))
+		router.Add(scdV1Router.Routes...)
 	}
 
-	handler := logging.HTTPMiddleware(logger, *dumpRequests,
-		healthyEndpointMiddleware(logger,
-			&multiRouter,
-		))
+	logSampleRatiosByRoute, err := logging.ParseSampleRatios(*logSampleRatios)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error parsing --log_sample_ratios")
+	}
+	var public http.Handler = ratelimit.SheddingMiddleware(*loadSheddingPoolSaturation, router)
+	if *publicMetrics {
+		public = metricsEndpointMiddleware(public)
+	}
+	handler := tracing.HTTPMiddleware(
+		logging.HTTPMiddleware(logger, logging.HTTPConfiguration{
+			Dump:            *dumpRequests,
+			RedactedHeaders: strings.Split(*logRedactedHeaders, ","),
+			RedactedFields:  strings.Split(*logRedactedFields, ","),
+			SampleRatios:    logSampleRatiosByRoute,
+			MinDuration:     *logMinDuration,
+		},
+			metrics.HTTPMiddleware(
+				healthyEndpointMiddleware(logger, public))))
 
 	httpServer := &http.Server{
 		Addr:              address,
@@ -295,15 +718,52 @@ func RunHTTPServer(ctx context.Context, ctxCanceler func(), address, locality st
 		IdleTimeout:       30 * time.Second,
 	}
 
+	serveTLS := *tlsCertFile != "" || *tlsKeyFile != ""
+	if serveTLS {
+		httpServer.TLSConfig, err = dsstls.NewServerConfig(ctx, dsstls.Configuration{
+			CertFile:          *tlsCertFile,
+			KeyFile:           *tlsKeyFile,
+			ClientCAFile:      *tlsClientCAFile,
+			RequireClientCert: *tlsRequireClientCert,
+			ReloadInterval:    *tlsReloadInterval,
+		}, logger)
+		if err != nil {
+			return stacktrace.Propagate(err, "Error configuring TLS")
+		}
+	}
+
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
 	defer signal.Stop(signals)
 
+	var adminServer *http.Server
+	if admin != nil {
+		adminServer = &http.Server{
+			Addr:              *adminAddress,
+			Handler:           admin,
+			ReadHeaderTimeout: 15 * time.Second,
+		}
+		go func() {
+			logger.Info("Starting admin HTTP server", zap.String("admin_address", *adminAddress))
+			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("Admin HTTP server failed", zap.Error(err))
+			}
+		}()
+	}
+
 	go func() {
 		defer func() {
 			if err := httpServer.Shutdown(context.Background()); err != nil {
 				logger.Warn("failed to shut down http server", zap.Error(err))
 			}
+			if adminServer != nil {
+				if err := adminServer.Shutdown(context.Background()); err != nil {
+					logger.Warn("failed to shut down admin http server", zap.Error(err))
+				}
+			}
+			if elector != nil {
+				resignLeadership(elector, logger, ridV1Server.Cron, scdCron)
+			}
 		}()
 
 		for {
@@ -329,10 +789,41 @@ func RunHTTPServer(ctx context.Context, ctxCanceler func(), address, locality st
 		return stacktrace.Propagate(err, "Error closing touched file to indicate service ready")
 	}
 
+	if serveTLS {
+		logger.Info("Starting DSS HTTPS server")
+		return httpServer.ListenAndServeTLS("", "")
+	}
 	logger.Info("Starting DSS HTTP server")
 	return httpServer.ListenAndServe()
 }
 
+// resignLeadership stops crons, waiting for their running jobs to complete,
+// then releases the leadership of the jobs held by elector so that another
+// instance takes them over without waiting for their leases to expire.
+func resignLeadership(elector *leader.Elector, logger *zap.Logger, crons ...*cron.Cron) {
+	deadline := time.After(*timeout)
+	for _, c := range crons {
+		if c == nil {
+			continue
+		}
+		select {
+		case <-c.Stop().Done():
+		case <-deadline:
+			logger.Warn("periodic jobs still running while resigning their leadership")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	for _, name := range elector.Held() {
+		if err := elector.Resign(ctx, name); err != nil {
+			logger.Warn("failed to resign leadership", zap.String("job", name), zap.Error(err))
+			continue
+		}
+		logger.Info("resigned leadership", zap.String("job", name))
+	}
+}
+
 // healthyEndpointMiddleware intercepts a request and responds with an "ok" message at the endpoint "/healthy".
 func healthyEndpointMiddleware(logger *zap.Logger, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -346,6 +837,18 @@ func healthyEndpointMiddleware(logger *zap.Logger, next http.Handler) http.Handl
 	})
 }
 
+// metricsEndpointMiddleware intercepts a request and responds with the Prometheus metrics at the endpoint "/metrics".
+func metricsEndpointMiddleware(next http.Handler) http.Handler {
+	metricsHandler := metrics.Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metrics" {
+			metricsHandler.ServeHTTP(w, r)
+		} else {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
 type RIDGarbageCollectorJob struct {
 	name string
 	gc   ridc.GarbageCollector
@@ -355,6 +858,24 @@ type RIDGarbageCollectorJob struct {
 func (gcj RIDGarbageCollectorJob) Run() {
 	logger := logging.WithValuesFromContext(gcj.ctx, logging.Logger)
 	err := gcj.gc.DeleteRIDExpiredRecords(gcj.ctx)
+	metrics.GarbageCollectorRan("rid", err)
+	if err != nil {
+		logger.Warn("Fail to delete expired records", zap.Error(err))
+	} else {
+		logger.Info("Successful delete expired records")
+	}
+}
+
+type SCDGarbageCollectorJob struct {
+	name string
+	gc   scdc.GarbageCollector
+	ctx  context.Context
+}
+
+func (gcj SCDGarbageCollectorJob) Run() {
+	logger := logging.WithValuesFromContext(gcj.ctx, logging.Logger)
+	err := gcj.gc.DeleteSCDExpiredRecords(gcj.ctx)
+	metrics.GarbageCollectorRan("scd", err)
 	if err != nil {
 		logger.Warn("Fail to delete expired records", zap.Error(err))
 	} else {
@@ -371,8 +892,85 @@ func SetDeprecatingHttpFlag(logger *zap.Logger, newFlag **bool, deprecatedFlag *
 	}
 }
 
+// validateFlags checks the consistency of the flags which does not depend on
+// external resources, reporting all the invalid flags at once.
+func validateFlags() error {
+	var errs []string
+	switch *datastoreType {
+	case datastoreTypeSQL, datastoreTypeMemory:
+	default:
+		errs = append(errs, fmt.Sprintf("unknown --datastore %s, must be one of {%s, %s}", *datastoreType, datastoreTypeSQL, datastoreTypeMemory))
+	}
+	switch *logFormat {
+	case logging.FormatJSON, logging.FormatConsole:
+	default:
+		errs = append(errs, fmt.Sprintf("unknown --log_format %s, must be one of {%s, %s}", *logFormat, logging.FormatJSON, logging.FormatConsole))
+	}
+	if *timeout <= 0 {
+		errs = append(errs, "--server_timeout must be positive")
+	}
+	if *maxResultLimit <= 0 {
+		errs = append(errs, "--max_result_limit must be positive")
+	}
+	if _, err := logging.ParseSampleRatios(*logSampleRatios); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid --log_sample_ratios: %s", stacktrace.RootCause(err)))
+	}
+	if *logMinDuration < 0 {
+		errs = append(errs, "--log_min_duration must not be negative")
+	}
+	if _, err := ratelimit.ParseLimits(*rateLimits); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid --rate_limits: %s", stacktrace.RootCause(err)))
+	}
+	if *rateLimitSharedState && (*rateLimits == "" || *datastoreType != datastoreTypeSQL) {
+		errs = append(errs, fmt.Sprintf("--rate_limit_shared_state requires --rate_limits and --datastore %s", datastoreTypeSQL))
+	}
+	if _, err := parseValidatedAPIs(*validateRequests); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid --validate_requests: %s", stacktrace.RootCause(err)))
+	}
+	if *tokenRevocationRefreshInterval <= 0 {
+		errs = append(errs, "--token_revocation_refresh_interval must be positive")
+	}
+	if *tokenRevocationMaxStaleness < 0 {
+		errs = append(errs, "--token_revocation_max_staleness must not be negative")
+	}
+	if *loadSheddingPoolSaturation < 0 || *loadSheddingPoolSaturation > 1 {
+		errs = append(errs, "--load_shedding_pool_saturation must be between 0 and 1")
+	}
+	if (*tlsCertFile == "") != (*tlsKeyFile == "") {
+		errs = append(errs, "--tls_cert_file and --tls_key_file must be set together")
+	}
+	if *tlsCertFile == "" && (*tlsClientCAFile != "" || *tlsRequireClientCert) {
+		errs = append(errs, "client certificates require --tls_cert_file and --tls_key_file")
+	}
+	if *tlsRequireClientCert && *tlsClientCAFile == "" {
+		errs = append(errs, "--tls_require_client_cert requires --tls_client_ca_file to verify client certificates")
+	}
+	if *clientCertificateBindingsFile != "" && *tlsClientCAFile == "" {
+		errs = append(errs, "--client_certificate_bindings_file requires --tls_client_ca_file to verify client certificates")
+	}
+	if *scdGarbageCollectorSpec != "" && (*scdGarbageCollectorTTL <= 0 || *scdGarbageCollectorBatchSize <= 0) {
+		errs = append(errs, "--scd_garbage_collector_ttl and --scd_garbage_collector_batch_size must be positive")
+	}
+	if len(errs) > 0 {
+		return stacktrace.NewError("Invalid configuration: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
 func main() {
 	flag.Parse()
+	if err := config.LoadFlags(flag.CommandLine, "config_file"); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if *printConfig {
+		if err := config.PrintFlags(os.Stdout, flag.CommandLine, "print_config"); err != nil {
+			log.Fatalf("Failed to print configuration: %v", err)
+		}
+		return
+	}
+	if err := validateFlags(); err != nil {
+		log.Fatalf("%v", err)
+	}
 	if err := logging.Configure(*logLevel, *logFormat); err != nil {
 		panic(fmt.Sprintf("Failed to configure logging: %s", err.Error()))
 	}
@@ -391,6 +989,21 @@ func main() {
 		}
 	}
 
+	shutdownTracing, err := tracing.Configure(ctx, tracing.Configuration{
+		Exporter:      *traceExporter,
+		File:          *traceFile,
+		SamplingRatio: *traceSamplingRatio,
+		ServiceName:   "core-service",
+	})
+	if err != nil {
+		logger.Panic("Failed to configure tracing", zap.Error(err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Warn("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
 	backoffs := []time.Duration{
 		5 * time.Second, 15 * time.Second, 1 * time.Minute, 1 * time.Minute,
 		1 * time.Minute, 5 * time.Minute}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -70,3 +71,51 @@ func TestHttpDeprecationPrefersNewFlagWhenBothAreOn(t *testing.T) {
 	require.True(t, *newFlag)
 	require.True(t, *oldFlag)
 }
+
+func TestValidateFlags(t *testing.T) {
+	require.NoError(t, validateFlags())
+
+	defer func(timeoutValue time.Duration, certFile, bindingsFile string) {
+		*timeout, *tlsCertFile, *clientCertificateBindingsFile = timeoutValue, certFile, bindingsFile
+	}(*timeout, *tlsCertFile, *clientCertificateBindingsFile)
+	*timeout = 0
+	*tlsCertFile = "dss.crt"
+	*clientCertificateBindingsFile = "bindings.json"
+
+	err := validateFlags()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--server_timeout must be positive")
+	require.Contains(t, err.Error(), "--tls_cert_file and --tls_key_file must be set together")
+	require.Contains(t, err.Error(), "--client_certificate_bindings_file requires --tls_client_ca_file")
+}
+
+func TestValidateRateLimitFlags(t *testing.T) {
+	defer func(limits string, sharedState bool, saturation float64) {
+		*rateLimits, *rateLimitSharedState, *loadSheddingPoolSaturation = limits, sharedState, saturation
+	}(*rateLimits, *rateLimitSharedState, *loadSheddingPoolSaturation)
+	*rateLimits = "read=10:20,search=1"
+	*loadSheddingPoolSaturation = 0.9
+	require.NoError(t, validateFlags())
+
+	*rateLimits = "reads=10"
+	*rateLimitSharedState = true
+	*loadSheddingPoolSaturation = 2
+
+	err := validateFlags()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid --rate_limits")
+	require.Contains(t, err.Error(), "--load_shedding_pool_saturation must be between 0 and 1")
+}
+
+func TestParseValidatedAPIs(t *testing.T) {
+	apis, err := parseValidatedAPIs("")
+	require.NoError(t, err)
+	require.Empty(t, apis)
+
+	apis, err = parseValidatedAPIs("rid_v2, scd_v1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"rid_v2": true, "scd_v1": true}, apis)
+
+	_, err = parseValidatedAPIs("rid_v2,scd")
+	require.Error(t, err)
+}
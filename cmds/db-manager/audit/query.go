@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	dssaudit "github.com/interuss/dss/pkg/audit"
+	auditc "github.com/interuss/dss/pkg/audit/cockroach"
+	"github.com/interuss/dss/pkg/datastore"
+	crdbflags "github.com/interuss/dss/pkg/datastore/flags"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdc "github.com/interuss/dss/pkg/scd/store/cockroach"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	AuditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Query the audit trail of the changes made to DSS entities",
+		RunE:  query,
+	}
+	flags      = pflag.NewFlagSet("audit", pflag.ExitOnError)
+	entityID   = flags.String("entity_id", "", "only list the changes made to the entity with this ID")
+	manager    = flags.String("manager", "", "only list the changes made by this manager (or owner, for remote ID entities)")
+	from       = flags.String("from", "", "only list the changes made at or after this time, formatted as RFC 3339 (e.g. 2024-01-02T15:04:05Z)")
+	to         = flags.String("to", "", "only list the changes made at or before this time, formatted as RFC 3339")
+	limit      = flags.Int("limit", 1000, "maximum number of changes to list")
+	queryRID   = flags.Bool("rid", true, "set this flag to true to list changes made to remote ID entities")
+	querySCD   = flags.Bool("scd", true, "set this flag to true to list changes made to strategic conflict detection entities")
+	ridDBName  = flags.String("rid_db_name", "rid", "name of the remote ID database")
+	jsonOutput = flags.Bool("json", false, "set this flag to true to print one JSON object per change, including its cells, instead of a table")
+)
+
+func init() {
+	AuditCmd.Flags().AddFlagSet(flags)
+}
+
+func query(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	filter := dssaudit.Filter{
+		EntityID: dssmodels.ID(*entityID),
+		Manager:  *manager,
+		Limit:    *limit,
+	}
+	var err error
+	if *from != "" {
+		if filter.From, err = time.Parse(time.RFC3339, *from); err != nil {
+			return fmt.Errorf("failed to parse from: %w", err)
+		}
+	}
+	if *to != "" {
+		if filter.To, err = time.Parse(time.RFC3339, *to); err != nil {
+			return fmt.Errorf("failed to parse to: %w", err)
+		}
+	}
+
+	var records []*dssaudit.Record
+	if *queryRID {
+		rs, err := queryDatabase(ctx, *ridDBName, filter)
+		if err != nil {
+			return err
+		}
+		records = append(records, rs...)
+	}
+	if *querySCD {
+		rs, err := queryDatabase(ctx, scdc.DatabaseName, filter)
+		if err != nil {
+			return err
+		}
+		records = append(records, rs...)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].RecordedAt.Before(records[j].RecordedAt) })
+	if *limit > 0 && len(records) > *limit {
+		records = records[:*limit]
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return fmt.Errorf("failed to encode audit record: %w", err)
+			}
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RECORDED AT\tENTITY TYPE\tENTITY ID\tOPERATION\tMANAGER\tOLD VERSION\tNEW VERSION\tCELLS")
+	for _, r := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			r.RecordedAt.UTC().Format(time.RFC3339Nano), r.EntityType, r.EntityID, r.Operation, r.Manager,
+			orDash(r.OldVersion), orDash(r.NewVersion), len(r.Cells))
+	}
+	return w.Flush()
+}
+
+func queryDatabase(ctx context.Context, dbName string, filter dssaudit.Filter) ([]*dssaudit.Record, error) {
+	connectParameters := crdbflags.ConnectParameters()
+	connectParameters.ApplicationName = "db-manager"
+	connectParameters.DBName = dbName
+	ds, err := datastore.Dial(ctx, connectParameters)
+	if err != nil {
+		logParams := connectParameters
+		logParams.Credentials.Password = "[REDACTED]"
+		return nil, fmt.Errorf("failed to connect to database with %+v: %w", logParams, err)
+	}
+	defer ds.Pool.Close()
+
+	records, err := auditc.NewLog(ds.Pool).QueryAuditRecords(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit trail of database %s: %w", dbName, err)
+	}
+	return records, nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -51,7 +51,7 @@ func evict(cmd *cobra.Command, _ []string) error {
 	)
 	action := func(ctx context.Context, r repos.Repository) (err error) {
 		if *listScdOirs {
-			expiredOpIntents, err = r.ListExpiredOperationalIntents(ctx, threshold)
+			expiredOpIntents, err = r.ListExpiredOperationalIntents(ctx, threshold, dssmodels.MaxResultLimit)
 			if err != nil {
 				return fmt.Errorf("listing expired operational intents: %w", err)
 			}
@@ -65,7 +65,7 @@ func evict(cmd *cobra.Command, _ []string) error {
 		}
 
 		if *listScdSubs {
-			expiredSubs, err = r.ListExpiredSubscriptions(ctx, threshold)
+			expiredSubs, err = r.ListExpiredSubscriptions(ctx, threshold, dssmodels.MaxResultLimit)
 			if err != nil {
 				return fmt.Errorf("listing expired subscriptions: %w", err)
 			}
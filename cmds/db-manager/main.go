@@ -5,22 +5,49 @@ import (
 	"log"
 	"os"
 
+	"github.com/interuss/dss/cmds/db-manager/audit"
 	"github.com/interuss/dss/cmds/db-manager/cleanup"
 	"github.com/interuss/dss/cmds/db-manager/migration"
+	"github.com/interuss/dss/cmds/db-manager/reports"
+	"github.com/interuss/dss/pkg/config"
 	"github.com/spf13/cobra"
 )
 
 var (
 	DBManagerCmd = &cobra.Command{
-		Use:   "db-manager",
-		Short: "DSS database management utility",
+		Use:               "db-manager",
+		Short:             "DSS database management utility",
+		PersistentPreRunE: loadConfig,
 	}
 )
 
+// loadConfig completes the flags of cmd with the configuration file and
+// environment variables, and prints them instead of running cmd if requested.
+func loadConfig(cmd *cobra.Command, _ []string) error {
+	if err := config.LoadPFlags(cmd.Flags(), "config_file"); err != nil {
+		return err
+	}
+	printConfig, err := cmd.Flags().GetBool("print_config")
+	if err != nil {
+		return err
+	}
+	if printConfig {
+		if err := config.PrintPFlags(os.Stdout, cmd.Flags(), "help", "print_config"); err != nil {
+			return err
+		}
+		os.Exit(0)
+	}
+	return nil
+}
+
 func init() {
+	DBManagerCmd.PersistentFlags().String("config_file", "", "Path to a YAML or JSON file setting any of the flags of the command by name; flags set on the command line or by their DSS_<FLAG NAME> environment variable take precedence")
+	DBManagerCmd.PersistentFlags().Bool("print_config", false, "Prints the effective configuration of the command in the format of config_file and exits")
 	DBManagerCmd.PersistentFlags().AddGoFlagSet(flag.CommandLine) // enable support for flags not yet migrated to using pflag (e.g. crdb flags)
 	DBManagerCmd.AddCommand(migration.MigrationCmd)
 	DBManagerCmd.AddCommand(cleanup.EvictCmd)
+	DBManagerCmd.AddCommand(audit.AuditCmd)
+	DBManagerCmd.AddCommand(reports.ReportsCmd)
 }
 
 func main() {
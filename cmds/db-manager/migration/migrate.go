@@ -88,6 +88,7 @@ func migrate(cmd *cobra.Command, _ []string) error {
 	var (
 		isCockroach = ds.Version.Type == datastore.CockroachDB
 		isYugabyte  = ds.Version.Type == datastore.Yugabyte
+		isPostgres  = ds.Version.Type == datastore.Postgres
 	)
 
 	// Make sure specified database exists
@@ -177,7 +178,7 @@ func migrate(cmd *cobra.Command, _ []string) error {
 
 			migrationSQL = sessionConfigurationSQL + fmt.Sprintf("USE %s;\n", dbName) + string(rawMigrationSQL)
 		}
-		if isYugabyte {
+		if isYugabyte || isPostgres {
 			// Migrations do not require database switch in opposite to CRDB.
 			migrationSQL = string(rawMigrationSQL)
 		}
@@ -0,0 +1,140 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/interuss/dss/pkg/datastore"
+	crdbflags "github.com/interuss/dss/pkg/datastore/flags"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	scdc "github.com/interuss/dss/pkg/scd/store/cockroach"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	ReportsCmd = &cobra.Command{
+		Use:   "reports",
+		Short: "List and show the reports submitted to the DSS by USSs",
+	}
+	listCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the reports submitted to the DSS, most recent first",
+		Args:  cobra.NoArgs,
+		RunE:  list,
+	}
+	showCmd = &cobra.Command{
+		Use:   "show <report_id>",
+		Short: "Show a report submitted to the DSS",
+		Args:  cobra.ExactArgs(1),
+		RunE:  show,
+	}
+	listFlags = pflag.NewFlagSet("list", pflag.ExitOnError)
+	reporter  = listFlags.String("reporter", "", "only list the reports submitted by this manager")
+	from      = listFlags.String("from", "", "only list the reports received at or after this time, formatted as RFC 3339 (e.g. 2024-01-02T15:04:05Z)")
+	to        = listFlags.String("to", "", "only list the reports received at or before this time, formatted as RFC 3339")
+	limit     = listFlags.Int("limit", 100, "maximum number of reports to list")
+)
+
+func init() {
+	listCmd.Flags().AddFlagSet(listFlags)
+	ReportsCmd.AddCommand(listCmd)
+	ReportsCmd.AddCommand(showCmd)
+}
+
+func list(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	earliest, err := parseOptionalTime(*from)
+	if err != nil {
+		return fmt.Errorf("failed to parse from: %w", err)
+	}
+	latest, err := parseOptionalTime(*to)
+	if err != nil {
+		return fmt.Errorf("failed to parse to: %w", err)
+	}
+
+	r, err := getSCDRepo(ctx)
+	if err != nil {
+		return err
+	}
+	reports, err := r.ListDSSReports(ctx, dssmodels.Manager(*reporter), earliest, latest, *limit)
+	if err != nil {
+		return fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPORT ID\tREPORTER\tRECEIVED AT")
+	for _, report := range reports {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", report.ID, report.Reporter, report.ReceivedAt.UTC().Format(time.RFC3339Nano))
+	}
+	return w.Flush()
+}
+
+func show(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	id, err := dssmodels.IDFromString(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid report ID: %w", err)
+	}
+
+	r, err := getSCDRepo(ctx)
+	if err != nil {
+		return err
+	}
+	report, err := r.GetDSSReport(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get report: %w", err)
+	}
+	if report == nil {
+		return fmt.Errorf("report %s not found", id)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, report.Report, "", "  "); err != nil {
+		return fmt.Errorf("failed to format report: %w", err)
+	}
+	fmt.Printf("report_id: %s\nreporter: %s\nreceived_at: %s\n%s\n",
+		report.ID, report.Reporter, report.ReceivedAt.UTC().Format(time.RFC3339Nano), indented.String())
+	return nil
+}
+
+func getSCDRepo(ctx context.Context) (repos.Repository, error) {
+	connectParameters := crdbflags.ConnectParameters()
+	connectParameters.ApplicationName = "db-manager"
+	connectParameters.DBName = scdc.DatabaseName
+	scdCrdb, err := datastore.Dial(ctx, connectParameters)
+	if err != nil {
+		logParams := connectParameters
+		logParams.Credentials.Password = "[REDACTED]"
+		return nil, fmt.Errorf("failed to connect to database with %+v: %w", logParams, err)
+	}
+
+	scdStore, err := scdc.NewStore(ctx, scdCrdb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create strategic conflict detection store with %+v: %w", connectParameters, err)
+	}
+	r, err := scdStore.Interact(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to interact with strategic conflict detection store: %w", err)
+	}
+	return r, nil
+}
+
+func parseOptionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
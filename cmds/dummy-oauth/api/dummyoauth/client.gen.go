@@ -0,0 +1,68 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package dummyoauth
+
+import (
+	"context"
+	"fmt"
+	"github.com/interuss/dss/cmds/dummy-oauth/api"
+	"net/http"
+	"net/url"
+)
+
+// Client sends the requests of the operations of this API to a server.
+type Client struct {
+	// BaseURL is the URL the paths of the operations are relative to, without
+	// trailing slash
+	BaseURL string
+	// HTTPClient sends the requests; http.DefaultClient is used if nil
+	HTTPClient *http.Client
+	// TokenSource provides the access tokens authorizing the requests, which
+	// are sent without access token if nil
+	TokenSource api.TokenSource
+}
+
+// GetToken sends the request of the GetToken operation.
+func (c *Client) GetToken(ctx context.Context, req *GetTokenRequest) (*GetTokenResponseSet, error) {
+	path := "/token"
+	query := url.Values{}
+	if req.IntendedAudience != nil {
+		query.Set("intended_audience", *req.IntendedAudience)
+	}
+	if req.Scope != nil {
+		query.Set("scope", *req.Scope)
+	}
+	if req.Issuer != nil {
+		query.Set("issuer", *req.Issuer)
+	}
+	if req.Expire != nil {
+		query.Set("expire", fmt.Sprint(*req.Expire))
+	}
+	if req.Sub != nil {
+		query.Set("sub", *req.Sub)
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetTokenSecurity, http.MethodGet, c.BaseURL+path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetTokenResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(TokenResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(BadRequestResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"github.com/interuss/dss/cmds/dummy-oauth/api"
 	"net/http"
-	"regexp"
 	"strconv"
 )
 
@@ -13,24 +12,33 @@ type APIRouter struct {
 	Routes         []*api.Route
 	Implementation Implementation
 	Authorizer     api.Authorizer
+	Options        api.RouterOptions
+
+	router *api.Router
 }
 
 // *dummyoauth.APIRouter (type defined above) implements the api.PartialRouter interface
 func (s *APIRouter) Handle(w http.ResponseWriter, r *http.Request) bool {
-	for _, route := range s.Routes {
-		if route.Method == r.Method && route.Pattern.MatchString(r.URL.Path) {
-			route.Handler(route.Pattern, w, r)
-			return true
-		}
-	}
-	return false
+	return s.router.Handle(w, r)
 }
 
-func (s *APIRouter) GetToken(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) GetToken(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req GetTokenRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, GetTokenSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &GetTokenRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Copy query parameters
 	query := r.URL.Query()
@@ -59,7 +67,7 @@ func (s *APIRouter) GetToken(exp *regexp.Regexp, w http.ResponseWriter, r *http.
 	}
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.GetToken(ctx, &req)
 
@@ -79,11 +87,14 @@ func (s *APIRouter) GetToken(exp *regexp.Regexp, w http.ResponseWriter, r *http.
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func MakeAPIRouter(impl Implementation, auth api.Authorizer) APIRouter {
+func MakeAPIRouter(impl Implementation, auth api.Authorizer, options ...api.RouterOption) APIRouter {
 	router := APIRouter{Implementation: impl, Authorizer: auth, Routes: make([]*api.Route, 1)}
+	for _, option := range options {
+		option(&router.Options)
+	}
 
-	pattern := regexp.MustCompile("^/token$")
-	router.Routes[0] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.GetToken}
+	router.Routes[0] = &api.Route{Method: http.MethodGet, Path: "/token", Handler: router.GetToken}
 
+	router.router = api.NewRouter(router.Routes...)
 	return router
 }
@@ -0,0 +1,20 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package dummyoauth
+
+import (
+	"github.com/interuss/dss/cmds/dummy-oauth/api"
+)
+
+// Schemas are the OpenAPI schemas of the data types of the requests of this
+// API, by name.
+var Schemas = api.Schemas{}
+
+var GetTokenRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "intended_audience", In: "query", Required: true, Schema: &api.Schema{Type: "string"}},
+		{Name: "scope", In: "query", Required: true, Schema: &api.Schema{Type: "string"}},
+		{Name: "issuer", In: "query", Required: false, Schema: &api.Schema{Type: "string"}},
+		{Name: "expire", In: "query", Required: false, Schema: &api.Schema{Type: "integer", Format: "int64"}},
+		{Name: "sub", In: "query", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
@@ -2,8 +2,9 @@ package main
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -16,11 +17,15 @@ import (
 
 var (
 	address = flag.String("addr", ":8085", "address")
-	keyFile = flag.String("private_key_file", "build/test-certs/auth2.key", "OAuth private key file")
+	keyFile = flag.String("private_key_file", "build/test-certs/auth2.key", "OAuth private key file, holding an RSA, ECDSA (P-256, P-384 or P-521) or Ed25519 key in PEM format")
+	keyID   = flag.String("key_id", "", "If set, the ID of the private key, added as the kid header of tokens")
 )
 
 type DummyOAuthImplementation struct {
-	PrivateKey *rsa.PrivateKey
+	PrivateKey crypto.PrivateKey
+	// SigningMethod is the algorithm signing tokens, inferred from PrivateKey.
+	SigningMethod jwt.SigningMethod
+	KeyID         string
 }
 
 func (s *DummyOAuthImplementation) GetToken(ctx context.Context, req *dummyoauth.GetTokenRequest) dummyoauth.GetTokenResponseSet {
@@ -65,7 +70,7 @@ func (s *DummyOAuthImplementation) GetToken(ctx context.Context, req *dummyoauth
 		sub = "fake_uss"
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+	token := jwt.NewWithClaims(s.SigningMethod, jwt.MapClaims{
 		"aud":   intendedAudience,
 		"scope": scope,
 		"iss":   issuer,
@@ -73,6 +78,10 @@ func (s *DummyOAuthImplementation) GetToken(ctx context.Context, req *dummyoauth
 		"sub":   sub,
 	})
 
+	if s.KeyID != "" {
+		token.Header["kid"] = s.KeyID
+	}
+
 	// Sign and get the complete encoded token as a string using the secret
 	tokenString, err := token.SignedString(s.PrivateKey)
 	if err != nil {
@@ -84,6 +93,32 @@ func (s *DummyOAuthImplementation) GetToken(ctx context.Context, req *dummyoauth
 	return resp
 }
 
+// parsePrivateKey parses the PEM-encoded private key in bytes and returns it
+// along with the signing method of the tokens it signs: RS256 for RSA keys,
+// ES256, ES384 or ES512 for ECDSA keys depending on their curve, and EdDSA for
+// Ed25519 keys.
+func parsePrivateKey(bytes []byte) (crypto.PrivateKey, jwt.SigningMethod, error) {
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(bytes); err == nil {
+		return key, jwt.SigningMethodRS256, nil
+	}
+	if key, err := jwt.ParseECPrivateKeyFromPEM(bytes); err == nil {
+		switch key.Curve.Params().BitSize {
+		case 256:
+			return key, jwt.SigningMethodES256, nil
+		case 384:
+			return key, jwt.SigningMethodES384, nil
+		case 521:
+			return key, jwt.SigningMethodES512, nil
+		default:
+			return nil, nil, fmt.Errorf("unsupported ECDSA curve %s", key.Curve.Params().Name)
+		}
+	}
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(bytes); err == nil {
+		return key, jwt.SigningMethodEdDSA, nil
+	}
+	return nil, nil, fmt.Errorf("private key is neither an RSA, ECDSA nor Ed25519 key in PEM format")
+}
+
 type PermissiveAuthorizer struct{}
 
 func (*PermissiveAuthorizer) Authorize(w http.ResponseWriter, r *http.Request, authOptions []api.AuthorizationOption) api.AuthorizationResult {
@@ -98,13 +133,14 @@ func main() {
 	if err != nil {
 		log.Panic(err)
 	}
-	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(bytes)
+	privateKey, signingMethod, err := parsePrivateKey(bytes)
 	if err != nil {
 		log.Panic(err)
 	}
+	log.Printf("Signing tokens with %s", signingMethod.Alg())
 
 	// Define and start HTTP server
-	impl := DummyOAuthImplementation{PrivateKey: privateKey}
+	impl := DummyOAuthImplementation{PrivateKey: privateKey, SigningMethod: signingMethod, KeyID: *keyID}
 	router := dummyoauth.MakeAPIRouter(&impl, &PermissiveAuthorizer{})
 	multiRouter := api.MultiRouter{Routers: []api.PartialRouter{&router}}
 	s := &http.Server{
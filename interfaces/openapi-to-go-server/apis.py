@@ -12,6 +12,8 @@ class API:
     path_prefix: str
     data_types: List[data_types.DataType]
     operations: List[operations.Operation]
+    schemas: Dict[str, Dict] = dataclasses.field(default_factory=dict)
+    """OpenAPI schemas of the components of this API, by name"""
 
     def primitive_go_type_for(self, data_type_name: str) -> str:
         if data_types.is_primitive_go_type(data_type_name):
@@ -102,4 +104,5 @@ def make_api(package: str, api_path: str, spec: Dict) -> API:
         declared_types.extend(further_data_tyes)
         declared_operations.extend(new_operations)
 
-    return API(package=package, path_prefix=api_path, data_types=declared_types, operations=declared_operations)
+    return API(package=package, path_prefix=api_path, data_types=declared_types, operations=declared_operations,
+               schemas=components['schemas'])
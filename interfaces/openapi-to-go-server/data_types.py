@@ -84,7 +84,7 @@ def get_data_type_name(component_name: str, data_type_name: str) -> str:
         raise NotImplementedError('$ref expected to start with `#/components/schemas/`, but found `{}` instead for {}'.format(component_name, data_type_name))
 
 
-def _parse_referenced_type_name(schema: Dict, data_type_name: str) -> str:
+def parse_referenced_type_name(schema: Dict, data_type_name: str) -> str:
     options = schema['anyOf'] if 'anyOf' in schema else schema['allOf']
     if len(options) != 1:
         raise NotImplementedError('Only one $ref is supported for anyOf and allOf; found {} elements instead'.format(len(options)))
@@ -117,7 +117,7 @@ def make_object_field(go_object_name: str, api_field_name: str, schema: Dict, re
     elif 'anyOf' in schema or 'allOf' in schema:
         return ObjectField(
             api_name=api_field_name,
-            go_type=_parse_referenced_type_name(schema, go_object_name + '.' + api_field_name),
+            go_type=parse_referenced_type_name(schema, go_object_name + '.' + api_field_name),
             description=schema.get('description', ''),
             required=is_required), []
     else:
@@ -192,6 +192,9 @@ def make_data_types(api_name: str, schema: Dict) -> Tuple[DataType, List[DataTyp
                 data_type.go_type = '[]{}'.format(item_type_name)
             else:
                 raise ValueError('Missing `items` declaration for {} array type'.format(api_name))
+        elif schema['type'] == 'object' and 'properties' not in schema and schema.get('additionalProperties', False):
+            # Free-form object
+            data_type.go_type = 'map[string]interface{}'
         elif schema['type'] == 'object':
             data_type.go_type = 'struct'
             data_type.fields, further_types = _make_object_fields(
@@ -202,7 +205,7 @@ def make_data_types(api_name: str, schema: Dict) -> Tuple[DataType, List[DataTyp
         else:
             raise ValueError('Unrecognized type `{}` in {} type'.format(schema['type'], api_name))
     elif 'anyOf' in schema or 'allOf' in schema:
-        data_type.go_type = _parse_referenced_type_name(schema, api_name)
+        data_type.go_type = parse_referenced_type_name(schema, api_name)
 
     if 'enum' in schema:
         data_type.enum_values = schema['enum']
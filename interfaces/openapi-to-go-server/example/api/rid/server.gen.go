@@ -6,31 +6,39 @@ import (
 	"encoding/json"
 	"example/api"
 	"net/http"
-	"regexp"
 )
 
 type APIRouter struct {
 	Routes         []*api.Route
 	Implementation Implementation
 	Authorizer     api.Authorizer
+	Options        api.RouterOptions
+
+	router *api.Router
 }
 
 // *rid.APIRouter (type defined above) implements the api.PartialRouter interface
 func (s *APIRouter) Handle(w http.ResponseWriter, r *http.Request) bool {
-	for _, route := range s.Routes {
-		if route.Method == r.Method && route.Pattern.MatchString(r.URL.Path) {
-			route.Handler(route.Pattern, w, r)
-			return true
-		}
-	}
-	return false
+	return s.router.Handle(w, r)
 }
 
-func (s *APIRouter) SearchIdentificationServiceAreas(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) SearchIdentificationServiceAreas(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req SearchIdentificationServiceAreasRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, SearchIdentificationServiceAreasSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &SearchIdentificationServiceAreasRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Copy query parameters
 	query := r.URL.Query()
@@ -49,7 +57,7 @@ func (s *APIRouter) SearchIdentificationServiceAreas(exp *regexp.Regexp, w http.
 	}
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.SearchIdentificationServiceAreas(ctx, &req)
 
@@ -81,18 +89,29 @@ func (s *APIRouter) SearchIdentificationServiceAreas(exp *regexp.Regexp, w http.
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) GetIdentificationServiceArea(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) GetIdentificationServiceArea(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req GetIdentificationServiceAreaRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, GetIdentificationServiceAreaSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &GetIdentificationServiceAreaRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Id = EntityUUID(pathMatch[1])
+	req.Id = EntityUUID(params.Get("id"))
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.GetIdentificationServiceArea(ctx, &req)
 
@@ -124,15 +143,26 @@ func (s *APIRouter) GetIdentificationServiceArea(exp *regexp.Regexp, w http.Resp
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) CreateIdentificationServiceArea(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) CreateIdentificationServiceArea(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req CreateIdentificationServiceAreaRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, CreateIdentificationServiceAreaSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &CreateIdentificationServiceAreaRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Id = EntityUUID(pathMatch[1])
+	req.Id = EntityUUID(params.Get("id"))
 
 	// Parse request body
 	req.Body = new(CreateIdentificationServiceAreaParameters)
@@ -140,7 +170,7 @@ func (s *APIRouter) CreateIdentificationServiceArea(exp *regexp.Regexp, w http.R
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.CreateIdentificationServiceArea(ctx, &req)
 
@@ -176,16 +206,27 @@ func (s *APIRouter) CreateIdentificationServiceArea(exp *regexp.Regexp, w http.R
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) UpdateIdentificationServiceArea(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) UpdateIdentificationServiceArea(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req UpdateIdentificationServiceAreaRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, UpdateIdentificationServiceAreaSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &UpdateIdentificationServiceAreaRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Id = EntityUUID(pathMatch[1])
-	req.Version = pathMatch[2]
+	req.Id = EntityUUID(params.Get("id"))
+	req.Version = params.Get("version")
 
 	// Parse request body
 	req.Body = new(UpdateIdentificationServiceAreaParameters)
@@ -193,7 +234,7 @@ func (s *APIRouter) UpdateIdentificationServiceArea(exp *regexp.Regexp, w http.R
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.UpdateIdentificationServiceArea(ctx, &req)
 
@@ -229,19 +270,30 @@ func (s *APIRouter) UpdateIdentificationServiceArea(exp *regexp.Regexp, w http.R
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) DeleteIdentificationServiceArea(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) DeleteIdentificationServiceArea(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req DeleteIdentificationServiceAreaRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, DeleteIdentificationServiceAreaSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &DeleteIdentificationServiceAreaRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Id = EntityUUID(pathMatch[1])
-	req.Version = pathMatch[2]
+	req.Id = EntityUUID(params.Get("id"))
+	req.Version = params.Get("version")
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.DeleteIdentificationServiceArea(ctx, &req)
 
@@ -277,11 +329,23 @@ func (s *APIRouter) DeleteIdentificationServiceArea(exp *regexp.Regexp, w http.R
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) SearchSubscriptions(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) SearchSubscriptions(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req SearchSubscriptionsRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, SearchSubscriptionsSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &SearchSubscriptionsRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Copy query parameters
 	query := r.URL.Query()
@@ -292,7 +356,7 @@ func (s *APIRouter) SearchSubscriptions(exp *regexp.Regexp, w http.ResponseWrite
 	}
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.SearchSubscriptions(ctx, &req)
 
@@ -324,18 +388,29 @@ func (s *APIRouter) SearchSubscriptions(exp *regexp.Regexp, w http.ResponseWrite
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) GetSubscription(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) GetSubscription(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req GetSubscriptionRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, GetSubscriptionSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &GetSubscriptionRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Id = SubscriptionUUID(pathMatch[1])
+	req.Id = SubscriptionUUID(params.Get("id"))
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.GetSubscription(ctx, &req)
 
@@ -367,15 +442,26 @@ func (s *APIRouter) GetSubscription(exp *regexp.Regexp, w http.ResponseWriter, r
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) CreateSubscription(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) CreateSubscription(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req CreateSubscriptionRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, CreateSubscriptionSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &CreateSubscriptionRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Id = SubscriptionUUID(pathMatch[1])
+	req.Id = SubscriptionUUID(params.Get("id"))
 
 	// Parse request body
 	req.Body = new(CreateSubscriptionParameters)
@@ -383,7 +469,7 @@ func (s *APIRouter) CreateSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.CreateSubscription(ctx, &req)
 
@@ -419,16 +505,27 @@ func (s *APIRouter) CreateSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) UpdateSubscription(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) UpdateSubscription(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req UpdateSubscriptionRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, UpdateSubscriptionSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &UpdateSubscriptionRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Id = SubscriptionUUID(pathMatch[1])
-	req.Version = pathMatch[2]
+	req.Id = SubscriptionUUID(params.Get("id"))
+	req.Version = params.Get("version")
 
 	// Parse request body
 	req.Body = new(UpdateSubscriptionParameters)
@@ -436,7 +533,7 @@ func (s *APIRouter) UpdateSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.UpdateSubscription(ctx, &req)
 
@@ -472,19 +569,30 @@ func (s *APIRouter) UpdateSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) DeleteSubscription(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) DeleteSubscription(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req DeleteSubscriptionRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, DeleteSubscriptionSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &DeleteSubscriptionRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Id = SubscriptionUUID(pathMatch[1])
-	req.Version = pathMatch[2]
+	req.Id = SubscriptionUUID(params.Get("id"))
+	req.Version = params.Get("version")
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.DeleteSubscription(ctx, &req)
 
@@ -520,38 +628,23 @@ func (s *APIRouter) DeleteSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func MakeAPIRouter(impl Implementation, auth api.Authorizer) APIRouter {
+func MakeAPIRouter(impl Implementation, auth api.Authorizer, options ...api.RouterOption) APIRouter {
 	router := APIRouter{Implementation: impl, Authorizer: auth, Routes: make([]*api.Route, 10)}
-
-	pattern := regexp.MustCompile("^/rid/v1/dss/identification_service_areas$")
-	router.Routes[0] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.SearchIdentificationServiceAreas}
-
-	pattern = regexp.MustCompile("^/rid/v1/dss/identification_service_areas/(?P<id>[^/]*)$")
-	router.Routes[1] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.GetIdentificationServiceArea}
-
-	pattern = regexp.MustCompile("^/rid/v1/dss/identification_service_areas/(?P<id>[^/]*)$")
-	router.Routes[2] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.CreateIdentificationServiceArea}
-
-	pattern = regexp.MustCompile("^/rid/v1/dss/identification_service_areas/(?P<id>[^/]*)/(?P<version>[^/]*)$")
-	router.Routes[3] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.UpdateIdentificationServiceArea}
-
-	pattern = regexp.MustCompile("^/rid/v1/dss/identification_service_areas/(?P<id>[^/]*)/(?P<version>[^/]*)$")
-	router.Routes[4] = &api.Route{Method: http.MethodDelete, Pattern: pattern, Handler: router.DeleteIdentificationServiceArea}
-
-	pattern = regexp.MustCompile("^/rid/v1/dss/subscriptions$")
-	router.Routes[5] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.SearchSubscriptions}
-
-	pattern = regexp.MustCompile("^/rid/v1/dss/subscriptions/(?P<id>[^/]*)$")
-	router.Routes[6] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.GetSubscription}
-
-	pattern = regexp.MustCompile("^/rid/v1/dss/subscriptions/(?P<id>[^/]*)$")
-	router.Routes[7] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.CreateSubscription}
-
-	pattern = regexp.MustCompile("^/rid/v1/dss/subscriptions/(?P<id>[^/]*)/(?P<version>[^/]*)$")
-	router.Routes[8] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.UpdateSubscription}
-
-	pattern = regexp.MustCompile("^/rid/v1/dss/subscriptions/(?P<id>[^/]*)/(?P<version>[^/]*)$")
-	router.Routes[9] = &api.Route{Method: http.MethodDelete, Pattern: pattern, Handler: router.DeleteSubscription}
-
+	for _, option := range options {
+		option(&router.Options)
+	}
+
+	router.Routes[0] = &api.Route{Method: http.MethodGet, Path: "/rid/v1/dss/identification_service_areas", Handler: router.SearchIdentificationServiceAreas}
+	router.Routes[1] = &api.Route{Method: http.MethodGet, Path: "/rid/v1/dss/identification_service_areas/{id}", Handler: router.GetIdentificationServiceArea}
+	router.Routes[2] = &api.Route{Method: http.MethodPut, Path: "/rid/v1/dss/identification_service_areas/{id}", Handler: router.CreateIdentificationServiceArea}
+	router.Routes[3] = &api.Route{Method: http.MethodPut, Path: "/rid/v1/dss/identification_service_areas/{id}/{version}", Handler: router.UpdateIdentificationServiceArea}
+	router.Routes[4] = &api.Route{Method: http.MethodDelete, Path: "/rid/v1/dss/identification_service_areas/{id}/{version}", Handler: router.DeleteIdentificationServiceArea}
+	router.Routes[5] = &api.Route{Method: http.MethodGet, Path: "/rid/v1/dss/subscriptions", Handler: router.SearchSubscriptions}
+	router.Routes[6] = &api.Route{Method: http.MethodGet, Path: "/rid/v1/dss/subscriptions/{id}", Handler: router.GetSubscription}
+	router.Routes[7] = &api.Route{Method: http.MethodPut, Path: "/rid/v1/dss/subscriptions/{id}", Handler: router.CreateSubscription}
+	router.Routes[8] = &api.Route{Method: http.MethodPut, Path: "/rid/v1/dss/subscriptions/{id}/{version}", Handler: router.UpdateSubscription}
+	router.Routes[9] = &api.Route{Method: http.MethodDelete, Path: "/rid/v1/dss/subscriptions/{id}/{version}", Handler: router.DeleteSubscription}
+
+	router.router = api.NewRouter(router.Routes...)
 	return router
 }
@@ -0,0 +1,237 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package rid
+
+import (
+	"example/api"
+	"regexp"
+)
+
+// Schemas are the OpenAPI schemas of the data types of the requests of this
+// API, by name.
+var Schemas = api.Schemas{
+	"Volume3D": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"footprint": {
+				Ref: "GeoPolygon",
+			},
+			"altitude_lo": {
+				Ref: "Altitude",
+			},
+			"altitude_hi": {
+				Ref: "Altitude",
+			},
+		},
+		Required: []string{"footprint"},
+	},
+	"Volume4D": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"spatial_volume": {
+				Ref: "Volume3D",
+			},
+			"time_start": {
+				Type:   "string",
+				Format: "date-time",
+			},
+			"time_end": {
+				Type:   "string",
+				Format: "date-time",
+			},
+		},
+		Required: []string{"spatial_volume"},
+	},
+	"UUIDv4": {
+		Type:      "string",
+		Format:    "uuid",
+		MinLength: api.Int(36),
+		MaxLength: api.Int(36),
+		Pattern:   regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`),
+	},
+	"EntityUUID": {
+		Ref: "UUIDv4",
+	},
+	"SubscriptionUUID": {
+		Ref: "UUIDv4",
+	},
+	"GeoPolygonString": {
+		Type: "string",
+	},
+	"Latitude": {
+		Type:    "number",
+		Format:  "double",
+		Minimum: api.Float64(-90),
+		Maximum: api.Float64(90),
+	},
+	"Longitude": {
+		Type:    "number",
+		Format:  "double",
+		Minimum: api.Float64(-180),
+		Maximum: api.Float64(180),
+	},
+	"LatLngPoint": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"lng": {
+				Ref: "Longitude",
+			},
+			"lat": {
+				Ref: "Latitude",
+			},
+		},
+		Required: []string{"lng", "lat"},
+	},
+	"Altitude": {
+		Type:   "number",
+		Format: "float",
+	},
+	"GeoPolygon": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"vertices": {
+				Type:     "array",
+				MinItems: api.Int(3),
+				Items: &api.Schema{
+					Ref: "LatLngPoint",
+				},
+			},
+		},
+		Required: []string{"vertices"},
+	},
+	"IdentificationServiceAreaURL": {
+		Type: "string",
+	},
+	"SubscriptionCallbacks": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"identification_service_area_url": {
+				Ref: "IdentificationServiceAreaURL",
+			},
+		},
+	},
+	"CreateIdentificationServiceAreaParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Ref: "Volume4D",
+			},
+			"flights_url": {
+				Ref: "RIDFlightsURL",
+			},
+		},
+		Required: []string{"extents", "flights_url"},
+	},
+	"UpdateIdentificationServiceAreaParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Ref: "Volume4D",
+			},
+			"flights_url": {
+				Ref: "RIDFlightsURL",
+			},
+		},
+		Required: []string{"extents", "flights_url"},
+	},
+	"CreateSubscriptionParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Ref: "Volume4D",
+			},
+			"callbacks": {
+				Ref: "SubscriptionCallbacks",
+			},
+		},
+		Required: []string{"extents", "callbacks"},
+	},
+	"UpdateSubscriptionParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Ref: "Volume4D",
+			},
+			"callbacks": {
+				Ref: "SubscriptionCallbacks",
+			},
+		},
+		Required: []string{"extents", "callbacks"},
+	},
+	"RIDFlightsURL": {
+		Type: "string",
+	},
+}
+
+var SearchIdentificationServiceAreasRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "area", In: "query", Required: true, Schema: &api.Schema{Ref: "GeoPolygonString"}},
+		{Name: "earliest_time", In: "query", Required: false, Schema: &api.Schema{Type: "string", Format: "date-time"}},
+		{Name: "latest_time", In: "query", Required: false, Schema: &api.Schema{Type: "string", Format: "date-time"}},
+	},
+}
+
+var GetIdentificationServiceAreaRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityUUID"}},
+	},
+}
+
+var CreateIdentificationServiceAreaRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityUUID"}},
+	},
+	Body:         &api.Schema{Ref: "CreateIdentificationServiceAreaParameters"},
+	BodyRequired: true,
+}
+
+var UpdateIdentificationServiceAreaRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityUUID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+	Body:         &api.Schema{Ref: "UpdateIdentificationServiceAreaParameters"},
+	BodyRequired: true,
+}
+
+var DeleteIdentificationServiceAreaRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityUUID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
+
+var SearchSubscriptionsRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "area", In: "query", Required: true, Schema: &api.Schema{Ref: "GeoPolygonString"}},
+	},
+}
+
+var GetSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionUUID"}},
+	},
+}
+
+var CreateSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionUUID"}},
+	},
+	Body:         &api.Schema{Ref: "CreateSubscriptionParameters"},
+	BodyRequired: true,
+}
+
+var UpdateSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionUUID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+	Body:         &api.Schema{Ref: "UpdateSubscriptionParameters"},
+	BodyRequired: true,
+}
+
+var DeleteSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionUUID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
@@ -0,0 +1,842 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package scd
+
+import (
+	"context"
+	"example/api"
+	"net/http"
+	"net/url"
+)
+
+// Client sends the requests of the operations of this API to a server.
+type Client struct {
+	// BaseURL is the URL the paths of the operations are relative to, without
+	// trailing slash
+	BaseURL string
+	// HTTPClient sends the requests; http.DefaultClient is used if nil
+	HTTPClient *http.Client
+	// TokenSource provides the access tokens authorizing the requests, which
+	// are sent without access token if nil
+	TokenSource api.TokenSource
+}
+
+// QueryOperationalIntentReferences sends the request of the QueryOperationalIntentReferences operation.
+func (c *Client) QueryOperationalIntentReferences(ctx context.Context, req *QueryOperationalIntentReferencesRequest) (*QueryOperationalIntentReferencesResponseSet, error) {
+	path := "/scd/dss/v1/operational_intent_references/query"
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, QueryOperationalIntentReferencesSecurity, http.MethodPost, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &QueryOperationalIntentReferencesResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(QueryOperationalIntentReferenceResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetOperationalIntentReference sends the request of the GetOperationalIntentReference operation.
+func (c *Client) GetOperationalIntentReference(ctx context.Context, req *GetOperationalIntentReferenceRequest) (*GetOperationalIntentReferenceResponseSet, error) {
+	path := "/scd/dss/v1/operational_intent_references/" + url.PathEscape(string(req.Entityid))
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetOperationalIntentReferenceSecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetOperationalIntentReferenceResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(GetOperationalIntentReferenceResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CreateOperationalIntentReference sends the request of the CreateOperationalIntentReference operation.
+func (c *Client) CreateOperationalIntentReference(ctx context.Context, req *CreateOperationalIntentReferenceRequest) (*CreateOperationalIntentReferenceResponseSet, error) {
+	path := "/scd/dss/v1/operational_intent_references/" + url.PathEscape(string(req.Entityid))
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, CreateOperationalIntentReferenceSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &CreateOperationalIntentReferenceResponseSet{}
+	switch resp.StatusCode {
+	case 201:
+		response.Response201 = new(ChangeOperationalIntentReferenceResponse)
+		err = api.ReadJSON(resp, response.Response201)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(AirspaceConflictResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 412:
+		response.Response412 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response412)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateOperationalIntentReference sends the request of the UpdateOperationalIntentReference operation.
+func (c *Client) UpdateOperationalIntentReference(ctx context.Context, req *UpdateOperationalIntentReferenceRequest) (*UpdateOperationalIntentReferenceResponseSet, error) {
+	path := "/scd/dss/v1/operational_intent_references/" + url.PathEscape(string(req.Entityid)) + "/" + url.PathEscape(string(req.Ovn))
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, UpdateOperationalIntentReferenceSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &UpdateOperationalIntentReferenceResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(ChangeOperationalIntentReferenceResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(AirspaceConflictResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 412:
+		response.Response412 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response412)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteOperationalIntentReference sends the request of the DeleteOperationalIntentReference operation.
+func (c *Client) DeleteOperationalIntentReference(ctx context.Context, req *DeleteOperationalIntentReferenceRequest) (*DeleteOperationalIntentReferenceResponseSet, error) {
+	path := "/scd/dss/v1/operational_intent_references/" + url.PathEscape(string(req.Entityid)) + "/" + url.PathEscape(string(req.Ovn))
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, DeleteOperationalIntentReferenceSecurity, http.MethodDelete, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &DeleteOperationalIntentReferenceResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(ChangeOperationalIntentReferenceResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 412:
+		response.Response412 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response412)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// QueryConstraintReferences sends the request of the QueryConstraintReferences operation.
+func (c *Client) QueryConstraintReferences(ctx context.Context, req *QueryConstraintReferencesRequest) (*QueryConstraintReferencesResponseSet, error) {
+	path := "/scd/dss/v1/constraint_references/query"
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, QueryConstraintReferencesSecurity, http.MethodPost, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &QueryConstraintReferencesResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(QueryConstraintReferencesResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetConstraintReference sends the request of the GetConstraintReference operation.
+func (c *Client) GetConstraintReference(ctx context.Context, req *GetConstraintReferenceRequest) (*GetConstraintReferenceResponseSet, error) {
+	path := "/scd/dss/v1/constraint_references/" + url.PathEscape(string(req.Entityid))
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetConstraintReferenceSecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetConstraintReferenceResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(GetConstraintReferenceResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CreateConstraintReference sends the request of the CreateConstraintReference operation.
+func (c *Client) CreateConstraintReference(ctx context.Context, req *CreateConstraintReferenceRequest) (*CreateConstraintReferenceResponseSet, error) {
+	path := "/scd/dss/v1/constraint_references/" + url.PathEscape(string(req.Entityid))
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, CreateConstraintReferenceSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &CreateConstraintReferenceResponseSet{}
+	switch resp.StatusCode {
+	case 201:
+		response.Response201 = new(ChangeConstraintReferenceResponse)
+		err = api.ReadJSON(resp, response.Response201)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateConstraintReference sends the request of the UpdateConstraintReference operation.
+func (c *Client) UpdateConstraintReference(ctx context.Context, req *UpdateConstraintReferenceRequest) (*UpdateConstraintReferenceResponseSet, error) {
+	path := "/scd/dss/v1/constraint_references/" + url.PathEscape(string(req.Entityid)) + "/" + url.PathEscape(string(req.Ovn))
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, UpdateConstraintReferenceSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &UpdateConstraintReferenceResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(ChangeConstraintReferenceResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteConstraintReference sends the request of the DeleteConstraintReference operation.
+func (c *Client) DeleteConstraintReference(ctx context.Context, req *DeleteConstraintReferenceRequest) (*DeleteConstraintReferenceResponseSet, error) {
+	path := "/scd/dss/v1/constraint_references/" + url.PathEscape(string(req.Entityid)) + "/" + url.PathEscape(string(req.Ovn))
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, DeleteConstraintReferenceSecurity, http.MethodDelete, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &DeleteConstraintReferenceResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(ChangeConstraintReferenceResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// QuerySubscriptions sends the request of the QuerySubscriptions operation.
+func (c *Client) QuerySubscriptions(ctx context.Context, req *QuerySubscriptionsRequest) (*QuerySubscriptionsResponseSet, error) {
+	path := "/scd/dss/v1/subscriptions/query"
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, QuerySubscriptionsSecurity, http.MethodPost, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &QuerySubscriptionsResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(QuerySubscriptionsResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetSubscription sends the request of the GetSubscription operation.
+func (c *Client) GetSubscription(ctx context.Context, req *GetSubscriptionRequest) (*GetSubscriptionResponseSet, error) {
+	path := "/scd/dss/v1/subscriptions/" + url.PathEscape(string(req.Subscriptionid))
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetSubscriptionSecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetSubscriptionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(GetSubscriptionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CreateSubscription sends the request of the CreateSubscription operation.
+func (c *Client) CreateSubscription(ctx context.Context, req *CreateSubscriptionRequest) (*CreateSubscriptionResponseSet, error) {
+	path := "/scd/dss/v1/subscriptions/" + url.PathEscape(string(req.Subscriptionid))
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, CreateSubscriptionSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &CreateSubscriptionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(PutSubscriptionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateSubscription sends the request of the UpdateSubscription operation.
+func (c *Client) UpdateSubscription(ctx context.Context, req *UpdateSubscriptionRequest) (*UpdateSubscriptionResponseSet, error) {
+	path := "/scd/dss/v1/subscriptions/" + url.PathEscape(string(req.Subscriptionid)) + "/" + url.PathEscape(req.Version)
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, UpdateSubscriptionSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &UpdateSubscriptionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(PutSubscriptionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteSubscription sends the request of the DeleteSubscription operation.
+func (c *Client) DeleteSubscription(ctx context.Context, req *DeleteSubscriptionRequest) (*DeleteSubscriptionResponseSet, error) {
+	path := "/scd/dss/v1/subscriptions/" + url.PathEscape(string(req.Subscriptionid)) + "/" + url.PathEscape(req.Version)
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, DeleteSubscriptionSecurity, http.MethodDelete, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &DeleteSubscriptionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(DeleteSubscriptionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// MakeDssReport sends the request of the MakeDssReport operation.
+func (c *Client) MakeDssReport(ctx context.Context, req *MakeDssReportRequest) (*MakeDssReportResponseSet, error) {
+	path := "/scd/dss/v1/reports"
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, MakeDssReportSecurity, http.MethodPost, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &MakeDssReportResponseSet{}
+	switch resp.StatusCode {
+	case 201:
+		response.Response201 = new(ErrorReport)
+		err = api.ReadJSON(resp, response.Response201)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetUssAvailability sends the request of the GetUssAvailability operation.
+func (c *Client) GetUssAvailability(ctx context.Context, req *GetUssAvailabilityRequest) (*GetUssAvailabilityResponseSet, error) {
+	path := "/scd/dss/v1/uss_availability/" + url.PathEscape(req.UssId)
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetUssAvailabilitySecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetUssAvailabilityResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(UssAvailabilityStatusResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// SetUssAvailability sends the request of the SetUssAvailability operation.
+func (c *Client) SetUssAvailability(ctx context.Context, req *SetUssAvailabilityRequest) (*SetUssAvailabilityResponseSet, error) {
+	path := "/scd/dss/v1/uss_availability/" + url.PathEscape(req.UssId)
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, SetUssAvailabilitySecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &SetUssAvailabilityResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(UssAvailabilityStatusResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -17,6 +17,8 @@ def _parse_args():
     # Input/output specifications
     parser.add_argument('--api', dest='apis', type=str, action='append',
                         help='Source YAML to preprocess along with tags (if applicable) and the name of the API.  Form of --api PATH_TO_YAML#TAG1,TAG2@API_NAME[/PATH_PREFIX]')
+    parser.add_argument('--api_overlay', dest='api_overlays', type=str, action='append', default=[],
+                        help='YAML merged into the source YAML of an API before it is preprocessed, to extend an API not owned by the caller.  Form of --api_overlay API_NAME=PATH_TO_YAML; mappings are merged recursively and other values of the overlay replace those of the source')
     parser.add_argument('--api_folder', dest='api_folder', type=str,
                         default=None,
                         help='Folder that will hold the generated output for APIs')
@@ -31,6 +33,21 @@ def _parse_args():
     return parser.parse_args()
 
 
+def _merge_overlay(spec: Dict, overlay: Dict) -> Dict:
+    """Merge overlay into spec recursively.
+
+    :param spec: OpenAPI specification to extend, modified in place
+    :param overlay: Partial OpenAPI specification whose mappings are merged into those of spec, and whose other values replace those of spec
+    :return: spec
+    """
+    for k, v in overlay.items():
+        if isinstance(v, dict) and isinstance(spec.get(k, None), dict):
+            _merge_overlay(spec[k], v)
+        else:
+            spec[k] = v
+    return spec
+
+
 def _generate_apis(api_list: List[apis.API], apis_folder: str, api_import: str, ensure_500: bool):
     """Generate Go libraries for APIs.
 
@@ -89,6 +106,31 @@ def _generate_apis(api_list: List[apis.API], apis_folder: str, api_import: str,
             f.write(rendering.template_content('header', server_template_vars))
             f.write(rendering.template_content('server', server_template_vars))
 
+        # Generate Go request schemas
+        schemas, request_schemas, new_imports = rendering.validation(api, api_package)
+        validation_template_vars = {
+            '<PACKAGE>': api.package,
+            '<IMPORTS>': rendering.imports(sorted(new_imports) + [api_import]),
+            '<API_PACKAGE>': api_package,
+            '<SCHEMAS>': '\n'.join(schemas),
+            '<REQUEST_SCHEMAS>': '\n'.join(request_schemas),
+        }
+        with open(os.path.join(api_folder, 'validation.gen.go'), 'w') as f:
+            f.write(rendering.template_content('header', validation_template_vars))
+            f.write(rendering.template_content('validation', validation_template_vars))
+
+        # Generate Go client
+        client_operations, new_imports = rendering.client(api, api_package, ensure_500)
+        client_template_vars = {
+            '<PACKAGE>': api.package,
+            '<IMPORTS>': rendering.imports(sorted(new_imports) + [api_import]),
+            '<API_PACKAGE>': api_package,
+            '<OPERATIONS>': '\n'.join(client_operations),
+        }
+        with open(os.path.join(api_folder, 'client.gen.go'), 'w') as f:
+            f.write(rendering.template_content('header', client_template_vars))
+            f.write(rendering.template_content('client', client_template_vars))
+
 
 def _generate_example(api_list: List[apis.API], output_folder: str, api_import: str):
     """Generate example implementations and entry point.
@@ -127,6 +169,12 @@ def _generate_example(api_list: List[apis.API], output_folder: str, api_import:
 def main():
     args = _parse_args()
 
+    # Parse API overlays
+    overlays: Dict[str, List[str]] = {}
+    for overlay_declaration in args.api_overlays:
+        package, overlay_yaml = overlay_declaration.split('=', 1)
+        overlays.setdefault(package, []).append(overlay_yaml)
+
     # Parse API definitions
     api_list: List[apis.API] = []
     for api_declaration in args.apis:
@@ -150,12 +198,18 @@ def main():
 
         with open(input_yaml, mode='r') as f:
             spec = yaml.full_load(f)
+        for overlay_yaml in overlays.pop(package, []):
+            with open(overlay_yaml, mode='r') as f:
+                _merge_overlay(spec, yaml.full_load(f))
 
         api = apis.make_api(package, api_path, spec)
         if tags:
             api.filter_operations(tags)
         api_list.append(api)
 
+    if overlays:
+        raise ValueError('No API named {} to apply overlays to'.format(', '.join(sorted(overlays))))
+
     # Render Go code
     if args.api_folder:
         _generate_apis(api_list, args.api_folder, args.api_import, True)
@@ -1,5 +1,5 @@
 import dataclasses
-from typing import Dict, List, Tuple
+from typing import Dict, List, Optional, Tuple
 
 import data_types
 import formatting
@@ -18,6 +18,12 @@ class StringParameter:
     go_type: str
     """The Go data type that holds the value of this parameter"""
 
+    required: bool = False
+    """True if this parameter must be specified to invoke the operation"""
+
+    schema: Optional[Dict] = None
+    """OpenAPI schema of the value of this parameter, if defined"""
+
     @property
     def go_field_name(self) -> str:
         """Go-style field name for this parameter in the Operation's `request_type_name`"""
@@ -112,6 +118,9 @@ class Operation:
     responses: List[Response]
     """All defined responses that may be returned from this operation"""
 
+    json_request_body_required: bool = False
+    """True if the request body must be specified to invoke this operation"""
+
     @property
     def interface_name(self) -> str:
         """Go-style name of this operation, as would appear in an interface"""
@@ -152,6 +161,7 @@ def _parse_parameters(schema: Dict) -> Tuple[List[StringParameter], List[StringP
         parameter_name = parameter['name']
         parameter_description = parameter.get('description', '')
         parameter_in = parameter['in']
+        parameter_required = parameter.get('required', False) or parameter_in == 'path'
         if 'schema' in parameter:
             parameter_field, further_types = data_types.make_object_field(
                 '', parameter_name, parameter['schema'], set())
@@ -164,12 +174,16 @@ def _parse_parameters(schema: Dict) -> Tuple[List[StringParameter], List[StringP
             path_parameters.append(
                 StringParameter(name=parameter_name,
                                 description=parameter_description,
-                                go_type=parameter_type))
+                                go_type=parameter_type,
+                                required=parameter_required,
+                                schema=parameter.get('schema', None)))
         elif parameter_in == 'query':
             query_parameters.append(
                 StringParameter(name=parameter_name,
                                 description=parameter_description,
-                                go_type=parameter_type))
+                                go_type=parameter_type,
+                                required=parameter_required,
+                                schema=parameter.get('schema', None)))
         else:
             raise NotImplementedError(
                 'Parameter in "{}" (`{}`) not yet implemented'.format(
@@ -207,6 +221,7 @@ def make_operations(path: str, schema: Dict) -> Tuple[List[Operation], List[data
         tags = action.get('tags', [])
         component_name = action.get('requestBody', {}).get('content', {}).get('application/json', {}).get('schema', {}).get('$ref', '')
         request_body_type = data_types.get_data_type_name(component_name, 'requestBody')
+        request_body_required = action.get('requestBody', {}).get('required', False)
 
         path_parameters, query_parameters, further_data_types = _parse_parameters(action)
         path_parameters += common_path_parameters
@@ -240,7 +255,8 @@ def make_operations(path: str, schema: Dict) -> Tuple[List[Operation], List[data
             path_parameters=path_parameters,
             query_parameters=query_parameters,
             json_request_body_type=request_body_type,
-            responses=responses
+            responses=responses,
+            json_request_body_required=request_body_required,
         ))
 
     return declared_operations, additional_data_types
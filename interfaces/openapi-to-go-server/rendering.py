@@ -1,8 +1,9 @@
-import re
+import json
 from typing import Dict, List, Set, Tuple
 
 import apis
 import data_types
+import formatting
 import operations
 
 
@@ -220,11 +221,10 @@ def routes(api: apis.API, api_package: str, ensure_500: bool) -> Tuple[List[str]
 
     # Define a top-level routed HTTP handler function for each operation
     for operation in api.operations:
-        imports.add('regexp')
         imports.add('net/http')
         lines.append(
-            'func (s *APIRouter) {}(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {{'.format(
-                operation.interface_name))
+            'func (s *APIRouter) {}(params {}.PathParams, w http.ResponseWriter, r *http.Request) {{'.format(
+                operation.interface_name, api_package))
 
         body: List[str] = []
 
@@ -237,20 +237,55 @@ def routes(api: apis.API, api_package: str, ensure_500: bool) -> Tuple[List[str]
         body.append(
             'req.Auth = s.Authorizer.Authorize(w, r, {}Security)'.format(
                 operation.interface_name))
+        body.append('{}.RecordAuthorization(r, req.Auth)'.format(api_package))
+        body.append('if req.Auth.Handled {')
+        body.extend(indent(['return'], 1))
+        body.append('}')
         body.append('')
 
+        # Validate the request against the API schema, if enabled
+        if _validates_request(operation):
+            body.extend(comment(['Validate request']))
+            body.append('if s.Options.ValidateRequests && req.Auth.Error == nil {')
+            body.extend(indent([
+                'if violations := Schemas.ValidateRequest(r, params, &{}RequestSchema); len(violations) > 0 {{'.format(operation.interface_name),
+            ], 1))
+            body.extend(indent([
+                '{}.WriteViolations(w, violations)'.format(api_package),
+                'return',
+            ], 2))
+            body.extend(indent(['}'], 1))
+            body.append('}')
+            body.append('')
+
         # Parse any path parameters
         if operation.path_parameters:
             body.extend(comment(['Parse path parameters']))
-            body.append('pathMatch := exp.FindStringSubmatch(r.URL.Path)')
-            for i, p in enumerate(operation.path_parameters):
+            for p in operation.path_parameters:
+                primitive_type = api.primitive_go_type_for(p.go_type)
                 if p.go_type == 'string':
                     body.append(
-                        'req.{} = pathMatch[{}]'.format(p.go_field_name, i + 1))
-                else:
+                        'req.{} = params.Get("{}")'.format(p.go_field_name, p.name))
+                elif primitive_type == 'string':
                     body.append(
-                        'req.{} = {}(pathMatch[{}])'.format(p.go_field_name,
-                                                            p.go_type, i + 1))
+                        'req.{} = {}(params.Get("{}"))'.format(p.go_field_name, p.go_type, p.name))
+                elif primitive_type.startswith('int') or primitive_type.startswith('float'):
+                    imports.add('strconv')
+                    if primitive_type.startswith('int'):
+                        parse_func = 'ParseInt'
+                        parse_params = '10, {}'.format(primitive_type[len('int'):])
+                    else:
+                        parse_func = 'ParseFloat'
+                        parse_params = primitive_type[len('float'):]
+                    body.append('if v, err := strconv.{}(params.Get("{}"), {}); err == nil {{'.format(parse_func, p.name, parse_params))
+                    body.extend(indent(['req.{} = {}(v)'.format(p.go_field_name, p.go_type)], 1))
+                    body.append('} else {')
+                    body.extend(indent([
+                        'http.Error(w, "Invalid path parameter {}: "+err.Error(), http.StatusBadRequest)'.format(p.name),
+                        'return'], 1))
+                    body.append('}')
+                else:
+                    raise NotImplementedError()
             body.append('')
 
         # Capture/parse any query parameters
@@ -308,7 +343,7 @@ def routes(api: apis.API, api_package: str, ensure_500: bool) -> Tuple[List[str]
         # Actually invoke the API Implementation with the processed request to obtain the response
         imports.add('context')
         body.extend(comment(['Call implementation']))
-        body.append('ctx, cancel := context.WithCancel(r.Context())')
+        body.append('ctx, cancel := context.WithCancel({}.WithResponseHeader(r.Context(), w))'.format(api_package))
         body.append('defer cancel()')
         body.append('response := s.Implementation.{}(ctx, &req)'.format(
             operation.interface_name))
@@ -348,22 +383,289 @@ def routing(api: apis.API, api_package: str) -> List[str]:
     lines: List[str] = []
     lines.append(
         'router := APIRouter{Implementation: impl, Authorizer: auth, Routes: make([]*%s.Route, %d)}' % (api_package, len(api.operations)))
+    lines.append('for _, option := range options {')
+    lines.extend(indent(['option(&router.Options)'], 1))
+    lines.append('}')
     lines.append('')
-    first_assignment = True
     for i, operation in enumerate(api.operations):
         prefix = ('/' + api.path_prefix) if api.path_prefix else ''
-        path_regex = prefix + re.sub(r'{([^}]*)}', r'(?P<\1>[^/]*)', operation.path)
-        lines.append('pattern {}= regexp.MustCompile("^{}$")'.format(
-            ':' if first_assignment else '', path_regex))
+        path = prefix + operation.path
         lines.append(
-            'router.Routes[%d] = &%s.Route{Method: %s, Pattern: pattern, Handler: router.%s}' % (
-            i, api_package, operation.verb_const_name, operation.interface_name))
-        lines.append('')
-        first_assignment = False
+            'router.Routes[%d] = &%s.Route{Method: %s, Path: "%s", Handler: router.%s}' % (
+            i, api_package, operation.verb_const_name, path, operation.interface_name))
+    lines.append('')
+    lines.append('router.router = %s.NewRouter(router.Routes...)' % api_package)
     lines.append('return router')
     return lines
 
 
+def _validates_request(operation: operations.Operation) -> bool:
+    """True iff requests of the operation have any parameter or body to validate against the API schema"""
+    return bool(operation.path_parameters or operation.query_parameters or operation.json_request_body_type)
+
+
+def _referenced_schema_name(schema: Dict, context: str) -> str:
+    """Name of the component schema `schema` refers to, or blank if it does not refer to a component schema"""
+    if '$ref' in schema:
+        return data_types.get_data_type_name(schema['$ref'], context)
+    elif 'anyOf' in schema or 'allOf' in schema:
+        return data_types.parse_referenced_type_name(schema, context)
+    return ''
+
+
+def _schema_literal(schema: Dict, context: str, api_package: str, imports: Set[str]) -> List[str]:
+    """Generate the fields of a Go Schema literal representing an OpenAPI schema.
+
+    :param schema: OpenAPI schema to render
+    :param context: Location of the schema in the API, for error messages
+    :param api_package: Name of root/common API package
+    :param imports: Go packages that need to be imported, to which any package used by the literal is added
+    :return: Lines of Go code of the fields of the Schema literal, each ending with a comma
+    """
+    ref = _referenced_schema_name(schema, context)
+    if ref:
+        return ['Ref: "{}",'.format(ref)]
+
+    lines: List[str] = []
+    if 'type' in schema:
+        lines.append('Type: "{}",'.format(schema['type']))
+    if 'format' in schema:
+        lines.append('Format: "{}",'.format(schema['format']))
+    if 'enum' in schema:
+        lines.append('Enum: []string{%s},' % ', '.join(
+            json.dumps(v if isinstance(v, str) else json.dumps(v)) for v in schema['enum']))
+    if schema.get('nullable', False):
+        lines.append('Nullable: true,')
+    for bound in ('minimum', 'maximum'):
+        exclusive = 'exclusive' + bound.capitalize()
+        value, is_exclusive = schema.get(bound, None), schema.get(exclusive, False)
+        if not isinstance(is_exclusive, bool):
+            # OpenAPI 3.1 states exclusive bounds as numbers
+            value, is_exclusive = is_exclusive, True
+        if value is not None:
+            lines.append('{}: {}.Float64({}),'.format(bound.capitalize(), api_package, value))
+        if is_exclusive:
+            lines.append('{}: true,'.format(formatting.capitalize_first_letter(exclusive)))
+    for length in ('minLength', 'maxLength', 'minItems', 'maxItems'):
+        if length in schema:
+            lines.append('{}: {}.Int({}),'.format(formatting.capitalize_first_letter(length), api_package, schema[length]))
+    if 'pattern' in schema:
+        imports.add('regexp')
+        pattern = schema['pattern']
+        lines.append('Pattern: regexp.MustCompile({}),'.format(
+            '`{}`'.format(pattern) if '`' not in pattern else json.dumps(pattern)))
+    if 'items' in schema:
+        lines.append('Items: &%s.Schema{' % api_package)
+        lines.extend(indent(_schema_literal(schema['items'], context + '.items', api_package, imports), 1))
+        lines.append('},')
+    if schema.get('properties', None):
+        lines.append('Properties: map[string]*%s.Schema{' % api_package)
+        for name, property_schema in schema['properties'].items():
+            lines.append('"%s": {' % name)
+            lines.extend(indent(_schema_literal(property_schema, context + '.' + name, api_package, imports), 1))
+            lines.append('},')
+        lines.append('},')
+    if schema.get('required', None):
+        lines.append('Required: []string{%s},' % ', '.join('"{}"'.format(r) for r in schema['required']))
+    return lines
+
+
+def _request_schema_names(api: apis.API) -> List[str]:
+    """Names of the component schemas needed to validate the requests of the API's operations, in declaration order"""
+    names: Set[str] = set()
+    to_check: List[Dict] = []
+    for operation in api.operations:
+        for p in operation.path_parameters + operation.query_parameters:
+            if p.schema:
+                to_check.append(p.schema)
+        if operation.json_request_body_type:
+            to_check.append({'$ref': '#/components/schemas/' + operation.json_request_body_type})
+    while to_check:
+        schema = to_check.pop()
+        if not isinstance(schema, dict):
+            continue
+        ref = _referenced_schema_name(schema, 'request schema')
+        if ref:
+            if ref not in names:
+                names.add(ref)
+                if ref not in api.schemas:
+                    raise ValueError('Schema {} is not declared in {} API'.format(ref, api.package))
+                to_check.append(api.schemas[ref])
+            continue
+        if 'items' in schema:
+            to_check.append(schema['items'])
+        to_check.extend(schema.get('properties', {}).values())
+    return [name for name in api.schemas if name in names]
+
+
+def validation(api: apis.API, api_package: str) -> Tuple[List[str], List[str], Set[str]]:
+    """Generate Go code defining the schemas the requests of the API's operations are validated against.
+
+    :param api: API to have its request schemas rendered
+    :param api_package: Name of root/common API package
+    :return:
+        * Lines of Go code of the entries of the Schemas of the API
+        * Lines of Go code defining the RequestSchema of each operation
+        * Go packages that need to be imported
+    """
+    imports: Set[str] = set()
+
+    schema_lines: List[str] = []
+    for name in _request_schema_names(api):
+        schema_lines.append('"%s": {' % name)
+        schema_lines.extend(indent(_schema_literal(api.schemas[name], name, api_package, imports), 1))
+        schema_lines.append('},')
+
+    lines: List[str] = []
+    for operation in api.operations:
+        if not _validates_request(operation):
+            continue
+        lines.append('var %sRequestSchema = %s.RequestSchema{' % (operation.interface_name, api_package))
+        body: List[str] = []
+        if operation.path_parameters or operation.query_parameters:
+            body.append('Parameters: []%s.ParameterSchema{' % api_package)
+            for p, p_in in [(p, 'path') for p in operation.path_parameters] + [(q, 'query') for q in operation.query_parameters]:
+                body.append('{Name: "%s", In: "%s", Required: %s, Schema: &%s.Schema{%s}},' % (
+                    p.name, p_in, 'true' if p.required else 'false', api_package,
+                    ' '.join(_schema_literal(p.schema or {'type': 'string'}, operation.interface_name + '.' + p.name, api_package, imports)).rstrip(',')))
+            body.append('},')
+        if operation.json_request_body_type:
+            body.append('Body: &%s.Schema{Ref: "%s"},' % (api_package, operation.json_request_body_type))
+            if operation.json_request_body_required:
+                body.append('BodyRequired: true,')
+        lines.extend(indent(body, 1))
+        lines.append('}')
+        lines.append('')
+    if lines:
+        lines.pop()
+
+    return schema_lines, lines, imports
+
+
+def _client_string_expression(api: apis.API, value: str, go_type: str, imports: Set[str]) -> str:
+    """Generate a Go expression formatting a parameter value as a string.
+
+    :param api: API defining the type of the parameter
+    :param value: Go expression of the parameter value
+    :param go_type: Go type of the parameter value
+    :param imports: Go packages that need to be imported, to which any package used by the expression is added
+    :return: Go expression of type string
+    """
+    if go_type == 'string':
+        return value
+    primitive_type = api.primitive_go_type_for(go_type)
+    if primitive_type == 'string':
+        return 'string({})'.format(value)
+    elif primitive_type.startswith('int') or primitive_type.startswith('float'):
+        imports.add('fmt')
+        return 'fmt.Sprint({})'.format(value)
+    else:
+        raise NotImplementedError()
+
+
+def client(api: apis.API, api_package: str, ensure_500: bool) -> Tuple[List[str], Set[str]]:
+    """Generate Go code of a client method sending the request of each operation.
+
+    :param api: API to have its operation clients rendered
+    :param api_package: Name of root/common API package
+    :param ensure_500: If True, add a 500 response to all operations that don't already define a 500 response
+    :return:
+        * Lines of Go code defining the client methods
+        * Go packages that need to be imported
+    """
+    lines: List[str] = []
+    imports: Set[str] = {'net/http'}
+
+    for operation in api.operations:
+        imports.add('context')
+        lines.extend(comment(['{} sends the request of the {} operation.'.format(operation.interface_name, operation.interface_name)]))
+        lines.append(
+            'func (c *Client) {}(ctx context.Context, req *{}) (*{}, error) {{'.format(
+                operation.interface_name, operation.request_type_name, operation.response_type_name))
+
+        body: List[str] = []
+
+        # Build the path from the path parameters
+        prefix = ('/' + api.path_prefix) if api.path_prefix else ''
+        parameters = {p.name: p for p in operation.path_parameters}
+        path_parts: List[str] = []
+        static = ''
+        for segment in (prefix + operation.path).split('/')[1:]:
+            static += '/'
+            if segment.startswith('{') and segment.endswith('}'):
+                p = parameters[segment[1:-1]]
+                imports.add('net/url')
+                path_parts.append('"{}"'.format(static))
+                path_parts.append('url.PathEscape({})'.format(
+                    _client_string_expression(api, 'req.' + p.go_field_name, p.go_type, imports)))
+                static = ''
+            else:
+                static += segment
+        if static:
+            path_parts.append('"{}"'.format(static))
+        body.append('path := {}'.format(' + '.join(path_parts)))
+
+        # Set any query parameters
+        query = 'nil'
+        if operation.query_parameters:
+            imports.add('net/url')
+            query = 'query'
+            body.append('query := url.Values{}')
+            for q in operation.query_parameters:
+                body.append('if req.{} != nil {{'.format(q.go_field_name))
+                body.extend(indent(['query.Set("{}", {})'.format(
+                    q.name, _client_string_expression(api, '*req.' + q.go_field_name, q.go_type, imports))], 1))
+                body.append('}')
+
+        # Set the request body, if defined
+        request_body = 'nil'
+        if operation.json_request_body_type:
+            request_body = 'body'
+            body.append('var body interface{}')
+            body.append('if req.Body != nil {')
+            body.extend(indent(['body = req.Body'], 1))
+            body.append('}')
+        body.append('')
+
+        # Send the request
+        body.append('resp, err := {}.SendRequest(ctx, c.HTTPClient, c.TokenSource, {}Security, {}, c.BaseURL+path, {}, {})'.format(
+            api_package, operation.interface_name, operation.verb_const_name, query, request_body))
+        body.append('if err != nil {')
+        body.extend(indent(['return nil, err'], 1))
+        body.append('}')
+        body.append('defer resp.Body.Close()')
+        body.append('')
+
+        # Read the response into the field of its status code
+        body.append('response := &{}{{}}'.format(operation.response_type_name))
+        body.append('switch resp.StatusCode {')
+        responses = [r for r in operation.responses]
+        if ensure_500 and 500 not in {r.code for r in responses}:
+            responses.append(operations.Response(code=500, description='', json_body_type='{}.InternalServerErrorBody'.format(api_package)))
+        for response in responses:
+            body_type = response.json_body_type if response.json_body_type else '{}.EmptyResponseBody'.format(api_package)
+            body.append('case {}:'.format(response.code))
+            body.extend(indent([
+                'response.{} = new({})'.format(response.response_set_field, body_type),
+                'err = {}.ReadJSON(resp, response.{})'.format(api_package, response.response_set_field)], 1))
+        body.append('default:')
+        body.extend(indent(['err = {}.NewResponseError(resp)'.format(api_package)], 1))
+        body.append('}')
+        body.append('if err != nil {')
+        body.extend(indent(['return nil, err'], 1))
+        body.append('}')
+        body.append('return response, nil')
+
+        lines.extend(indent(body, 1))
+
+        lines.append('}')
+        lines.append('')
+    if lines:
+        lines.pop()
+
+    return lines, imports
+
+
 def example_implementation(api: apis.API, implementation_name: str) -> List[str]:
     """Generate Go code for a dummy API Implementation and a main routine to run it.
 
@@ -0,0 +1,285 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package auxv1
+
+import (
+	"context"
+	"github.com/interuss/dss/pkg/api"
+	"net/http"
+	"net/url"
+)
+
+// Client sends the requests of the operations of this API to a server.
+type Client struct {
+	// BaseURL is the URL the paths of the operations are relative to, without
+	// trailing slash
+	BaseURL string
+	// HTTPClient sends the requests; http.DefaultClient is used if nil
+	HTTPClient *http.Client
+	// TokenSource provides the access tokens authorizing the requests, which
+	// are sent without access token if nil
+	TokenSource api.TokenSource
+}
+
+// GetVersion sends the request of the GetVersion operation.
+func (c *Client) GetVersion(ctx context.Context, req *GetVersionRequest) (*GetVersionResponseSet, error) {
+	path := "/aux/v1/version"
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetVersionSecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetVersionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(VersionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ValidateOauth sends the request of the ValidateOauth operation.
+func (c *Client) ValidateOauth(ctx context.Context, req *ValidateOauthRequest) (*ValidateOauthResponseSet, error) {
+	path := "/aux/v1/validate_oauth"
+	query := url.Values{}
+	if req.Owner != nil {
+		query.Set("owner", *req.Owner)
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, ValidateOauthSecurity, http.MethodGet, c.BaseURL+path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &ValidateOauthResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(api.EmptyResponseBody)
+		err = api.ReadJSON(resp, response.Response200)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListDSSReports sends the request of the ListDSSReports operation.
+func (c *Client) ListDSSReports(ctx context.Context, req *ListDSSReportsRequest) (*ListDSSReportsResponseSet, error) {
+	path := "/aux/v1/reports"
+	query := url.Values{}
+	if req.Reporter != nil {
+		query.Set("reporter", *req.Reporter)
+	}
+	if req.EarliestTime != nil {
+		query.Set("earliest_time", *req.EarliestTime)
+	}
+	if req.LatestTime != nil {
+		query.Set("latest_time", *req.LatestTime)
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, ListDSSReportsSecurity, http.MethodGet, c.BaseURL+path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &ListDSSReportsResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(ListDSSReportsResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetDSSReport sends the request of the GetDSSReport operation.
+func (c *Client) GetDSSReport(ctx context.Context, req *GetDSSReportRequest) (*GetDSSReportResponseSet, error) {
+	path := "/aux/v1/reports/" + url.PathEscape(req.ReportId)
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetDSSReportSecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetDSSReportResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(DSSReport)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListTokenRevocations sends the request of the ListTokenRevocations operation.
+func (c *Client) ListTokenRevocations(ctx context.Context, req *ListTokenRevocationsRequest) (*ListTokenRevocationsResponseSet, error) {
+	path := "/aux/v1/token_revocations"
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, ListTokenRevocationsSecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &ListTokenRevocationsResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(ListTokenRevocationsResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CreateTokenRevocation sends the request of the CreateTokenRevocation operation.
+func (c *Client) CreateTokenRevocation(ctx context.Context, req *CreateTokenRevocationRequest) (*CreateTokenRevocationResponseSet, error) {
+	path := "/aux/v1/token_revocations"
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, CreateTokenRevocationSecurity, http.MethodPost, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &CreateTokenRevocationResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(TokenRevocation)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteTokenRevocation sends the request of the DeleteTokenRevocation operation.
+func (c *Client) DeleteTokenRevocation(ctx context.Context, req *DeleteTokenRevocationRequest) (*DeleteTokenRevocationResponseSet, error) {
+	path := "/aux/v1/token_revocations/" + url.PathEscape(req.RevocationId)
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, DeleteTokenRevocationSecurity, http.MethodDelete, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &DeleteTokenRevocationResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(TokenRevocation)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
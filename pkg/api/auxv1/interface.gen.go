@@ -7,8 +7,9 @@ import (
 )
 
 var (
-	DssWriteIdentificationServiceAreasScope = api.RequiredScope("dss.write.identification_service_areas")
 	DssReadIdentificationServiceAreasScope  = api.RequiredScope("dss.read.identification_service_areas")
+	DssWriteIdentificationServiceAreasScope = api.RequiredScope("dss.write.identification_service_areas")
+	DssAdminScope                           = api.RequiredScope("dss.admin")
 	GetVersionSecurity                      = []api.AuthorizationOption{}
 	ValidateOauthSecurity                   = []api.AuthorizationOption{
 		{
@@ -18,6 +19,31 @@ var (
 			"Auth": {DssWriteIdentificationServiceAreasScope},
 		},
 	}
+	ListDSSReportsSecurity = []api.AuthorizationOption{
+		{
+			"Auth": {DssAdminScope},
+		},
+	}
+	GetDSSReportSecurity = []api.AuthorizationOption{
+		{
+			"Auth": {DssAdminScope},
+		},
+	}
+	ListTokenRevocationsSecurity = []api.AuthorizationOption{
+		{
+			"Auth": {DssAdminScope},
+		},
+	}
+	CreateTokenRevocationSecurity = []api.AuthorizationOption{
+		{
+			"Auth": {DssAdminScope},
+		},
+	}
+	DeleteTokenRevocationSecurity = []api.AuthorizationOption{
+		{
+			"Auth": {DssAdminScope},
+		},
+	}
 )
 
 type GetVersionRequest struct {
@@ -53,10 +79,157 @@ type ValidateOauthResponseSet struct {
 	Response500 *api.InternalServerErrorBody
 }
 
+type ListDSSReportsRequest struct {
+	// Only list the reports submitted by this manager.
+	Reporter *string
+
+	// Only list the reports received at or after this time, formatted as RFC 3339.
+	EarliestTime *string
+
+	// Only list the reports received at or before this time, formatted as RFC 3339.
+	LatestTime *string
+
+	// The result of attempting to authorize this request
+	Auth api.AuthorizationResult
+}
+type ListDSSReportsResponseSet struct {
+	// The reports were successfully listed.
+	Response200 *ListDSSReportsResponse
+
+	// One or more parameters were invalid.
+	Response400 *ErrorResponse
+
+	// Bearer access token was not provided in Authorization header, token could not be decoded, or token was invalid.
+	Response401 *ErrorResponse
+
+	// The access token was decoded successfully but did not include a scope appropriate to this endpoint.
+	Response403 *ErrorResponse
+
+	// Strategic conflict detection is not enabled on this DSS.
+	Response404 *ErrorResponse
+
+	// Auto-generated internal server error response
+	Response500 *api.InternalServerErrorBody
+}
+
+type GetDSSReportRequest struct {
+	// ID assigned by the DSS to the report.
+	ReportId string
+
+	// The result of attempting to authorize this request
+	Auth api.AuthorizationResult
+}
+type GetDSSReportResponseSet struct {
+	// The report was successfully retrieved.
+	Response200 *DSSReport
+
+	// The report ID is invalid.
+	Response400 *ErrorResponse
+
+	// Bearer access token was not provided in Authorization header, token could not be decoded, or token was invalid.
+	Response401 *ErrorResponse
+
+	// The access token was decoded successfully but did not include a scope appropriate to this endpoint.
+	Response403 *ErrorResponse
+
+	// The report does not exist, or strategic conflict detection is not enabled on this DSS.
+	Response404 *ErrorResponse
+
+	// Auto-generated internal server error response
+	Response500 *api.InternalServerErrorBody
+}
+
+type ListTokenRevocationsRequest struct {
+	// The result of attempting to authorize this request
+	Auth api.AuthorizationResult
+}
+type ListTokenRevocationsResponseSet struct {
+	// The revocations were successfully listed.
+	Response200 *ListTokenRevocationsResponse
+
+	// Bearer access token was not provided in Authorization header, token could not be decoded, or token was invalid.
+	Response401 *ErrorResponse
+
+	// The access token was decoded successfully but did not include a scope appropriate to this endpoint.
+	Response403 *ErrorResponse
+
+	// Auto-generated internal server error response
+	Response500 *api.InternalServerErrorBody
+}
+
+type CreateTokenRevocationRequest struct {
+	// The data contained in the body of this request, if it parsed correctly
+	Body *CreateTokenRevocationParameters
+
+	// The error encountered when attempting to parse the body of this request
+	BodyParseError error
+
+	// The result of attempting to authorize this request
+	Auth api.AuthorizationResult
+}
+type CreateTokenRevocationResponseSet struct {
+	// The revocation was successfully created and applies immediately to subsequent requests.
+	Response200 *TokenRevocation
+
+	// The request body was invalid.
+	Response400 *ErrorResponse
+
+	// Bearer access token was not provided in Authorization header, token could not be decoded, or token was invalid.
+	Response401 *ErrorResponse
+
+	// The access token was decoded successfully but did not include a scope appropriate to this endpoint.
+	Response403 *ErrorResponse
+
+	// Auto-generated internal server error response
+	Response500 *api.InternalServerErrorBody
+}
+
+type DeleteTokenRevocationRequest struct {
+	// ID assigned by the DSS to the revocation.
+	RevocationId string
+
+	// The result of attempting to authorize this request
+	Auth api.AuthorizationResult
+}
+type DeleteTokenRevocationResponseSet struct {
+	// The revocation was successfully deleted.
+	Response200 *TokenRevocation
+
+	// The revocation ID is invalid.
+	Response400 *ErrorResponse
+
+	// Bearer access token was not provided in Authorization header, token could not be decoded, or token was invalid.
+	Response401 *ErrorResponse
+
+	// The access token was decoded successfully but did not include a scope appropriate to this endpoint.
+	Response403 *ErrorResponse
+
+	// The revocation does not exist.
+	Response404 *ErrorResponse
+
+	// Auto-generated internal server error response
+	Response500 *api.InternalServerErrorBody
+}
+
 type Implementation interface {
 	// Queries the version of the DSS.
 	GetVersion(ctx context.Context, req *GetVersionRequest) GetVersionResponseSet
 
 	// Validate Oauth token against the DSS.
 	ValidateOauth(ctx context.Context, req *ValidateOauthRequest) ValidateOauthResponseSet
+
+	// Lists the reports submitted to the DSS by USSs.
+	ListDSSReports(ctx context.Context, req *ListDSSReportsRequest) ListDSSReportsResponseSet
+
+	// Retrieves a report submitted to the DSS by a USS.
+	GetDSSReport(ctx context.Context, req *GetDSSReportRequest) GetDSSReportResponseSet
+
+	// Lists the access token revocations.
+	ListTokenRevocations(ctx context.Context, req *ListTokenRevocationsRequest) ListTokenRevocationsResponseSet
+
+	// Revokes either an access token or all the access tokens issued to a subject until now.
+	CreateTokenRevocation(ctx context.Context, req *CreateTokenRevocationRequest) CreateTokenRevocationResponseSet
+
+	// Deletes an access token revocation.
+	DeleteTokenRevocation(ctx context.Context, req *DeleteTokenRevocationRequest) DeleteTokenRevocationResponseSet
 }
@@ -3,36 +3,37 @@ package auxv1
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/interuss/dss/pkg/api"
 	"net/http"
-	"regexp"
 )
 
 type APIRouter struct {
 	Routes         []*api.Route
 	Implementation Implementation
 	Authorizer     api.Authorizer
+	Options        api.RouterOptions
+
+	router *api.Router
 }
 
 // *auxv1.APIRouter (type defined above) implements the api.PartialRouter interface
 func (s *APIRouter) Handle(w http.ResponseWriter, r *http.Request) bool {
-	for _, route := range s.Routes {
-		if route.Method == r.Method && route.Pattern.MatchString(r.URL.Path) {
-			route.Handler(route.Pattern, w, r)
-			return true
-		}
-	}
-	return false
+	return s.router.Handle(w, r)
 }
 
-func (s *APIRouter) GetVersion(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) GetVersion(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req GetVersionRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, GetVersionSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.GetVersion(ctx, &req)
 
@@ -48,11 +49,23 @@ func (s *APIRouter) GetVersion(exp *regexp.Regexp, w http.ResponseWriter, r *htt
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) ValidateOauth(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) ValidateOauth(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req ValidateOauthRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, ValidateOauthSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &ValidateOauthRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Copy query parameters
 	query := r.URL.Query()
@@ -63,7 +76,7 @@ func (s *APIRouter) ValidateOauth(exp *regexp.Regexp, w http.ResponseWriter, r *
 	}
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.ValidateOauth(ctx, &req)
 
@@ -87,14 +100,282 @@ func (s *APIRouter) ValidateOauth(exp *regexp.Regexp, w http.ResponseWriter, r *
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func MakeAPIRouter(impl Implementation, auth api.Authorizer) APIRouter {
-	router := APIRouter{Implementation: impl, Authorizer: auth, Routes: make([]*api.Route, 2)}
+func (s *APIRouter) ListDSSReports(params api.PathParams, w http.ResponseWriter, r *http.Request) {
+	var req ListDSSReportsRequest
+
+	// Authorize request
+	req.Auth = s.Authorizer.Authorize(w, r, ListDSSReportsSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &ListDSSReportsRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
+
+	// Copy query parameters
+	query := r.URL.Query()
+	// TODO: Change to query.Has after Go 1.17
+	if query.Get("reporter") != "" {
+		v := query.Get("reporter")
+		req.Reporter = &v
+	}
+	if query.Get("earliest_time") != "" {
+		v := query.Get("earliest_time")
+		req.EarliestTime = &v
+	}
+	if query.Get("latest_time") != "" {
+		v := query.Get("latest_time")
+		req.LatestTime = &v
+	}
+
+	// Call implementation
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
+	defer cancel()
+	response := s.Implementation.ListDSSReports(ctx, &req)
+
+	// Write response to client
+	if response.Response200 != nil {
+		api.WriteJSON(w, 200, response.Response200)
+		return
+	}
+	if response.Response400 != nil {
+		api.WriteJSON(w, 400, response.Response400)
+		return
+	}
+	if response.Response401 != nil {
+		api.WriteJSON(w, 401, response.Response401)
+		return
+	}
+	if response.Response403 != nil {
+		api.WriteJSON(w, 403, response.Response403)
+		return
+	}
+	if response.Response404 != nil {
+		api.WriteJSON(w, 404, response.Response404)
+		return
+	}
+	if response.Response500 != nil {
+		api.WriteJSON(w, 500, response.Response500)
+		return
+	}
+	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
+}
+
+func (s *APIRouter) GetDSSReport(params api.PathParams, w http.ResponseWriter, r *http.Request) {
+	var req GetDSSReportRequest
+
+	// Authorize request
+	req.Auth = s.Authorizer.Authorize(w, r, GetDSSReportSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &GetDSSReportRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
+
+	// Parse path parameters
+	req.ReportId = params.Get("report_id")
+
+	// Call implementation
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
+	defer cancel()
+	response := s.Implementation.GetDSSReport(ctx, &req)
+
+	// Write response to client
+	if response.Response200 != nil {
+		api.WriteJSON(w, 200, response.Response200)
+		return
+	}
+	if response.Response400 != nil {
+		api.WriteJSON(w, 400, response.Response400)
+		return
+	}
+	if response.Response401 != nil {
+		api.WriteJSON(w, 401, response.Response401)
+		return
+	}
+	if response.Response403 != nil {
+		api.WriteJSON(w, 403, response.Response403)
+		return
+	}
+	if response.Response404 != nil {
+		api.WriteJSON(w, 404, response.Response404)
+		return
+	}
+	if response.Response500 != nil {
+		api.WriteJSON(w, 500, response.Response500)
+		return
+	}
+	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
+}
+
+func (s *APIRouter) ListTokenRevocations(params api.PathParams, w http.ResponseWriter, r *http.Request) {
+	var req ListTokenRevocationsRequest
+
+	// Authorize request
+	req.Auth = s.Authorizer.Authorize(w, r, ListTokenRevocationsSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Call implementation
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
+	defer cancel()
+	response := s.Implementation.ListTokenRevocations(ctx, &req)
+
+	// Write response to client
+	if response.Response200 != nil {
+		api.WriteJSON(w, 200, response.Response200)
+		return
+	}
+	if response.Response401 != nil {
+		api.WriteJSON(w, 401, response.Response401)
+		return
+	}
+	if response.Response403 != nil {
+		api.WriteJSON(w, 403, response.Response403)
+		return
+	}
+	if response.Response500 != nil {
+		api.WriteJSON(w, 500, response.Response500)
+		return
+	}
+	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
+}
+
+func (s *APIRouter) CreateTokenRevocation(params api.PathParams, w http.ResponseWriter, r *http.Request) {
+	var req CreateTokenRevocationRequest
 
-	pattern := regexp.MustCompile("^/aux/v1/version$")
-	router.Routes[0] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.GetVersion}
+	// Authorize request
+	req.Auth = s.Authorizer.Authorize(w, r, CreateTokenRevocationSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &CreateTokenRevocationRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
+
+	// Parse request body
+	req.Body = new(CreateTokenRevocationParameters)
+	defer r.Body.Close()
+	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
+
+	// Call implementation
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
+	defer cancel()
+	response := s.Implementation.CreateTokenRevocation(ctx, &req)
+
+	// Write response to client
+	if response.Response200 != nil {
+		api.WriteJSON(w, 200, response.Response200)
+		return
+	}
+	if response.Response400 != nil {
+		api.WriteJSON(w, 400, response.Response400)
+		return
+	}
+	if response.Response401 != nil {
+		api.WriteJSON(w, 401, response.Response401)
+		return
+	}
+	if response.Response403 != nil {
+		api.WriteJSON(w, 403, response.Response403)
+		return
+	}
+	if response.Response500 != nil {
+		api.WriteJSON(w, 500, response.Response500)
+		return
+	}
+	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
+}
+
+func (s *APIRouter) DeleteTokenRevocation(params api.PathParams, w http.ResponseWriter, r *http.Request) {
+	var req DeleteTokenRevocationRequest
+
+	// Authorize request
+	req.Auth = s.Authorizer.Authorize(w, r, DeleteTokenRevocationSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &DeleteTokenRevocationRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
+
+	// Parse path parameters
+	req.RevocationId = params.Get("revocation_id")
+
+	// Call implementation
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
+	defer cancel()
+	response := s.Implementation.DeleteTokenRevocation(ctx, &req)
+
+	// Write response to client
+	if response.Response200 != nil {
+		api.WriteJSON(w, 200, response.Response200)
+		return
+	}
+	if response.Response400 != nil {
+		api.WriteJSON(w, 400, response.Response400)
+		return
+	}
+	if response.Response401 != nil {
+		api.WriteJSON(w, 401, response.Response401)
+		return
+	}
+	if response.Response403 != nil {
+		api.WriteJSON(w, 403, response.Response403)
+		return
+	}
+	if response.Response404 != nil {
+		api.WriteJSON(w, 404, response.Response404)
+		return
+	}
+	if response.Response500 != nil {
+		api.WriteJSON(w, 500, response.Response500)
+		return
+	}
+	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
+}
+
+func MakeAPIRouter(impl Implementation, auth api.Authorizer, options ...api.RouterOption) APIRouter {
+	router := APIRouter{Implementation: impl, Authorizer: auth, Routes: make([]*api.Route, 7)}
+	for _, option := range options {
+		option(&router.Options)
+	}
 
-	pattern = regexp.MustCompile("^/aux/v1/validate_oauth$")
-	router.Routes[1] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.ValidateOauth}
+	router.Routes[0] = &api.Route{Method: http.MethodGet, Path: "/aux/v1/version", Handler: router.GetVersion}
+	router.Routes[1] = &api.Route{Method: http.MethodGet, Path: "/aux/v1/validate_oauth", Handler: router.ValidateOauth}
+	router.Routes[2] = &api.Route{Method: http.MethodGet, Path: "/aux/v1/reports", Handler: router.ListDSSReports}
+	router.Routes[3] = &api.Route{Method: http.MethodGet, Path: "/aux/v1/reports/{report_id}", Handler: router.GetDSSReport}
+	router.Routes[4] = &api.Route{Method: http.MethodGet, Path: "/aux/v1/token_revocations", Handler: router.ListTokenRevocations}
+	router.Routes[5] = &api.Route{Method: http.MethodPost, Path: "/aux/v1/token_revocations", Handler: router.CreateTokenRevocation}
+	router.Routes[6] = &api.Route{Method: http.MethodDelete, Path: "/aux/v1/token_revocations/{revocation_id}", Handler: router.DeleteTokenRevocation}
 
+	router.router = api.NewRouter(router.Routes...)
 	return router
 }
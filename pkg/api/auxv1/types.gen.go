@@ -10,3 +10,67 @@ type ErrorResponse struct {
 	// Human-readable message indicating what error occurred and/or why.
 	Message *string `json:"message,omitempty"`
 }
+
+type DSSReport struct {
+	// ID assigned by the DSS to the report.
+	ReportId string `json:"report_id"`
+
+	// Manager of the USS which submitted the report.
+	Reporter string `json:"reporter"`
+
+	// Time at which the DSS received the report.
+	ReceivedAt string `json:"received_at"`
+
+	Report SubmittedReport `json:"report"`
+}
+
+// The report as submitted by the USS to the DSS, including its report_id.
+type SubmittedReport map[string]interface{}
+
+type ListDSSReportsResponse struct {
+	// Reports matching the query, most recent first.
+	Reports []DSSReport `json:"reports"`
+}
+
+type TokenRevocation struct {
+	// ID assigned by the DSS to the revocation.
+	RevocationId string `json:"revocation_id"`
+
+	// Issuer of the revoked access tokens. The revocation applies to the tokens of every issuer if absent.
+	Issuer *string `json:"issuer,omitempty"`
+
+	// JWT ID of the revoked access token.
+	Jti *string `json:"jti,omitempty"`
+
+	// Subject (client ID) of the revoked access tokens. All the tokens issued to this subject until revoked_at are revoked.
+	Subject *string `json:"subject,omitempty"`
+
+	// Free-form explanation of the revocation.
+	Reason string `json:"reason"`
+
+	// Client ID of the administrator who created the revocation.
+	RevokedBy string `json:"revoked_by"`
+
+	// Time at which the revocation was created.
+	RevokedAt string `json:"revoked_at"`
+}
+
+// Exactly one of jti and subject must be specified.
+type CreateTokenRevocationParameters struct {
+	// Only revoke the access tokens of this issuer.
+	Issuer *string `json:"issuer,omitempty"`
+
+	// JWT ID of the access token to revoke.
+	Jti *string `json:"jti,omitempty"`
+
+	// Subject (client ID) whose access tokens issued until now are revoked.
+	Subject *string `json:"subject,omitempty"`
+
+	// Free-form explanation of the revocation.
+	Reason *string `json:"reason,omitempty"`
+}
+
+type ListTokenRevocationsResponse struct {
+	// All the revocations, most recent first.
+	Revocations []TokenRevocation `json:"revocations"`
+}
@@ -0,0 +1,59 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package auxv1
+
+import (
+	"github.com/interuss/dss/pkg/api"
+)
+
+// Schemas are the OpenAPI schemas of the data types of the requests of this
+// API, by name.
+var Schemas = api.Schemas{
+	"CreateTokenRevocationParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"issuer": {
+				Type: "string",
+			},
+			"jti": {
+				Type: "string",
+			},
+			"subject": {
+				Type: "string",
+			},
+			"reason": {
+				Type: "string",
+			},
+		},
+	},
+}
+
+var ValidateOauthRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "owner", In: "query", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
+
+var ListDSSReportsRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "reporter", In: "query", Required: false, Schema: &api.Schema{Type: "string"}},
+		{Name: "earliest_time", In: "query", Required: false, Schema: &api.Schema{Type: "string", Format: "date-time"}},
+		{Name: "latest_time", In: "query", Required: false, Schema: &api.Schema{Type: "string", Format: "date-time"}},
+	},
+}
+
+var GetDSSReportRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "report_id", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
+
+var CreateTokenRevocationRequestSchema = api.RequestSchema{
+	Body:         &api.Schema{Ref: "CreateTokenRevocationParameters"},
+	BodyRequired: true,
+}
+
+var DeleteTokenRevocationRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "revocation_id", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
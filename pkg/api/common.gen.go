@@ -2,12 +2,21 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // --- Interface definitions ---
@@ -36,6 +45,11 @@ type AuthorizationResult struct {
 
 	// If authorization was not successful, the problem with the authorization
 	Error error
+
+	// If set, the Authorizer already wrote the response to the request (e.g.
+	// because the client exceeded its rate limit) and the operation must not
+	// be performed
+	Handled bool
 }
 
 type Authorizer interface {
@@ -56,11 +70,34 @@ func WriteJSON(w http.ResponseWriter, code int, obj interface{}) {
 
 // --- API router definitions ---
 
-type Handler func(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request)
+// PathParam is the value of a path parameter of a request.
+type PathParam struct {
+	// Name is the name of the parameter in the OpenAPI path template
+	Name  string
+	Value string
+}
+
+// PathParams are the values of the path parameters of a request, in the order
+// of the OpenAPI path template of its Route.
+type PathParams []PathParam
+
+// Get returns the value of the path parameter name, or the empty string if
+// there is no such parameter.
+func (p PathParams) Get(name string) string {
+	for _, param := range p {
+		if param.Name == name {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+type Handler func(params PathParams, w http.ResponseWriter, r *http.Request)
 
 type Route struct {
-	Method  string
-	Pattern *regexp.Regexp
+	Method string
+	// Path is the OpenAPI path template of the operation handled by this Route
+	Path    string
 	Handler Handler
 }
 
@@ -68,6 +105,266 @@ type PartialRouter interface {
 	Handle(w http.ResponseWriter, r *http.Request) bool
 }
 
+// RouterOptions configure the APIRouter of an API.
+type RouterOptions struct {
+	// ValidateRequests enables the validation of the path, query and body of
+	// authorized requests against the OpenAPI schema of their operation;
+	// requests violating it are responded to with 400 and a
+	// ValidationErrorBody without reaching the implementation
+	ValidateRequests bool
+}
+
+// RouterOption sets an option of the APIRouter of an API.
+type RouterOption func(*RouterOptions)
+
+// WithRequestValidation sets RouterOptions.ValidateRequests to enabled.
+func WithRequestValidation(enabled bool) RouterOption {
+	return func(options *RouterOptions) {
+		options.ValidateRequests = enabled
+	}
+}
+
+// --- Path trie router definitions ---
+
+// Router routes requests to the Route matching their method and path. Routes
+// are stored in a trie of path segments, so that finding the Route of a
+// request does not depend on the number of Routes. A segment of a path
+// template is either static or, when enclosed in braces, a parameter matching
+// any segment; static segments take precedence over parameters.
+type Router struct {
+	root routeNode
+}
+
+type routeNode struct {
+	static map[string]*routeNode
+	param  *routeNode
+	// routes of the path ending at this node, by method
+	routes map[string]*routeTarget
+}
+
+type routeTarget struct {
+	route      *Route
+	paramNames []string
+}
+
+// NewRouter returns a Router routing requests to routes.
+func NewRouter(routes ...*Route) *Router {
+	router := &Router{}
+	router.Add(routes...)
+	return router
+}
+
+// Add adds routes to the Router. It panics if a path template is invalid or if
+// a Route with the same method and path template was already added.
+func (t *Router) Add(routes ...*Route) {
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, "/") {
+			panic(fmt.Sprintf("path template %s of %s route must start with /", route.Path, route.Method))
+		}
+		node := &t.root
+		var paramNames []string
+		for _, segment := range strings.Split(route.Path[1:], "/") {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				paramNames = append(paramNames, segment[1:len(segment)-1])
+				if node.param == nil {
+					node.param = &routeNode{}
+				}
+				node = node.param
+				continue
+			}
+			if strings.ContainsAny(segment, "{}") {
+				panic(fmt.Sprintf("path template %s of %s route has a partial parameter segment", route.Path, route.Method))
+			}
+			if node.static == nil {
+				node.static = map[string]*routeNode{}
+			}
+			child, ok := node.static[segment]
+			if !ok {
+				child = &routeNode{}
+				node.static[segment] = child
+			}
+			node = child
+		}
+		if node.routes == nil {
+			node.routes = map[string]*routeTarget{}
+		}
+		if _, exists := node.routes[route.Method]; exists {
+			panic(fmt.Sprintf("duplicate %s route for path template %s", route.Method, route.Path))
+		}
+		node.routes[route.Method] = &routeTarget{route: route, paramNames: paramNames}
+	}
+}
+
+// Lookup returns the Route matching method and path along with the values of
+// its path parameters. If path matches Routes of other methods only, Lookup
+// returns a nil Route and these methods, sorted. If path matches no Route,
+// Lookup returns a nil Route and no methods.
+func (t *Router) Lookup(method string, path string) (*Route, PathParams, []string) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, nil, nil
+	}
+	node, matched := t.root.lookup(path[1:], nil)
+	if node == nil {
+		return nil, nil, nil
+	}
+	target, ok := node.routes[method]
+	if !ok {
+		allowed := make([]string, 0, len(node.routes))
+		for m := range node.routes {
+			allowed = append(allowed, m)
+		}
+		sort.Strings(allowed)
+		return nil, nil, allowed
+	}
+	var params PathParams
+	if len(matched) > 0 {
+		params = make(PathParams, len(matched))
+		for i, value := range matched {
+			params[i] = PathParam{Name: target.paramNames[i], Value: value}
+		}
+	}
+	return target.route, params, nil
+}
+
+// lookup returns the node of the trie rooted at n matching path, stripped of
+// its leading slash, appending the values of the parameter segments to values.
+func (n *routeNode) lookup(path string, values []string) (*routeNode, []string) {
+	segment, rest, last := path, "", true
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		segment, rest, last = path[:i], path[i+1:], false
+	}
+	if child, ok := n.static[segment]; ok {
+		if node, matched := child.descend(rest, last, values); node != nil {
+			return node, matched
+		}
+	}
+	if n.param != nil {
+		return n.param.descend(rest, last, append(values, segment))
+	}
+	return nil, nil
+}
+
+// descend continues the lookup of a path at n, once the segment leading to n
+// has been matched.
+func (n *routeNode) descend(rest string, last bool, values []string) (*routeNode, []string) {
+	if last {
+		if len(n.routes) == 0 {
+			return nil, nil
+		}
+		return n, values
+	}
+	return n.lookup(rest, values)
+}
+
+// Handle implements PartialRouter. It responds with 405 Method Not Allowed
+// when the path of r only matches Routes of other methods.
+func (t *Router) Handle(w http.ResponseWriter, r *http.Request) bool {
+	route, params, allowed := t.Lookup(r.Method, r.URL.Path)
+	if route != nil {
+		RecordRoute(r, route)
+		route.Handler(params, w, r)
+		return true
+	}
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return true
+	}
+	return false
+}
+
+// ServeHTTP implements http.Handler, responding with 404 Not Found when no
+// Route matches the path of r.
+func (t *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !t.Handle(w, r) {
+		http.NotFound(w, r)
+	}
+}
+
+// --- Route recording definitions ---
+
+type matchedRouteKey struct{}
+
+// WithRouteRecorder returns a shallow copy of r in which the Route eventually
+// handling the request is recorded; see MatchedRoute. r is returned as is if
+// it already records its Route.
+func WithRouteRecorder(r *http.Request) *http.Request {
+	if _, ok := r.Context().Value(matchedRouteKey{}).(**Route); ok {
+		return r
+	}
+	return r.WithContext(context.WithValue(r.Context(), matchedRouteKey{}, new(*Route)))
+}
+
+// RecordRoute records route as the Route handling r, if r was obtained from
+// WithRouteRecorder.
+func RecordRoute(r *http.Request, route *Route) {
+	if matched, ok := r.Context().Value(matchedRouteKey{}).(**Route); ok {
+		*matched = route
+	}
+}
+
+// MatchedRoute returns the Route that handled r, or nil if r was not obtained
+// from WithRouteRecorder or no Route matched it.
+func MatchedRoute(r *http.Request) *Route {
+	if matched, ok := r.Context().Value(matchedRouteKey{}).(**Route); ok {
+		return *matched
+	}
+	return nil
+}
+
+// --- Authorization recording definitions ---
+
+type authorizationKey struct{}
+
+// WithAuthorizationRecorder returns a shallow copy of r in which the
+// AuthorizationResult of the operation eventually handling the request is
+// recorded; see RecordedAuthorization. r is returned as is if it already
+// records its AuthorizationResult.
+func WithAuthorizationRecorder(r *http.Request) *http.Request {
+	if _, ok := r.Context().Value(authorizationKey{}).(**AuthorizationResult); ok {
+		return r
+	}
+	return r.WithContext(context.WithValue(r.Context(), authorizationKey{}, new(*AuthorizationResult)))
+}
+
+// RecordAuthorization records result as the AuthorizationResult of the
+// operation handling r, if r was obtained from WithAuthorizationRecorder.
+func RecordAuthorization(r *http.Request, result AuthorizationResult) {
+	if recorded, ok := r.Context().Value(authorizationKey{}).(**AuthorizationResult); ok {
+		*recorded = &result
+	}
+}
+
+// RecordedAuthorization returns the AuthorizationResult of the operation that
+// handled r, or nil if r was not obtained from WithAuthorizationRecorder or no
+// operation handled it.
+func RecordedAuthorization(r *http.Request) *AuthorizationResult {
+	if recorded, ok := r.Context().Value(authorizationKey{}).(**AuthorizationResult); ok {
+		return *recorded
+	}
+	return nil
+}
+
+// --- Response header definitions ---
+
+type responseHeaderKey struct{}
+
+// WithResponseHeader returns a copy of ctx through which the implementation
+// handling a request may set headers of the response written to w; see
+// SetResponseHeader.
+func WithResponseHeader(ctx context.Context, w http.ResponseWriter) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, w.Header())
+}
+
+// SetResponseHeader sets the header key of the response to value, if ctx was
+// obtained from WithResponseHeader. It has no effect once the response is
+// written.
+func SetResponseHeader(ctx context.Context, key string, value string) {
+	if header, ok := ctx.Value(responseHeaderKey{}).(http.Header); ok {
+		header.Set(key, value)
+	}
+}
+
 // --- Multi-router definitions ---
 
 type MultiRouter struct {
@@ -82,3 +379,412 @@ func (m *MultiRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	http.NotFound(w, r)
 }
+
+// --- Client definitions ---
+
+// maxErrorBodySize is the maximum size of the body of a ResponseError.
+const maxErrorBodySize = 4096
+
+// TokenSource provides the access tokens presented by clients to servers.
+type TokenSource interface {
+	// Token returns an access token intended for audience (the host of the
+	// server) and granting all the scopes of one of authOptions.
+	Token(ctx context.Context, audience string, authOptions []AuthorizationOption) (string, error)
+}
+
+// StaticTokenSource is a TokenSource always providing the same access token.
+type StaticTokenSource string
+
+// Token implements TokenSource.
+func (s StaticTokenSource) Token(ctx context.Context, audience string, authOptions []AuthorizationOption) (string, error) {
+	return string(s), nil
+}
+
+// ResponseError is returned by clients when the server responds to an
+// operation with a status code the API does not declare for it.
+type ResponseError struct {
+	StatusCode int
+	// Header of the response, e.g. to read its Retry-After
+	Header http.Header
+	// Body of the response, truncated to maxErrorBodySize
+	Body string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("unexpected response %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// NewResponseError returns the ResponseError of resp, reading its body.
+func NewResponseError(resp *http.Response) *ResponseError {
+	var body bytes.Buffer
+	_, _ = body.ReadFrom(io.LimitReader(resp.Body, maxErrorBodySize))
+	return &ResponseError{StatusCode: resp.StatusCode, Header: resp.Header, Body: body.String()}
+}
+
+// SendRequest sends the request of an operation to rawURL with query and,
+// unless it is nil, body encoded in JSON. The request is authorized with an
+// access token of tokenSource unless it is nil, and sent with httpClient, or
+// http.DefaultClient if nil.
+func SendRequest(ctx context.Context, httpClient *http.Client, tokenSource TokenSource, authOptions []AuthorizationOption, method string, rawURL string, query url.Values, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	if len(query) > 0 {
+		rawURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if tokenSource != nil {
+		token, err := tokenSource.Token(ctx, req.URL.Hostname(), authOptions)
+		if err != nil {
+			return nil, fmt.Errorf("error obtaining access token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(req)
+}
+
+// ReadJSON decodes the JSON body of resp into obj. An empty body leaves obj
+// unchanged.
+func ReadJSON(resp *http.Response, obj interface{}) error {
+	if err := json.NewDecoder(resp.Body).Decode(obj); err != nil && err != io.EOF {
+		return fmt.Errorf("error decoding body of %d response: %w", resp.StatusCode, err)
+	}
+	return nil
+}
+
+// --- Request validation definitions ---
+
+// Schema is the OpenAPI schema of a value. Unset fields do not constrain the
+// value.
+type Schema struct {
+	// Ref is the name of the schema of the same Schemas defining this schema;
+	// the other fields are ignored when it is set
+	Ref string
+
+	// Type is one of object, array, string, number, integer or boolean
+	Type     string
+	Format   string
+	Enum     []string
+	Nullable bool
+
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+
+	MinLength *int
+	MaxLength *int
+	Pattern   *regexp.Regexp
+
+	MinItems *int
+	MaxItems *int
+	Items    *Schema
+
+	Properties map[string]*Schema
+	Required   []string
+}
+
+// Float64 returns a pointer to v, for the numeric bounds of a Schema.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// Int returns a pointer to v, for the length bounds of a Schema.
+func Int(v int) *int {
+	return &v
+}
+
+// Schemas are the schemas of the data types of an API, by name.
+type Schemas map[string]*Schema
+
+// ParameterSchema is the schema of a path or query parameter of an operation.
+type ParameterSchema struct {
+	Name string
+	// In is either path or query
+	In       string
+	Required bool
+	Schema   *Schema
+}
+
+// RequestSchema is the schema of the requests of an operation.
+type RequestSchema struct {
+	Parameters []ParameterSchema
+	// Body is the schema of the JSON body of the requests, or nil if the
+	// operation has no request body
+	Body         *Schema
+	BodyRequired bool
+}
+
+// Violation is the violation of the schema of an operation by a request.
+type Violation struct {
+	// Location of the violating value, e.g. body.extents[0].time_start
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return v.Location + ": " + v.Message
+}
+
+// ValidationErrorBody is the body of the 400 responses to requests violating
+// the schema of their operation.
+type ValidationErrorBody struct {
+	Message    string      `json:"message"`
+	Violations []Violation `json:"violations"`
+}
+
+// WriteViolations responds to a request with its violations of the schema of
+// its operation.
+func WriteViolations(w http.ResponseWriter, violations []Violation) {
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.String()
+	}
+	WriteJSON(w, http.StatusBadRequest, ValidationErrorBody{
+		Message:    "Request violates the API schema: " + strings.Join(messages, "; "),
+		Violations: violations,
+	})
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ValidateRequest returns the violations of request by r, whose path
+// parameters are params. The body of r is read and replaced by a copy, so that
+// it can still be decoded afterwards.
+func (s Schemas) ValidateRequest(r *http.Request, params PathParams, request *RequestSchema) []Violation {
+	var violations []Violation
+	query := r.URL.Query()
+	for _, parameter := range request.Parameters {
+		var values []string
+		if parameter.In == "path" {
+			if value := params.Get(parameter.Name); value != "" {
+				values = []string{value}
+			}
+		} else {
+			values = query[parameter.Name]
+		}
+		violations = s.validateParameter(parameter, values, violations)
+	}
+	if request.Body != nil {
+		violations = s.validateBody(r, request.Body, request.BodyRequired, violations)
+	}
+	return violations
+}
+
+func (s Schemas) validateParameter(parameter ParameterSchema, values []string, violations []Violation) []Violation {
+	location := parameter.In + "." + parameter.Name
+	if len(values) == 0 {
+		if parameter.Required {
+			violations = append(violations, Violation{Location: location, Message: "is required"})
+		}
+		return violations
+	}
+	schema := s.resolve(parameter.Schema)
+	if schema.Type == "array" {
+		items := make([]interface{}, len(values))
+		for i, value := range values {
+			items[i] = s.parameterValue(schema.Items, value)
+		}
+		return s.validate(schema, location, items, violations)
+	}
+	return s.validate(schema, location, s.parameterValue(schema, values[0]), violations)
+}
+
+// parameterValue converts value to the JSON representation of the type of
+// schema, leaving it as is if it does not represent such a value.
+func (s Schemas) parameterValue(schema *Schema, value string) interface{} {
+	switch s.resolve(schema).Type {
+	case "number", "integer":
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return json.Number(value)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func (s Schemas) validateBody(r *http.Request, schema *Schema, required bool, violations []Violation) []Violation {
+	data, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return append(violations, Violation{Location: "body", Message: "could not be read: " + err.Error()})
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		if required {
+			violations = append(violations, Violation{Location: "body", Message: "is required"})
+		}
+		return violations
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return append(violations, Violation{Location: "body", Message: "is not valid JSON: " + err.Error()})
+	}
+	return s.validate(schema, "body", value, violations)
+}
+
+// resolve follows the references of schema to the Schema defining it.
+func (s Schemas) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = s[schema.Ref]
+	}
+	if schema == nil {
+		return &Schema{}
+	}
+	return schema
+}
+
+// validate appends the violations of schema by value, a JSON value decoded
+// with json.Decoder.UseNumber located at location, to violations.
+func (s Schemas) validate(schema *Schema, location string, value interface{}, violations []Violation) []Violation {
+	schema = s.resolve(schema)
+	violation := func(format string, args ...interface{}) []Violation {
+		return append(violations, Violation{Location: location, Message: fmt.Sprintf(format, args...)})
+	}
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return violations
+		}
+		return violation("must not be null")
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return violation("must be an object")
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				violations = append(violations, Violation{Location: location + "." + name, Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := schema.Properties[name]; ok {
+				violations = s.validate(property, location+"."+name, object[name], violations)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return violation("must be an array")
+		}
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			violations = violation("must have at least %d items", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			violations = violation("must have at most %d items", *schema.MaxItems)
+		}
+		for i, item := range items {
+			violations = s.validate(schema.Items, fmt.Sprintf("%s[%d]", location, i), item, violations)
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return violation("must be a string")
+		}
+		if len(schema.Enum) > 0 && !contains(schema.Enum, str) {
+			return violation("must be one of %s", strings.Join(schema.Enum, ", "))
+		}
+		// Only the first violation of the string is reported, as the others
+		// usually follow from it
+		length := utf8.RuneCountInString(str)
+		switch {
+		case schema.MinLength != nil && length < *schema.MinLength:
+			violations = violation("must be at least %d characters long", *schema.MinLength)
+		case schema.MaxLength != nil && length > *schema.MaxLength:
+			violations = violation("must be at most %d characters long", *schema.MaxLength)
+		case schema.Pattern != nil && !schema.Pattern.MatchString(str):
+			violations = violation("must match %s", schema.Pattern)
+		case !validFormat(schema.Format, str):
+			violations = violation("must be a valid %s", schema.Format)
+		}
+	case "number", "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return violation("must be a number")
+		}
+		f, err := number.Float64()
+		if err != nil {
+			return violation("must be a number")
+		}
+		if schema.Type == "integer" && f != math.Trunc(f) {
+			return violation("must be an integer")
+		}
+		if len(schema.Enum) > 0 && !contains(schema.Enum, number.String()) {
+			return violation("must be one of %s", strings.Join(schema.Enum, ", "))
+		}
+		if schema.Minimum != nil && (f < *schema.Minimum || schema.ExclusiveMinimum && f == *schema.Minimum) {
+			if schema.ExclusiveMinimum {
+				violations = violation("must be greater than %v", *schema.Minimum)
+			} else {
+				violations = violation("must be at least %v", *schema.Minimum)
+			}
+		}
+		if schema.Maximum != nil && (f > *schema.Maximum || schema.ExclusiveMaximum && f == *schema.Maximum) {
+			if schema.ExclusiveMaximum {
+				violations = violation("must be less than %v", *schema.Maximum)
+			} else {
+				violations = violation("must be at most %v", *schema.Maximum)
+			}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return violation("must be a boolean")
+		}
+	}
+	return violations
+}
+
+// validFormat returns false if str does not have format; formats other than
+// date-time, date, uuid, uri and url are not checked.
+func validFormat(format string, str string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, str)
+		return err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", str)
+		return err == nil
+	case "uuid":
+		return uuidPattern.MatchString(str)
+	case "uri", "url":
+		u, err := url.Parse(str)
+		return err == nil && u.Scheme != "" && u.Host != ""
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
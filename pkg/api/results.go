@@ -0,0 +1,20 @@
+package api
+
+import "context"
+
+// TruncatedResultsHeader is the response header set to "true" by searches
+// whose results were truncated to their limit, in which case results are
+// missing.
+const TruncatedResultsHeader = "X-DSS-Results-Truncated"
+
+// TruncateResults returns the first limit of results and, if results holds
+// more, marks the response to the request of ctx as truncated (see
+// SetResponseHeader). Searches should request limit+1 results from their store
+// so that exactly limit results are not mistaken for truncated ones.
+func TruncateResults[T any](ctx context.Context, results []T, limit int) []T {
+	if len(results) <= limit {
+		return results
+	}
+	SetResponseHeader(ctx, TruncatedResultsHeader, "true")
+	return results[:limit]
+}
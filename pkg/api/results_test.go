@@ -0,0 +1,29 @@
+package api_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateResults(t *testing.T) {
+	for _, tc := range []struct {
+		results   []int
+		want      []int
+		truncated string
+	}{
+		{[]int{1}, []int{1}, ""},
+		{[]int{1, 2}, []int{1, 2}, ""},
+		{[]int{1, 2, 3}, []int{1, 2}, "true"},
+	} {
+		w := httptest.NewRecorder()
+		require.Equal(t, tc.want, api.TruncateResults(api.WithResponseHeader(context.Background(), w), tc.results, 2))
+		require.Equal(t, tc.truncated, w.Header().Get(api.TruncatedResultsHeader))
+	}
+
+	// Without a response header in the context, results are still truncated.
+	require.Equal(t, []int{1, 2}, api.TruncateResults(context.Background(), []int{1, 2, 3}, 2))
+}
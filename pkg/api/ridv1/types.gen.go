@@ -28,6 +28,9 @@ type Volume4D struct {
 // Response to DSS request for the subscription with the given id.
 type GetSubscriptionResponse struct {
 	Subscription Subscription `json:"subscription"`
+
+	// DSS extension: spacetime extents submitted for the subscription.  Absent when the DSS only knows the cells it covers.
+	Extents *Volume4D `json:"extents,omitempty"`
 }
 
 // Response to DSS query for subscriptions in a particular area.
@@ -158,6 +161,9 @@ type PutSubscriptionResponse struct {
 // Response to DSS request for the identification service area with the given id.
 type GetIdentificationServiceAreaResponse struct {
 	ServiceArea IdentificationServiceArea `json:"service_area"`
+
+	// DSS extension: spacetime extents submitted for the Identification Service Area.  Absent when the DSS only knows the cells it covers.
+	Extents *Volume4D `json:"extents,omitempty"`
 }
 
 // Parameters for a request to create an Identification Service Area in the DSS.
@@ -0,0 +1,459 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package ridv2
+
+import (
+	"context"
+	"github.com/interuss/dss/pkg/api"
+	"net/http"
+	"net/url"
+)
+
+// Client sends the requests of the operations of this API to a server.
+type Client struct {
+	// BaseURL is the URL the paths of the operations are relative to, without
+	// trailing slash
+	BaseURL string
+	// HTTPClient sends the requests; http.DefaultClient is used if nil
+	HTTPClient *http.Client
+	// TokenSource provides the access tokens authorizing the requests, which
+	// are sent without access token if nil
+	TokenSource api.TokenSource
+}
+
+// SearchIdentificationServiceAreas sends the request of the SearchIdentificationServiceAreas operation.
+func (c *Client) SearchIdentificationServiceAreas(ctx context.Context, req *SearchIdentificationServiceAreasRequest) (*SearchIdentificationServiceAreasResponseSet, error) {
+	path := "/rid/v2/dss/identification_service_areas"
+	query := url.Values{}
+	if req.Area != nil {
+		query.Set("area", string(*req.Area))
+	}
+	if req.EarliestTime != nil {
+		query.Set("earliest_time", *req.EarliestTime)
+	}
+	if req.LatestTime != nil {
+		query.Set("latest_time", *req.LatestTime)
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, SearchIdentificationServiceAreasSecurity, http.MethodGet, c.BaseURL+path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &SearchIdentificationServiceAreasResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(SearchIdentificationServiceAreasResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetIdentificationServiceArea sends the request of the GetIdentificationServiceArea operation.
+func (c *Client) GetIdentificationServiceArea(ctx context.Context, req *GetIdentificationServiceAreaRequest) (*GetIdentificationServiceAreaResponseSet, error) {
+	path := "/rid/v2/dss/identification_service_areas/" + url.PathEscape(string(req.Id))
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetIdentificationServiceAreaSecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetIdentificationServiceAreaResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(GetIdentificationServiceAreaResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CreateIdentificationServiceArea sends the request of the CreateIdentificationServiceArea operation.
+func (c *Client) CreateIdentificationServiceArea(ctx context.Context, req *CreateIdentificationServiceAreaRequest) (*CreateIdentificationServiceAreaResponseSet, error) {
+	path := "/rid/v2/dss/identification_service_areas/" + url.PathEscape(string(req.Id))
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, CreateIdentificationServiceAreaSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &CreateIdentificationServiceAreaResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(PutIdentificationServiceAreaResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateIdentificationServiceArea sends the request of the UpdateIdentificationServiceArea operation.
+func (c *Client) UpdateIdentificationServiceArea(ctx context.Context, req *UpdateIdentificationServiceAreaRequest) (*UpdateIdentificationServiceAreaResponseSet, error) {
+	path := "/rid/v2/dss/identification_service_areas/" + url.PathEscape(string(req.Id)) + "/" + url.PathEscape(req.Version)
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, UpdateIdentificationServiceAreaSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &UpdateIdentificationServiceAreaResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(PutIdentificationServiceAreaResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteIdentificationServiceArea sends the request of the DeleteIdentificationServiceArea operation.
+func (c *Client) DeleteIdentificationServiceArea(ctx context.Context, req *DeleteIdentificationServiceAreaRequest) (*DeleteIdentificationServiceAreaResponseSet, error) {
+	path := "/rid/v2/dss/identification_service_areas/" + url.PathEscape(string(req.Id)) + "/" + url.PathEscape(req.Version)
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, DeleteIdentificationServiceAreaSecurity, http.MethodDelete, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &DeleteIdentificationServiceAreaResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(DeleteIdentificationServiceAreaResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// SearchSubscriptions sends the request of the SearchSubscriptions operation.
+func (c *Client) SearchSubscriptions(ctx context.Context, req *SearchSubscriptionsRequest) (*SearchSubscriptionsResponseSet, error) {
+	path := "/rid/v2/dss/subscriptions"
+	query := url.Values{}
+	if req.Area != nil {
+		query.Set("area", string(*req.Area))
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, SearchSubscriptionsSecurity, http.MethodGet, c.BaseURL+path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &SearchSubscriptionsResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(SearchSubscriptionsResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 413:
+		response.Response413 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response413)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetSubscription sends the request of the GetSubscription operation.
+func (c *Client) GetSubscription(ctx context.Context, req *GetSubscriptionRequest) (*GetSubscriptionResponseSet, error) {
+	path := "/rid/v2/dss/subscriptions/" + url.PathEscape(string(req.Id))
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetSubscriptionSecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetSubscriptionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(GetSubscriptionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CreateSubscription sends the request of the CreateSubscription operation.
+func (c *Client) CreateSubscription(ctx context.Context, req *CreateSubscriptionRequest) (*CreateSubscriptionResponseSet, error) {
+	path := "/rid/v2/dss/subscriptions/" + url.PathEscape(string(req.Id))
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, CreateSubscriptionSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &CreateSubscriptionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(PutSubscriptionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateSubscription sends the request of the UpdateSubscription operation.
+func (c *Client) UpdateSubscription(ctx context.Context, req *UpdateSubscriptionRequest) (*UpdateSubscriptionResponseSet, error) {
+	path := "/rid/v2/dss/subscriptions/" + url.PathEscape(string(req.Id)) + "/" + url.PathEscape(req.Version)
+	var body interface{}
+	if req.Body != nil {
+		body = req.Body
+	}
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, UpdateSubscriptionSecurity, http.MethodPut, c.BaseURL+path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &UpdateSubscriptionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(PutSubscriptionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 429:
+		response.Response429 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response429)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteSubscription sends the request of the DeleteSubscription operation.
+func (c *Client) DeleteSubscription(ctx context.Context, req *DeleteSubscriptionRequest) (*DeleteSubscriptionResponseSet, error) {
+	path := "/rid/v2/dss/subscriptions/" + url.PathEscape(string(req.Id)) + "/" + url.PathEscape(req.Version)
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, DeleteSubscriptionSecurity, http.MethodDelete, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &DeleteSubscriptionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(DeleteSubscriptionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 400:
+		response.Response400 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response400)
+	case 401:
+		response.Response401 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response404)
+	case 409:
+		response.Response409 = new(ErrorResponse)
+		err = api.ReadJSON(resp, response.Response409)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
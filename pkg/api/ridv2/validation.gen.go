@@ -0,0 +1,289 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package ridv2
+
+import (
+	"github.com/interuss/dss/pkg/api"
+	"regexp"
+)
+
+// Schemas are the OpenAPI schemas of the data types of the requests of this
+// API, by name.
+var Schemas = api.Schemas{
+	"Time": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"value": {
+				Type:   "string",
+				Format: "date-time",
+			},
+			"format": {
+				Type: "string",
+				Enum: []string{"RFC3339"},
+			},
+		},
+		Required: []string{"value", "format"},
+	},
+	"Radius": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"value": {
+				Type:             "number",
+				Format:           "float",
+				Minimum:          api.Float64(0),
+				ExclusiveMinimum: true,
+			},
+			"units": {
+				Type: "string",
+				Enum: []string{"M"},
+			},
+		},
+		Required: []string{"value", "units"},
+	},
+	"Circle": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"center": {
+				Ref: "LatLngPoint",
+			},
+			"radius": {
+				Ref: "Radius",
+			},
+		},
+	},
+	"Volume3D": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"outline_circle": {
+				Ref: "Circle",
+			},
+			"outline_polygon": {
+				Ref: "Polygon",
+			},
+			"altitude_lower": {
+				Ref: "Altitude",
+			},
+			"altitude_upper": {
+				Ref: "Altitude",
+			},
+		},
+	},
+	"Volume4D": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"volume": {
+				Ref: "Volume3D",
+			},
+			"time_start": {
+				Ref: "Time",
+			},
+			"time_end": {
+				Ref: "Time",
+			},
+		},
+		Required: []string{"volume"},
+	},
+	"UUIDv4": {
+		Type:      "string",
+		Format:    "uuid",
+		MinLength: api.Int(36),
+		MaxLength: api.Int(36),
+		Pattern:   regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`),
+	},
+	"EntityUUID": {
+		Ref: "UUIDv4",
+	},
+	"SubscriptionUUID": {
+		Ref: "UUIDv4",
+	},
+	"GeoPolygonString": {
+		Type: "string",
+	},
+	"Latitude": {
+		Type:    "number",
+		Format:  "double",
+		Minimum: api.Float64(-90),
+		Maximum: api.Float64(90),
+	},
+	"Longitude": {
+		Type:    "number",
+		Format:  "double",
+		Minimum: api.Float64(-180),
+		Maximum: api.Float64(180),
+	},
+	"LatLngPoint": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"lng": {
+				Ref: "Longitude",
+			},
+			"lat": {
+				Ref: "Latitude",
+			},
+		},
+		Required: []string{"lng", "lat"},
+	},
+	"Altitude": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"value": {
+				Type:    "number",
+				Format:  "double",
+				Minimum: api.Float64(-8000),
+				Maximum: api.Float64(100000),
+			},
+			"reference": {
+				Type: "string",
+				Enum: []string{"W84"},
+			},
+			"units": {
+				Type: "string",
+				Enum: []string{"M"},
+			},
+		},
+		Required: []string{"value", "reference", "units"},
+	},
+	"Polygon": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"vertices": {
+				Type:     "array",
+				MinItems: api.Int(3),
+				Items: &api.Schema{
+					Ref: "LatLngPoint",
+				},
+			},
+		},
+		Required: []string{"vertices"},
+	},
+	"CreateIdentificationServiceAreaParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Ref: "Volume4D",
+			},
+			"uss_base_url": {
+				Ref: "FlightsUSSBaseURL",
+			},
+		},
+		Required: []string{"extents", "uss_base_url"},
+	},
+	"UpdateIdentificationServiceAreaParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Ref: "Volume4D",
+			},
+			"uss_base_url": {
+				Ref: "FlightsUSSBaseURL",
+			},
+		},
+		Required: []string{"extents", "uss_base_url"},
+	},
+	"CreateSubscriptionParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Ref: "Volume4D",
+			},
+			"uss_base_url": {
+				Ref: "SubscriptionUSSBaseURL",
+			},
+		},
+		Required: []string{"extents", "uss_base_url"},
+	},
+	"UpdateSubscriptionParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Ref: "Volume4D",
+			},
+			"uss_base_url": {
+				Ref: "SubscriptionUSSBaseURL",
+			},
+		},
+		Required: []string{"extents", "uss_base_url"},
+	},
+	"USSBaseURL": {
+		Type: "string",
+	},
+	"SubscriptionUSSBaseURL": {
+		Ref: "USSBaseURL",
+	},
+	"FlightsUSSBaseURL": {
+		Ref: "USSBaseURL",
+	},
+}
+
+var SearchIdentificationServiceAreasRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "area", In: "query", Required: true, Schema: &api.Schema{Ref: "GeoPolygonString"}},
+		{Name: "earliest_time", In: "query", Required: false, Schema: &api.Schema{Type: "string", Format: "date-time"}},
+		{Name: "latest_time", In: "query", Required: false, Schema: &api.Schema{Type: "string", Format: "date-time"}},
+	},
+}
+
+var GetIdentificationServiceAreaRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityUUID"}},
+	},
+}
+
+var CreateIdentificationServiceAreaRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityUUID"}},
+	},
+	Body:         &api.Schema{Ref: "CreateIdentificationServiceAreaParameters"},
+	BodyRequired: true,
+}
+
+var UpdateIdentificationServiceAreaRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityUUID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+	Body:         &api.Schema{Ref: "UpdateIdentificationServiceAreaParameters"},
+	BodyRequired: true,
+}
+
+var DeleteIdentificationServiceAreaRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityUUID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
+
+var SearchSubscriptionsRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "area", In: "query", Required: true, Schema: &api.Schema{Ref: "GeoPolygonString"}},
+	},
+}
+
+var GetSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionUUID"}},
+	},
+}
+
+var CreateSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionUUID"}},
+	},
+	Body:         &api.Schema{Ref: "CreateSubscriptionParameters"},
+	BodyRequired: true,
+}
+
+var UpdateSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionUUID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+	Body:         &api.Schema{Ref: "UpdateSubscriptionParameters"},
+	BodyRequired: true,
+}
+
+var DeleteSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "id", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionUUID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
@@ -0,0 +1,158 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/dss/pkg/api/auxv1"
+	"github.com/interuss/dss/pkg/api/ridv1"
+	"github.com/interuss/dss/pkg/api/ridv2"
+	"github.com/interuss/dss/pkg/api/scdv1"
+	apiversioningv1 "github.com/interuss/dss/pkg/api/versioningv1"
+	"github.com/stretchr/testify/require"
+)
+
+const ridV2SearchPath = "/rid/v2/dss/identification_service_areas"
+
+// dssRoutes returns the routes of all the APIs served by the DSS.
+func dssRoutes() []*api.Route {
+	var (
+		aux        = auxv1.MakeAPIRouter(nil, nil)
+		versioning = apiversioningv1.MakeAPIRouter(nil, nil)
+		ridV1      = ridv1.MakeAPIRouter(nil, nil)
+		ridV2      = ridv2.MakeAPIRouter(nil, nil)
+		scd        = scdv1.MakeAPIRouter(nil, nil)
+		routes     []*api.Route
+	)
+	for _, apiRoutes := range [][]*api.Route{aux.Routes, versioning.Routes, ridV1.Routes, ridV2.Routes, scd.Routes} {
+		routes = append(routes, apiRoutes...)
+	}
+	return routes
+}
+
+func recordingRoute(method string, path string, matched *api.PathParams) *api.Route {
+	return &api.Route{
+		Method: method,
+		Path:   path,
+		Handler: func(params api.PathParams, w http.ResponseWriter, r *http.Request) {
+			*matched = params
+			w.WriteHeader(http.StatusNoContent)
+		},
+	}
+}
+
+func TestRouterMatchesRoutes(t *testing.T) {
+	var matched api.PathParams
+	router := api.NewRouter(
+		recordingRoute(http.MethodGet, "/things/{id}", &matched),
+		recordingRoute(http.MethodPut, "/things/{id}/{version}", &matched),
+		recordingRoute(http.MethodGet, "/things/special", &matched),
+		recordingRoute(http.MethodGet, "/things/special/{part}/details", &matched),
+	)
+
+	for _, tc := range []struct {
+		method   string
+		path     string
+		status   int
+		expected api.PathParams
+	}{
+		{http.MethodGet, "/things/1", http.StatusNoContent, api.PathParams{{Name: "id", Value: "1"}}},
+		{http.MethodPut, "/things/1/v2", http.StatusNoContent, api.PathParams{{Name: "id", Value: "1"}, {Name: "version", Value: "v2"}}},
+		{http.MethodGet, "/things/special", http.StatusNoContent, nil},
+		{http.MethodGet, "/things/special/a/details", http.StatusNoContent, api.PathParams{{Name: "part", Value: "a"}}},
+		// Falls back to the parameter once the static segment leads nowhere
+		{http.MethodPut, "/things/special/v3", http.StatusNoContent, api.PathParams{{Name: "id", Value: "special"}, {Name: "version", Value: "v3"}}},
+		{http.MethodGet, "/things/1/v2", http.StatusMethodNotAllowed, nil},
+		{http.MethodGet, "/things", http.StatusNotFound, nil},
+		{http.MethodGet, "/things/1/v2/other", http.StatusNotFound, nil},
+		{http.MethodGet, "/other", http.StatusNotFound, nil},
+	} {
+		matched = nil
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+		require.Equal(t, tc.status, w.Code, "%s %s", tc.method, tc.path)
+		require.Equal(t, tc.expected, matched, "%s %s", tc.method, tc.path)
+		require.Equal(t, tc.expected.Get("id"), matched.Get("id"))
+	}
+}
+
+func TestRouterRespondsMethodNotAllowed(t *testing.T) {
+	var matched api.PathParams
+	router := api.NewRouter(
+		recordingRoute(http.MethodPut, "/things/{id}", &matched),
+		recordingRoute(http.MethodDelete, "/things/{id}", &matched),
+	)
+
+	w := httptest.NewRecorder()
+	require.True(t, router.Handle(w, httptest.NewRequest(http.MethodPost, "/things/1", nil)))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	require.Equal(t, "DELETE, PUT", w.Header().Get("Allow"))
+
+	require.False(t, router.Handle(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/others/1", nil)))
+}
+
+func TestRouterRejectsInvalidRoutes(t *testing.T) {
+	var matched api.PathParams
+	router := api.NewRouter(recordingRoute(http.MethodGet, "/things/{id}", &matched))
+	require.Panics(t, func() { router.Add(recordingRoute(http.MethodGet, "/things/{name}", &matched)) })
+	require.Panics(t, func() { router.Add(recordingRoute(http.MethodGet, "/things/id-{id}", &matched)) })
+	require.Panics(t, func() { router.Add(recordingRoute(http.MethodGet, "things", &matched)) })
+	require.NotPanics(t, func() { router.Add(recordingRoute(http.MethodPut, "/things/{name}", &matched)) })
+}
+
+func TestRouterRoutesDSSAPIs(t *testing.T) {
+	routes := dssRoutes()
+	router := api.NewRouter(routes...)
+
+	for _, route := range routes {
+		path := regexp.MustCompile("{[^}]*}").ReplaceAllString(route.Path, "x")
+		matched, _, _ := router.Lookup(route.Method, path)
+		require.Equal(t, route, matched, "%s %s", route.Method, route.Path)
+	}
+}
+
+func BenchmarkRouterLookup(b *testing.B) {
+	router := api.NewRouter(dssRoutes()...)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if route, _, _ := router.Lookup(http.MethodGet, ridV2SearchPath); route == nil {
+			b.Fatal("No route found")
+		}
+	}
+}
+
+// BenchmarkRegexpLookup measures the previous routing of requests, which
+// evaluated the regular expression of every route in turn.
+func BenchmarkRegexpLookup(b *testing.B) {
+	type regexpRoute struct {
+		method  string
+		pattern *regexp.Regexp
+	}
+	var routes []regexpRoute
+	for _, route := range dssRoutes() {
+		routes = append(routes, regexpRoute{
+			method:  route.Method,
+			pattern: regexp.MustCompile("^" + regexp.MustCompile("{([^}]*)}").ReplaceAllString(route.Path, "(?P<$1>[^/]*)") + "$"),
+		})
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		found := false
+		for _, route := range routes {
+			if route.method == http.MethodGet && route.pattern.MatchString(ridV2SearchPath) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			b.Fatal("No route found")
+		}
+	}
+}
@@ -6,31 +6,39 @@ import (
 	"encoding/json"
 	"github.com/interuss/dss/pkg/api"
 	"net/http"
-	"regexp"
 )
 
 type APIRouter struct {
 	Routes         []*api.Route
 	Implementation Implementation
 	Authorizer     api.Authorizer
+	Options        api.RouterOptions
+
+	router *api.Router
 }
 
 // *scdv1.APIRouter (type defined above) implements the api.PartialRouter interface
 func (s *APIRouter) Handle(w http.ResponseWriter, r *http.Request) bool {
-	for _, route := range s.Routes {
-		if route.Method == r.Method && route.Pattern.MatchString(r.URL.Path) {
-			route.Handler(route.Pattern, w, r)
-			return true
-		}
-	}
-	return false
+	return s.router.Handle(w, r)
 }
 
-func (s *APIRouter) QueryOperationalIntentReferences(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) QueryOperationalIntentReferences(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req QueryOperationalIntentReferencesRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, QueryOperationalIntentReferencesSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &QueryOperationalIntentReferencesRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse request body
 	req.Body = new(QueryOperationalIntentReferenceParameters)
@@ -38,7 +46,7 @@ func (s *APIRouter) QueryOperationalIntentReferences(exp *regexp.Regexp, w http.
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.QueryOperationalIntentReferences(ctx, &req)
 
@@ -74,18 +82,29 @@ func (s *APIRouter) QueryOperationalIntentReferences(exp *regexp.Regexp, w http.
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) GetOperationalIntentReference(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) GetOperationalIntentReference(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req GetOperationalIntentReferenceRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, GetOperationalIntentReferenceSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &GetOperationalIntentReferenceRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Entityid = EntityID(pathMatch[1])
+	req.Entityid = EntityID(params.Get("entityid"))
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.GetOperationalIntentReference(ctx, &req)
 
@@ -121,15 +140,26 @@ func (s *APIRouter) GetOperationalIntentReference(exp *regexp.Regexp, w http.Res
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) CreateOperationalIntentReference(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) CreateOperationalIntentReference(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req CreateOperationalIntentReferenceRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, CreateOperationalIntentReferenceSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &CreateOperationalIntentReferenceRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Entityid = EntityID(pathMatch[1])
+	req.Entityid = EntityID(params.Get("entityid"))
 
 	// Parse request body
 	req.Body = new(PutOperationalIntentReferenceParameters)
@@ -137,7 +167,7 @@ func (s *APIRouter) CreateOperationalIntentReference(exp *regexp.Regexp, w http.
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.CreateOperationalIntentReference(ctx, &req)
 
@@ -181,16 +211,27 @@ func (s *APIRouter) CreateOperationalIntentReference(exp *regexp.Regexp, w http.
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) UpdateOperationalIntentReference(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) UpdateOperationalIntentReference(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req UpdateOperationalIntentReferenceRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, UpdateOperationalIntentReferenceSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &UpdateOperationalIntentReferenceRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Entityid = EntityID(pathMatch[1])
-	req.Ovn = EntityOVN(pathMatch[2])
+	req.Entityid = EntityID(params.Get("entityid"))
+	req.Ovn = EntityOVN(params.Get("ovn"))
 
 	// Parse request body
 	req.Body = new(PutOperationalIntentReferenceParameters)
@@ -198,7 +239,7 @@ func (s *APIRouter) UpdateOperationalIntentReference(exp *regexp.Regexp, w http.
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.UpdateOperationalIntentReference(ctx, &req)
 
@@ -242,19 +283,30 @@ func (s *APIRouter) UpdateOperationalIntentReference(exp *regexp.Regexp, w http.
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) DeleteOperationalIntentReference(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) DeleteOperationalIntentReference(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req DeleteOperationalIntentReferenceRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, DeleteOperationalIntentReferenceSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &DeleteOperationalIntentReferenceRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Entityid = EntityID(pathMatch[1])
-	req.Ovn = EntityOVN(pathMatch[2])
+	req.Entityid = EntityID(params.Get("entityid"))
+	req.Ovn = EntityOVN(params.Get("ovn"))
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.DeleteOperationalIntentReference(ctx, &req)
 
@@ -298,11 +350,23 @@ func (s *APIRouter) DeleteOperationalIntentReference(exp *regexp.Regexp, w http.
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) QueryConstraintReferences(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) QueryConstraintReferences(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req QueryConstraintReferencesRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, QueryConstraintReferencesSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &QueryConstraintReferencesRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse request body
 	req.Body = new(QueryConstraintReferenceParameters)
@@ -310,7 +374,7 @@ func (s *APIRouter) QueryConstraintReferences(exp *regexp.Regexp, w http.Respons
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.QueryConstraintReferences(ctx, &req)
 
@@ -346,18 +410,29 @@ func (s *APIRouter) QueryConstraintReferences(exp *regexp.Regexp, w http.Respons
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) GetConstraintReference(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) GetConstraintReference(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req GetConstraintReferenceRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, GetConstraintReferenceSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &GetConstraintReferenceRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Entityid = EntityID(pathMatch[1])
+	req.Entityid = EntityID(params.Get("entityid"))
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.GetConstraintReference(ctx, &req)
 
@@ -393,15 +468,26 @@ func (s *APIRouter) GetConstraintReference(exp *regexp.Regexp, w http.ResponseWr
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) CreateConstraintReference(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) CreateConstraintReference(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req CreateConstraintReferenceRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, CreateConstraintReferenceSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &CreateConstraintReferenceRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Entityid = EntityID(pathMatch[1])
+	req.Entityid = EntityID(params.Get("entityid"))
 
 	// Parse request body
 	req.Body = new(PutConstraintReferenceParameters)
@@ -409,7 +495,7 @@ func (s *APIRouter) CreateConstraintReference(exp *regexp.Regexp, w http.Respons
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.CreateConstraintReference(ctx, &req)
 
@@ -449,16 +535,27 @@ func (s *APIRouter) CreateConstraintReference(exp *regexp.Regexp, w http.Respons
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) UpdateConstraintReference(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) UpdateConstraintReference(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req UpdateConstraintReferenceRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, UpdateConstraintReferenceSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &UpdateConstraintReferenceRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Entityid = EntityID(pathMatch[1])
-	req.Ovn = EntityOVN(pathMatch[2])
+	req.Entityid = EntityID(params.Get("entityid"))
+	req.Ovn = EntityOVN(params.Get("ovn"))
 
 	// Parse request body
 	req.Body = new(PutConstraintReferenceParameters)
@@ -466,7 +563,7 @@ func (s *APIRouter) UpdateConstraintReference(exp *regexp.Regexp, w http.Respons
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.UpdateConstraintReference(ctx, &req)
 
@@ -506,19 +603,30 @@ func (s *APIRouter) UpdateConstraintReference(exp *regexp.Regexp, w http.Respons
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) DeleteConstraintReference(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) DeleteConstraintReference(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req DeleteConstraintReferenceRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, DeleteConstraintReferenceSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &DeleteConstraintReferenceRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Entityid = EntityID(pathMatch[1])
-	req.Ovn = EntityOVN(pathMatch[2])
+	req.Entityid = EntityID(params.Get("entityid"))
+	req.Ovn = EntityOVN(params.Get("ovn"))
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.DeleteConstraintReference(ctx, &req)
 
@@ -558,11 +666,23 @@ func (s *APIRouter) DeleteConstraintReference(exp *regexp.Regexp, w http.Respons
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) QuerySubscriptions(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) QuerySubscriptions(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req QuerySubscriptionsRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, QuerySubscriptionsSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &QuerySubscriptionsRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse request body
 	req.Body = new(QuerySubscriptionParameters)
@@ -570,7 +690,7 @@ func (s *APIRouter) QuerySubscriptions(exp *regexp.Regexp, w http.ResponseWriter
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.QuerySubscriptions(ctx, &req)
 
@@ -606,18 +726,29 @@ func (s *APIRouter) QuerySubscriptions(exp *regexp.Regexp, w http.ResponseWriter
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) GetSubscription(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) GetSubscription(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req GetSubscriptionRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, GetSubscriptionSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &GetSubscriptionRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Subscriptionid = SubscriptionID(pathMatch[1])
+	req.Subscriptionid = SubscriptionID(params.Get("subscriptionid"))
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.GetSubscription(ctx, &req)
 
@@ -653,15 +784,26 @@ func (s *APIRouter) GetSubscription(exp *regexp.Regexp, w http.ResponseWriter, r
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) CreateSubscription(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) CreateSubscription(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req CreateSubscriptionRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, CreateSubscriptionSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &CreateSubscriptionRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Subscriptionid = SubscriptionID(pathMatch[1])
+	req.Subscriptionid = SubscriptionID(params.Get("subscriptionid"))
 
 	// Parse request body
 	req.Body = new(PutSubscriptionParameters)
@@ -669,7 +811,7 @@ func (s *APIRouter) CreateSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.CreateSubscription(ctx, &req)
 
@@ -705,16 +847,27 @@ func (s *APIRouter) CreateSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) UpdateSubscription(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) UpdateSubscription(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req UpdateSubscriptionRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, UpdateSubscriptionSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &UpdateSubscriptionRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Subscriptionid = SubscriptionID(pathMatch[1])
-	req.Version = pathMatch[2]
+	req.Subscriptionid = SubscriptionID(params.Get("subscriptionid"))
+	req.Version = params.Get("version")
 
 	// Parse request body
 	req.Body = new(PutSubscriptionParameters)
@@ -722,7 +875,7 @@ func (s *APIRouter) UpdateSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.UpdateSubscription(ctx, &req)
 
@@ -758,19 +911,30 @@ func (s *APIRouter) UpdateSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) DeleteSubscription(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) DeleteSubscription(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req DeleteSubscriptionRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, DeleteSubscriptionSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &DeleteSubscriptionRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.Subscriptionid = SubscriptionID(pathMatch[1])
-	req.Version = pathMatch[2]
+	req.Subscriptionid = SubscriptionID(params.Get("subscriptionid"))
+	req.Version = params.Get("version")
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.DeleteSubscription(ctx, &req)
 
@@ -810,11 +974,23 @@ func (s *APIRouter) DeleteSubscription(exp *regexp.Regexp, w http.ResponseWriter
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) MakeDssReport(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) MakeDssReport(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req MakeDssReportRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, MakeDssReportSecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &MakeDssReportRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse request body
 	req.Body = new(ErrorReport)
@@ -822,7 +998,7 @@ func (s *APIRouter) MakeDssReport(exp *regexp.Regexp, w http.ResponseWriter, r *
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.MakeDssReport(ctx, &req)
 
@@ -854,18 +1030,29 @@ func (s *APIRouter) MakeDssReport(exp *regexp.Regexp, w http.ResponseWriter, r *
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) GetUssAvailability(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) GetUssAvailability(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req GetUssAvailabilityRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, GetUssAvailabilitySecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &GetUssAvailabilityRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.UssId = pathMatch[1]
+	req.UssId = params.Get("uss_id")
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.GetUssAvailability(ctx, &req)
 
@@ -897,15 +1084,26 @@ func (s *APIRouter) GetUssAvailability(exp *regexp.Regexp, w http.ResponseWriter
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func (s *APIRouter) SetUssAvailability(exp *regexp.Regexp, w http.ResponseWriter, r *http.Request) {
+func (s *APIRouter) SetUssAvailability(params api.PathParams, w http.ResponseWriter, r *http.Request) {
 	var req SetUssAvailabilityRequest
 
 	// Authorize request
 	req.Auth = s.Authorizer.Authorize(w, r, SetUssAvailabilitySecurity)
+	api.RecordAuthorization(r, req.Auth)
+	if req.Auth.Handled {
+		return
+	}
+
+	// Validate request
+	if s.Options.ValidateRequests && req.Auth.Error == nil {
+		if violations := Schemas.ValidateRequest(r, params, &SetUssAvailabilityRequestSchema); len(violations) > 0 {
+			api.WriteViolations(w, violations)
+			return
+		}
+	}
 
 	// Parse path parameters
-	pathMatch := exp.FindStringSubmatch(r.URL.Path)
-	req.UssId = pathMatch[1]
+	req.UssId = params.Get("uss_id")
 
 	// Parse request body
 	req.Body = new(SetUssAvailabilityStatusParameters)
@@ -913,7 +1111,7 @@ func (s *APIRouter) SetUssAvailability(exp *regexp.Regexp, w http.ResponseWriter
 	req.BodyParseError = json.NewDecoder(r.Body).Decode(req.Body)
 
 	// Call implementation
-	ctx, cancel := context.WithCancel(r.Context())
+	ctx, cancel := context.WithCancel(api.WithResponseHeader(r.Context(), w))
 	defer cancel()
 	response := s.Implementation.SetUssAvailability(ctx, &req)
 
@@ -945,62 +1143,31 @@ func (s *APIRouter) SetUssAvailability(exp *regexp.Regexp, w http.ResponseWriter
 	api.WriteJSON(w, 500, api.InternalServerErrorBody{ErrorMessage: "Handler implementation did not set a response"})
 }
 
-func MakeAPIRouter(impl Implementation, auth api.Authorizer) APIRouter {
+func MakeAPIRouter(impl Implementation, auth api.Authorizer, options ...api.RouterOption) APIRouter {
 	router := APIRouter{Implementation: impl, Authorizer: auth, Routes: make([]*api.Route, 18)}
-
-	pattern := regexp.MustCompile("^/dss/v1/operational_intent_references/query$")
-	router.Routes[0] = &api.Route{Method: http.MethodPost, Pattern: pattern, Handler: router.QueryOperationalIntentReferences}
-
-	pattern = regexp.MustCompile("^/dss/v1/operational_intent_references/(?P<entityid>[^/]*)$")
-	router.Routes[1] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.GetOperationalIntentReference}
-
-	pattern = regexp.MustCompile("^/dss/v1/operational_intent_references/(?P<entityid>[^/]*)$")
-	router.Routes[2] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.CreateOperationalIntentReference}
-
-	pattern = regexp.MustCompile("^/dss/v1/operational_intent_references/(?P<entityid>[^/]*)/(?P<ovn>[^/]*)$")
-	router.Routes[3] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.UpdateOperationalIntentReference}
-
-	pattern = regexp.MustCompile("^/dss/v1/operational_intent_references/(?P<entityid>[^/]*)/(?P<ovn>[^/]*)$")
-	router.Routes[4] = &api.Route{Method: http.MethodDelete, Pattern: pattern, Handler: router.DeleteOperationalIntentReference}
-
-	pattern = regexp.MustCompile("^/dss/v1/constraint_references/query$")
-	router.Routes[5] = &api.Route{Method: http.MethodPost, Pattern: pattern, Handler: router.QueryConstraintReferences}
-
-	pattern = regexp.MustCompile("^/dss/v1/constraint_references/(?P<entityid>[^/]*)$")
-	router.Routes[6] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.GetConstraintReference}
-
-	pattern = regexp.MustCompile("^/dss/v1/constraint_references/(?P<entityid>[^/]*)$")
-	router.Routes[7] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.CreateConstraintReference}
-
-	pattern = regexp.MustCompile("^/dss/v1/constraint_references/(?P<entityid>[^/]*)/(?P<ovn>[^/]*)$")
-	router.Routes[8] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.UpdateConstraintReference}
-
-	pattern = regexp.MustCompile("^/dss/v1/constraint_references/(?P<entityid>[^/]*)/(?P<ovn>[^/]*)$")
-	router.Routes[9] = &api.Route{Method: http.MethodDelete, Pattern: pattern, Handler: router.DeleteConstraintReference}
-
-	pattern = regexp.MustCompile("^/dss/v1/subscriptions/query$")
-	router.Routes[10] = &api.Route{Method: http.MethodPost, Pattern: pattern, Handler: router.QuerySubscriptions}
-
-	pattern = regexp.MustCompile("^/dss/v1/subscriptions/(?P<subscriptionid>[^/]*)$")
-	router.Routes[11] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.GetSubscription}
-
-	pattern = regexp.MustCompile("^/dss/v1/subscriptions/(?P<subscriptionid>[^/]*)$")
-	router.Routes[12] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.CreateSubscription}
-
-	pattern = regexp.MustCompile("^/dss/v1/subscriptions/(?P<subscriptionid>[^/]*)/(?P<version>[^/]*)$")
-	router.Routes[13] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.UpdateSubscription}
-
-	pattern = regexp.MustCompile("^/dss/v1/subscriptions/(?P<subscriptionid>[^/]*)/(?P<version>[^/]*)$")
-	router.Routes[14] = &api.Route{Method: http.MethodDelete, Pattern: pattern, Handler: router.DeleteSubscription}
-
-	pattern = regexp.MustCompile("^/dss/v1/reports$")
-	router.Routes[15] = &api.Route{Method: http.MethodPost, Pattern: pattern, Handler: router.MakeDssReport}
-
-	pattern = regexp.MustCompile("^/dss/v1/uss_availability/(?P<uss_id>[^/]*)$")
-	router.Routes[16] = &api.Route{Method: http.MethodGet, Pattern: pattern, Handler: router.GetUssAvailability}
-
-	pattern = regexp.MustCompile("^/dss/v1/uss_availability/(?P<uss_id>[^/]*)$")
-	router.Routes[17] = &api.Route{Method: http.MethodPut, Pattern: pattern, Handler: router.SetUssAvailability}
-
+	for _, option := range options {
+		option(&router.Options)
+	}
+
+	router.Routes[0] = &api.Route{Method: http.MethodPost, Path: "/dss/v1/operational_intent_references/query", Handler: router.QueryOperationalIntentReferences}
+	router.Routes[1] = &api.Route{Method: http.MethodGet, Path: "/dss/v1/operational_intent_references/{entityid}", Handler: router.GetOperationalIntentReference}
+	router.Routes[2] = &api.Route{Method: http.MethodPut, Path: "/dss/v1/operational_intent_references/{entityid}", Handler: router.CreateOperationalIntentReference}
+	router.Routes[3] = &api.Route{Method: http.MethodPut, Path: "/dss/v1/operational_intent_references/{entityid}/{ovn}", Handler: router.UpdateOperationalIntentReference}
+	router.Routes[4] = &api.Route{Method: http.MethodDelete, Path: "/dss/v1/operational_intent_references/{entityid}/{ovn}", Handler: router.DeleteOperationalIntentReference}
+	router.Routes[5] = &api.Route{Method: http.MethodPost, Path: "/dss/v1/constraint_references/query", Handler: router.QueryConstraintReferences}
+	router.Routes[6] = &api.Route{Method: http.MethodGet, Path: "/dss/v1/constraint_references/{entityid}", Handler: router.GetConstraintReference}
+	router.Routes[7] = &api.Route{Method: http.MethodPut, Path: "/dss/v1/constraint_references/{entityid}", Handler: router.CreateConstraintReference}
+	router.Routes[8] = &api.Route{Method: http.MethodPut, Path: "/dss/v1/constraint_references/{entityid}/{ovn}", Handler: router.UpdateConstraintReference}
+	router.Routes[9] = &api.Route{Method: http.MethodDelete, Path: "/dss/v1/constraint_references/{entityid}/{ovn}", Handler: router.DeleteConstraintReference}
+	router.Routes[10] = &api.Route{Method: http.MethodPost, Path: "/dss/v1/subscriptions/query", Handler: router.QuerySubscriptions}
+	router.Routes[11] = &api.Route{Method: http.MethodGet, Path: "/dss/v1/subscriptions/{subscriptionid}", Handler: router.GetSubscription}
+	router.Routes[12] = &api.Route{Method: http.MethodPut, Path: "/dss/v1/subscriptions/{subscriptionid}", Handler: router.CreateSubscription}
+	router.Routes[13] = &api.Route{Method: http.MethodPut, Path: "/dss/v1/subscriptions/{subscriptionid}/{version}", Handler: router.UpdateSubscription}
+	router.Routes[14] = &api.Route{Method: http.MethodDelete, Path: "/dss/v1/subscriptions/{subscriptionid}/{version}", Handler: router.DeleteSubscription}
+	router.Routes[15] = &api.Route{Method: http.MethodPost, Path: "/dss/v1/reports", Handler: router.MakeDssReport}
+	router.Routes[16] = &api.Route{Method: http.MethodGet, Path: "/dss/v1/uss_availability/{uss_id}", Handler: router.GetUssAvailability}
+	router.Routes[17] = &api.Route{Method: http.MethodPut, Path: "/dss/v1/uss_availability/{uss_id}", Handler: router.SetUssAvailability}
+
+	router.router = api.NewRouter(router.Routes...)
 	return router
 }
@@ -278,6 +278,9 @@ type PutOperationalIntentReferenceParameters struct {
 	// This optional field not part of the original F3548 standard API allows a USS to request a specific OVN when creating or updating an operational intent. When creating an operational intent, this enables a USS to immediately publish the operational intent details with the expected OVN. When updating an operational intent, this enables a USS to immediately make available this new version of the operational intent details if specifically requested by the remote USS. The USS must still wait for the DSS receipt to actually publish the new operational intent details. This allows USSs to obtain correct operational intent details even if the DSS takes a long time to respond and/or the USS processing  it.
 	// The requested suffix must be a UUIDv7 string containing a timestamp of the current time. If the suffix is invalid, and notably if the time is too far in the past or the future, the request will be rejected. If the suffix is valid, the DSS will set the OVN of the operational intent to be `{entityid}_{requested_ovn_suffix}`. If no suffix is set, the DSS will proceed as specified by the standard.
 	RequestedOvnSuffix *UUIDv7Format `json:"requested_ovn_suffix,omitempty"`
+
+	// DSS extension: priority of the operational intent, which the DSS does not otherwise know.  It is forwarded in the details of the operational intent notified to subscribers, and is 0 if absent.
+	Priority *int32 `json:"priority,omitempty"`
 }
 
 // Information necessary to create a subscription to serve a single operational intent's notification needs.
@@ -292,6 +295,9 @@ type ImplicitSubscriptionParameters struct {
 // Response to DSS request for the OperationalIntentReference with the given ID.
 type GetOperationalIntentReferenceResponse struct {
 	OperationalIntentReference OperationalIntentReference `json:"operational_intent_reference"`
+
+	// DSS extension: spacetime extents submitted for the operational intent, in the order they were submitted.  The outline of a volume is absent when the DSS only knows the cells it covers.
+	Extents *[]Volume4D `json:"extents,omitempty"`
 }
 
 // Response to a request to create, update, or delete an OperationalIntentReference in the DSS.
@@ -350,6 +356,9 @@ type PutConstraintReferenceParameters struct {
 // Response to DSS request for the ConstraintReference with the given ID.
 type GetConstraintReferenceResponse struct {
 	ConstraintReference ConstraintReference `json:"constraint_reference"`
+
+	// DSS extension: spacetime extents submitted for the constraint, in the order they were submitted.  The outline of a volume is absent when the DSS only knows the cells it covers.
+	Extents *[]Volume4D `json:"extents,omitempty"`
 }
 
 // Response to a request to create, update, or delete a ConstraintReference. in the DSS.
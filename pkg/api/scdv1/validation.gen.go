@@ -0,0 +1,484 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package scdv1
+
+import (
+	"github.com/interuss/dss/pkg/api"
+	"regexp"
+)
+
+// Schemas are the OpenAPI schemas of the data types of the requests of this
+// API, by name.
+var Schemas = api.Schemas{
+	"UUIDv4Format": {
+		Type:      "string",
+		Format:    "uuid",
+		MinLength: api.Int(36),
+		MaxLength: api.Int(36),
+		Pattern:   regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-4[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`),
+	},
+	"UUIDv7Format": {
+		Type:      "string",
+		Format:    "uuid",
+		MinLength: api.Int(36),
+		MaxLength: api.Int(36),
+		Pattern:   regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-7[0-9a-fA-F]{3}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}$`),
+	},
+	"EntityID": {
+		Ref: "UUIDv4Format",
+	},
+	"EntityOVN": {
+		Type:      "string",
+		MinLength: api.Int(16),
+		MaxLength: api.Int(128),
+	},
+	"SubscriptionID": {
+		Ref: "UUIDv4Format",
+	},
+	"Key": {
+		Type: "array",
+		Items: &api.Schema{
+			Ref: "EntityOVN",
+		},
+	},
+	"Time": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"value": {
+				Type:   "string",
+				Format: "date-time",
+			},
+			"format": {
+				Type: "string",
+				Enum: []string{"RFC3339"},
+			},
+		},
+		Required: []string{"value", "format"},
+	},
+	"Radius": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"value": {
+				Type:             "number",
+				Format:           "float",
+				Minimum:          api.Float64(0),
+				ExclusiveMinimum: true,
+			},
+			"units": {
+				Type: "string",
+				Enum: []string{"M"},
+			},
+		},
+		Required: []string{"value", "units"},
+	},
+	"Altitude": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"value": {
+				Type:    "number",
+				Format:  "double",
+				Minimum: api.Float64(-8000),
+				Maximum: api.Float64(100000),
+			},
+			"reference": {
+				Type: "string",
+				Enum: []string{"W84"},
+			},
+			"units": {
+				Type: "string",
+				Enum: []string{"M"},
+			},
+		},
+		Required: []string{"value", "reference", "units"},
+	},
+	"Latitude": {
+		Type:    "number",
+		Format:  "double",
+		Minimum: api.Float64(-90),
+		Maximum: api.Float64(90),
+	},
+	"Longitude": {
+		Type:    "number",
+		Format:  "double",
+		Minimum: api.Float64(-180),
+		Maximum: api.Float64(180),
+	},
+	"Polygon": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"vertices": {
+				Type:     "array",
+				MinItems: api.Int(3),
+				Items: &api.Schema{
+					Ref: "LatLngPoint",
+				},
+			},
+		},
+		Required: []string{"vertices"},
+	},
+	"LatLngPoint": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"lng": {
+				Ref: "Longitude",
+			},
+			"lat": {
+				Ref: "Latitude",
+			},
+		},
+		Required: []string{"lng", "lat"},
+	},
+	"Circle": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"center": {
+				Ref: "LatLngPoint",
+			},
+			"radius": {
+				Ref: "Radius",
+			},
+		},
+	},
+	"Volume3D": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"outline_circle": {
+				Ref: "Circle",
+			},
+			"outline_polygon": {
+				Ref: "Polygon",
+			},
+			"altitude_lower": {
+				Ref: "Altitude",
+			},
+			"altitude_upper": {
+				Ref: "Altitude",
+			},
+		},
+	},
+	"Volume4D": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"volume": {
+				Ref: "Volume3D",
+			},
+			"time_start": {
+				Ref: "Time",
+			},
+			"time_end": {
+				Ref: "Time",
+			},
+		},
+		Required: []string{"volume"},
+	},
+	"QuerySubscriptionParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"area_of_interest": {
+				Ref: "Volume4D",
+			},
+		},
+	},
+	"PutSubscriptionParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Ref: "Volume4D",
+			},
+			"uss_base_url": {
+				Ref: "SubscriptionUssBaseURL",
+			},
+			"notify_for_operational_intents": {
+				Type: "boolean",
+			},
+			"notify_for_constraints": {
+				Type: "boolean",
+			},
+		},
+		Required: []string{"extents", "uss_base_url"},
+	},
+	"SubscriptionUssBaseURL": {
+		Ref: "UssBaseURL",
+	},
+	"UssBaseURL": {
+		Type: "string",
+	},
+	"OperationalIntentState": {
+		Type: "string",
+		Enum: []string{"Accepted", "Activated", "Nonconforming", "Contingent"},
+	},
+	"OperationalIntentUssBaseURL": {
+		Ref: "UssBaseURL",
+	},
+	"PutOperationalIntentReferenceParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Type: "array",
+				Items: &api.Schema{
+					Ref: "Volume4D",
+				},
+			},
+			"key": {
+				Ref: "Key",
+			},
+			"state": {
+				Ref: "OperationalIntentState",
+			},
+			"uss_base_url": {
+				Ref: "OperationalIntentUssBaseURL",
+			},
+			"subscription_id": {
+				Ref: "EntityID",
+			},
+			"new_subscription": {
+				Ref: "ImplicitSubscriptionParameters",
+			},
+			"requested_ovn_suffix": {
+				Ref: "UUIDv7Format",
+			},
+			"priority": {
+				Type:   "integer",
+				Format: "int32",
+			},
+		},
+		Required: []string{"extents", "state", "uss_base_url"},
+	},
+	"ImplicitSubscriptionParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"uss_base_url": {
+				Ref: "SubscriptionUssBaseURL",
+			},
+			"notify_for_constraints": {
+				Type: "boolean",
+			},
+		},
+		Required: []string{"uss_base_url"},
+	},
+	"QueryOperationalIntentReferenceParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"area_of_interest": {
+				Ref: "Volume4D",
+			},
+		},
+	},
+	"ConstraintUssBaseURL": {
+		Ref: "UssBaseURL",
+	},
+	"PutConstraintReferenceParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"extents": {
+				Type: "array",
+				Items: &api.Schema{
+					Ref: "Volume4D",
+				},
+			},
+			"uss_base_url": {
+				Ref: "ConstraintUssBaseURL",
+			},
+		},
+		Required: []string{"extents", "uss_base_url"},
+	},
+	"QueryConstraintReferenceParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"area_of_interest": {
+				Ref: "Volume4D",
+			},
+		},
+	},
+	"UssAvailabilityState": {
+		Type: "string",
+		Enum: []string{"Unknown", "Normal", "Down"},
+	},
+	"SetUssAvailabilityStatusParameters": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"old_version": {
+				Type: "string",
+			},
+			"availability": {
+				Ref: "UssAvailabilityState",
+			},
+		},
+		Required: []string{"old_version", "availability"},
+	},
+	"ExchangeRecord": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"url": {
+				Type: "string",
+			},
+			"method": {
+				Type: "string",
+			},
+			"headers": {
+				Type: "array",
+				Items: &api.Schema{
+					Type: "string",
+				},
+			},
+			"recorder_role": {
+				Type: "string",
+			},
+			"request_time": {
+				Ref: "Time",
+			},
+			"request_body": {
+				Type: "string",
+			},
+			"response_time": {
+				Ref: "Time",
+			},
+			"response_body": {
+				Type: "string",
+			},
+			"response_code": {
+				Type:   "integer",
+				Format: "int32",
+			},
+			"problem": {
+				Type: "string",
+			},
+		},
+		Required: []string{"url", "method", "recorder_role", "request_time"},
+	},
+	"ErrorReport": {
+		Type: "object",
+		Properties: map[string]*api.Schema{
+			"report_id": {
+				Type: "string",
+			},
+			"exchange": {
+				Ref: "ExchangeRecord",
+			},
+		},
+		Required: []string{"exchange"},
+	},
+}
+
+var QueryOperationalIntentReferencesRequestSchema = api.RequestSchema{
+	Body:         &api.Schema{Ref: "QueryOperationalIntentReferenceParameters"},
+	BodyRequired: true,
+}
+
+var GetOperationalIntentReferenceRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "entityid", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityID"}},
+	},
+}
+
+var CreateOperationalIntentReferenceRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "entityid", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityID"}},
+	},
+	Body:         &api.Schema{Ref: "PutOperationalIntentReferenceParameters"},
+	BodyRequired: true,
+}
+
+var UpdateOperationalIntentReferenceRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "entityid", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityID"}},
+		{Name: "ovn", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityOVN"}},
+	},
+	Body:         &api.Schema{Ref: "PutOperationalIntentReferenceParameters"},
+	BodyRequired: true,
+}
+
+var DeleteOperationalIntentReferenceRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "entityid", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityID"}},
+		{Name: "ovn", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityOVN"}},
+	},
+}
+
+var QueryConstraintReferencesRequestSchema = api.RequestSchema{
+	Body:         &api.Schema{Ref: "QueryConstraintReferenceParameters"},
+	BodyRequired: true,
+}
+
+var GetConstraintReferenceRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "entityid", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityID"}},
+	},
+}
+
+var CreateConstraintReferenceRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "entityid", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityID"}},
+	},
+	Body:         &api.Schema{Ref: "PutConstraintReferenceParameters"},
+	BodyRequired: true,
+}
+
+var UpdateConstraintReferenceRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "entityid", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityID"}},
+		{Name: "ovn", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityOVN"}},
+	},
+	Body:         &api.Schema{Ref: "PutConstraintReferenceParameters"},
+	BodyRequired: true,
+}
+
+var DeleteConstraintReferenceRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "entityid", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityID"}},
+		{Name: "ovn", In: "path", Required: true, Schema: &api.Schema{Ref: "EntityOVN"}},
+	},
+}
+
+var QuerySubscriptionsRequestSchema = api.RequestSchema{
+	Body:         &api.Schema{Ref: "QuerySubscriptionParameters"},
+	BodyRequired: true,
+}
+
+var GetSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "subscriptionid", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionID"}},
+	},
+}
+
+var CreateSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "subscriptionid", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionID"}},
+	},
+	Body:         &api.Schema{Ref: "PutSubscriptionParameters"},
+	BodyRequired: true,
+}
+
+var UpdateSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "subscriptionid", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+	Body:         &api.Schema{Ref: "PutSubscriptionParameters"},
+	BodyRequired: true,
+}
+
+var DeleteSubscriptionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "subscriptionid", In: "path", Required: true, Schema: &api.Schema{Ref: "SubscriptionID"}},
+		{Name: "version", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
+
+var MakeDssReportRequestSchema = api.RequestSchema{
+	Body:         &api.Schema{Ref: "ErrorReport"},
+	BodyRequired: true,
+}
+
+var GetUssAvailabilityRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "uss_id", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+}
+
+var SetUssAvailabilityRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "uss_id", In: "path", Required: true, Schema: &api.Schema{Type: "string"}},
+	},
+	Body:         &api.Schema{Ref: "SetUssAvailabilityStatusParameters"},
+	BodyRequired: true,
+}
@@ -0,0 +1,132 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/dss/pkg/api/ridv2"
+	"github.com/stretchr/testify/require"
+)
+
+const validISA = `{
+  "extents": {
+    "volume": {
+      "outline_polygon": {"vertices": [{"lat": 37.0, "lng": -122.0}, {"lat": 37.0, "lng": -122.1}, {"lat": 37.1, "lng": -122.0}]},
+      "altitude_lower": {"value": 0, "reference": "W84", "units": "M"},
+      "altitude_upper": {"value": 100, "reference": "W84", "units": "M"}
+    },
+    "time_start": {"value": "2024-01-01T00:00:00Z", "format": "RFC3339"},
+    "time_end": {"value": "2024-01-01T01:00:00.5Z", "format": "RFC3339"}
+  },
+  "uss_base_url": "https://uss.example.com/flights"
+}`
+
+// ridV2Implementation records the requests reaching the implementation of the
+// RID v2 API.
+type ridV2Implementation struct {
+	ridv2.Implementation
+	created  *ridv2.CreateIdentificationServiceAreaRequest
+	searched *ridv2.SearchIdentificationServiceAreasRequest
+}
+
+func (i *ridV2Implementation) CreateIdentificationServiceArea(ctx context.Context, req *ridv2.CreateIdentificationServiceAreaRequest) ridv2.CreateIdentificationServiceAreaResponseSet {
+	i.created = req
+	return ridv2.CreateIdentificationServiceAreaResponseSet{Response200: &ridv2.PutIdentificationServiceAreaResponse{}}
+}
+
+func (i *ridV2Implementation) SearchIdentificationServiceAreas(ctx context.Context, req *ridv2.SearchIdentificationServiceAreasRequest) ridv2.SearchIdentificationServiceAreasResponseSet {
+	i.searched = req
+	return ridv2.SearchIdentificationServiceAreasResponseSet{Response200: &ridv2.SearchIdentificationServiceAreasResponse{}}
+}
+
+// headerAuthorizer authorizes the requests carrying an Authorization header.
+type headerAuthorizer struct{}
+
+func (headerAuthorizer) Authorize(w http.ResponseWriter, r *http.Request, authOptions []api.AuthorizationOption) api.AuthorizationResult {
+	if r.Header.Get("Authorization") == "" {
+		return api.AuthorizationResult{Error: errors.New("missing access token")}
+	}
+	return api.AuthorizationResult{}
+}
+
+func serveRIDV2(impl *ridV2Implementation, method string, target string, body string, options ...api.RouterOption) *httptest.ResponseRecorder {
+	router := ridv2.MakeAPIRouter(impl, headerAuthorizer{}, options...)
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	api.NewRouter(router.Routes...).ServeHTTP(w, r)
+	return w
+}
+
+func TestValidationRejectsInvalidRequests(t *testing.T) {
+	invalidISA := strings.NewReplacer(
+		`"lat": 37.1`, `"lat": 91`,
+		`"reference": "W84", "units": "M"}
+    },`, `"reference": "AGL", "units": "M"}
+    },`,
+		`"2024-01-01T00:00:00Z"`, `"yesterday"`,
+		`,
+  "uss_base_url": "https://uss.example.com/flights"`, ``,
+	).Replace(validISA)
+	impl := &ridV2Implementation{}
+
+	w := serveRIDV2(impl, http.MethodPut, "/rid/v2/dss/identification_service_areas/not-a-uuid", invalidISA, api.WithRequestValidation(true))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Nil(t, impl.created)
+
+	var body api.ValidationErrorBody
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	var locations []string
+	for _, violation := range body.Violations {
+		locations = append(locations, violation.Location)
+	}
+	require.Equal(t, []string{
+		"path.id",
+		"body.uss_base_url",
+		"body.extents.time_start.value",
+		"body.extents.volume.altitude_upper.reference",
+		"body.extents.volume.outline_polygon.vertices[2].lat",
+	}, locations)
+	require.Contains(t, body.Message, "body.extents.volume.outline_polygon.vertices[2].lat: must be at most 90")
+
+	w = serveRIDV2(impl, http.MethodGet, "/rid/v2/dss/identification_service_areas?earliest_time=now", "", api.WithRequestValidation(true))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Nil(t, impl.searched)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	require.Equal(t, []api.Violation{
+		{Location: "query.area", Message: "is required"},
+		{Location: "query.earliest_time", Message: "must be a valid date-time"},
+	}, body.Violations)
+}
+
+func TestValidationPassesValidRequests(t *testing.T) {
+	impl := &ridV2Implementation{}
+
+	w := serveRIDV2(impl, http.MethodPut, "/rid/v2/dss/identification_service_areas/00000000-0000-4000-8000-000000000001", validISA, api.WithRequestValidation(true))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, impl.created)
+	require.NoError(t, impl.created.BodyParseError)
+	require.Equal(t, ridv2.FlightsUSSBaseURL("https://uss.example.com/flights"), impl.created.Body.UssBaseUrl)
+}
+
+func TestValidationIsOptional(t *testing.T) {
+	impl := &ridV2Implementation{}
+
+	w := serveRIDV2(impl, http.MethodPut, "/rid/v2/dss/identification_service_areas/not-a-uuid", `{}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, impl.created)
+
+	// Unauthorized requests are left to the implementation
+	router := ridv2.MakeAPIRouter(impl, headerAuthorizer{}, api.WithRequestValidation(true))
+	impl.searched = nil
+	w = httptest.NewRecorder()
+	api.NewRouter(router.Routes...).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/rid/v2/dss/identification_service_areas", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, impl.searched)
+}
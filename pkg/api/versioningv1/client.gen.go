@@ -0,0 +1,57 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package versioning
+
+import (
+	"context"
+	"github.com/interuss/dss/pkg/api"
+	"net/http"
+	"net/url"
+)
+
+// Client sends the requests of the operations of this API to a server.
+type Client struct {
+	// BaseURL is the URL the paths of the operations are relative to, without
+	// trailing slash
+	BaseURL string
+	// HTTPClient sends the requests; http.DefaultClient is used if nil
+	HTTPClient *http.Client
+	// TokenSource provides the access tokens authorizing the requests, which
+	// are sent without access token if nil
+	TokenSource api.TokenSource
+}
+
+// GetVersion sends the request of the GetVersion operation.
+func (c *Client) GetVersion(ctx context.Context, req *GetVersionRequest) (*GetVersionResponseSet, error) {
+	path := "/versions/" + url.PathEscape(string(req.SystemIdentity))
+
+	resp, err := api.SendRequest(ctx, c.HTTPClient, c.TokenSource, GetVersionSecurity, http.MethodGet, c.BaseURL+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := &GetVersionResponseSet{}
+	switch resp.StatusCode {
+	case 200:
+		response.Response200 = new(GetVersionResponse)
+		err = api.ReadJSON(resp, response.Response200)
+	case 401:
+		response.Response401 = new(api.EmptyResponseBody)
+		err = api.ReadJSON(resp, response.Response401)
+	case 403:
+		response.Response403 = new(api.EmptyResponseBody)
+		err = api.ReadJSON(resp, response.Response403)
+	case 404:
+		response.Response404 = new(api.EmptyResponseBody)
+		err = api.ReadJSON(resp, response.Response404)
+	case 500:
+		response.Response500 = new(api.InternalServerErrorBody)
+		err = api.ReadJSON(resp, response.Response500)
+	default:
+		err = api.NewResponseError(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,20 @@
+// This file is auto-generated; do not change as any changes will be overwritten
+package versioning
+
+import (
+	"github.com/interuss/dss/pkg/api"
+)
+
+// Schemas are the OpenAPI schemas of the data types of the requests of this
+// API, by name.
+var Schemas = api.Schemas{
+	"SystemBoundaryIdentifier": {
+		Type: "string",
+	},
+}
+
+var GetVersionRequestSchema = api.RequestSchema{
+	Parameters: []api.ParameterSchema{
+		{Name: "system_identity", In: "path", Required: true, Schema: &api.Schema{Ref: "SystemBoundaryIdentifier"}},
+	},
+}
@@ -0,0 +1,112 @@
+// Package audit defines the append-only trail of the changes made to the
+// entities of the DSS.
+//
+// Audit records are appended by the repositories of the store holding the
+// entities they are about, in the transaction changing those entities, so
+// that the trail reflects exactly the committed changes.
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/geo/s2"
+	dssmodels "github.com/interuss/dss/pkg/models"
+)
+
+// EntityType identifies the kind of entity an audit record is about.
+type EntityType string
+
+// Operation is the kind of change an audit record describes.
+type Operation string
+
+const (
+	EntityISA               EntityType = "isa"
+	EntityRIDSubscription   EntityType = "rid_subscription"
+	EntitySCDSubscription   EntityType = "scd_subscription"
+	EntityOperationalIntent EntityType = "operational_intent"
+	EntityConstraint        EntityType = "constraint"
+)
+
+const (
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+)
+
+// Record describes a change made to an entity.
+type Record struct {
+	ID         dssmodels.ID `json:"id"`
+	EntityType EntityType   `json:"entity_type"`
+	EntityID   dssmodels.ID `json:"entity_id"`
+	Operation  Operation    `json:"operation"`
+	// Manager is the owner or manager of the entity making the change, as
+	// identified by its access token.
+	Manager string `json:"manager"`
+	// OldVersion and NewVersion are the version or OVN of the entity before
+	// and after the change; OldVersion is empty for a creation and NewVersion
+	// for a deletion.
+	OldVersion string `json:"old_version,omitempty"`
+	NewVersion string `json:"new_version,omitempty"`
+	// Cells are the cells covered by the entity after the change, or before
+	// it for a deletion.
+	Cells s2.CellUnion `json:"cells"`
+	// RecordedAt is the timestamp of the transaction making the change.
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// NewRecord returns the Record of the change made by manager to the entity of
+// type entityType identified by id, from oldVersion to newVersion. The
+// Operation is a creation if oldVersion is empty, a deletion if newVersion is
+// empty and an update otherwise.
+func NewRecord(entityType EntityType, id dssmodels.ID, manager string, oldVersion, newVersion string, cells s2.CellUnion) *Record {
+	op := OperationUpdate
+	switch {
+	case oldVersion == "":
+		op = OperationCreate
+	case newVersion == "":
+		op = OperationDelete
+	}
+	return &Record{
+		EntityType: entityType,
+		EntityID:   id,
+		Operation:  op,
+		Manager:    manager,
+		OldVersion: oldVersion,
+		NewVersion: newVersion,
+		Cells:      cells,
+	}
+}
+
+// Filter selects audit records. Zero-valued fields do not constrain the
+// selection.
+type Filter struct {
+	EntityID dssmodels.ID
+	Manager  string
+	// From and To bound the RecordedAt timestamp of the records, inclusively.
+	From time.Time
+	To   time.Time
+	// Limit is the maximum number of records returned.
+	Limit int
+}
+
+// Matches returns whether r is selected by f, ignoring f.Limit.
+func (f Filter) Matches(r *Record) bool {
+	switch {
+	case f.EntityID != "" && r.EntityID != f.EntityID:
+		return false
+	case f.Manager != "" && r.Manager != f.Manager:
+		return false
+	case !f.From.IsZero() && r.RecordedAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && r.RecordedAt.After(f.To):
+		return false
+	}
+	return true
+}
+
+// Log gives read access to an audit trail.
+type Log interface {
+	// QueryAuditRecords returns the records selected by filter, oldest first.
+	QueryAuditRecords(ctx context.Context, filter Filter) ([]*Record, error)
+}
@@ -0,0 +1,140 @@
+// Package cockroach implements the audit trail of a DSS store backed by a
+// CockroachDB database.
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/geo/s2"
+	"github.com/interuss/dss/pkg/audit"
+	dssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+)
+
+const recordFields = "id, entity_type, entity_id, operation, manager, COALESCE(old_version, ''), COALESCE(new_version, ''), cells, recorded_at"
+
+// Log appends to and queries the audit_log table of the database q is
+// connected to.
+type Log struct {
+	q dssql.Queryable
+}
+
+// NewLog returns a Log querying q, which may be a connection pool or a
+// transaction.
+func NewLog(q dssql.Queryable) *Log {
+	return &Log{q: q}
+}
+
+// Append adds r to the audit trail, recorded at the timestamp of the ongoing
+// transaction.
+func (l *Log) Append(ctx context.Context, r *audit.Record) error {
+	const query = `
+		INSERT INTO
+			audit_log
+			(entity_type, entity_id, operation, manager, old_version, new_version, cells, recorded_at)
+		VALUES
+			($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7, transaction_timestamp())`
+
+	id, err := r.EntityID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	_, err = l.q.Exec(ctx, query,
+		string(r.EntityType),
+		id,
+		string(r.Operation),
+		r.Manager,
+		r.OldVersion,
+		r.NewVersion,
+		dssql.CellUnionToCellIds(r.Cells))
+	if err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
+
+// QueryAuditRecords implements audit.Log.
+func (l *Log) QueryAuditRecords(ctx context.Context, filter audit.Filter) ([]*audit.Record, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if filter.EntityID != "" {
+		id, err := filter.EntityID.PgUUID()
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+		}
+		args = append(args, id)
+		conditions = append(conditions, fmt.Sprintf("entity_id = $%d", len(args)))
+	}
+	if filter.Manager != "" {
+		args = append(args, filter.Manager)
+		conditions = append(conditions, fmt.Sprintf("manager = $%d", len(args)))
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("recorded_at >= $%d", len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("recorded_at <= $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	limit := ""
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		limit = fmt.Sprintf("LIMIT $%d", len(args))
+	}
+
+	var query = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			audit_log
+		%s
+		ORDER BY
+			recorded_at, id
+		%s`, recordFields, where, limit)
+
+	rows, err := l.q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	var payload []*audit.Record
+	for rows.Next() {
+		var (
+			r    = new(audit.Record)
+			cids []int64
+		)
+		err := rows.Scan(
+			&r.ID,
+			&r.EntityType,
+			&r.EntityID,
+			&r.Operation,
+			&r.Manager,
+			&r.OldVersion,
+			&r.NewVersion,
+			&cids,
+			&r.RecordedAt,
+		)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning audit record row")
+		}
+		r.Cells = make(s2.CellUnion, len(cids))
+		for i, cid := range cids {
+			r.Cells[i] = s2.CellID(cid)
+		}
+		payload = append(payload, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return payload, nil
+}
@@ -0,0 +1,49 @@
+// Package memory implements the audit trail of a DSS store keeping its data in
+// process memory.
+package memory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/interuss/dss/pkg/audit"
+	dssmodels "github.com/interuss/dss/pkg/models"
+)
+
+// Trail holds the records of an in-memory audit trail. It is meant to be part
+// of the data of an in-memory store so that records are appended atomically
+// with the changes they are about; it performs no locking itself.
+type Trail struct {
+	records []*audit.Record
+}
+
+// Clone returns a copy of t. Records are never modified once appended, so they
+// are shared with t.
+func (t Trail) Clone() Trail {
+	return Trail{records: append([]*audit.Record(nil), t.records...)}
+}
+
+// Append adds a copy of r to t, recorded at now.
+func (t *Trail) Append(now time.Time, r *audit.Record) {
+	c := *r
+	c.ID = dssmodels.ID(uuid.New().String())
+	c.Cells = append(c.Cells[:0:0], r.Cells...)
+	c.RecordedAt = now
+	t.records = append(t.records, &c)
+}
+
+// Query returns copies of the records of t selected by filter, oldest first.
+func (t *Trail) Query(filter audit.Filter) []*audit.Record {
+	var result []*audit.Record
+	for _, r := range t.records {
+		if filter.Limit > 0 && len(result) >= filter.Limit {
+			break
+		}
+		if filter.Matches(r) {
+			c := *r
+			c.Cells = append(c.Cells[:0:0], r.Cells...)
+			result = append(result, &c)
+		}
+	}
+	return result
+}
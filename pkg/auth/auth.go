@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
@@ -17,6 +19,7 @@ import (
 	"github.com/interuss/dss/pkg/api"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/metrics"
 	"github.com/interuss/stacktrace"
 
 	"github.com/go-jose/go-jose/v4"
@@ -26,7 +29,8 @@ import (
 
 // KeyResolver abstracts resolving keys.
 type KeyResolver interface {
-	// ResolveKeys returns a public or private key, most commonly an rsa.PublicKey.
+	// ResolveKeys returns public keys verifying access tokens: *rsa.PublicKey,
+	// *ecdsa.PublicKey or ed25519.PublicKey.
 	ResolveKeys(context.Context) ([]interface{}, error)
 }
 
@@ -45,7 +49,8 @@ type FromFileKeyResolver struct {
 	keys     []interface{}
 }
 
-// ResolveKeys resolves an RSA public key from file for verifying JWTs.
+// ResolveKeys resolves RSA, ECDSA or Ed25519 public keys from PEM files for
+// verifying JWTs.
 func (r *FromFileKeyResolver) ResolveKeys(context.Context) ([]interface{}, error) {
 	if r.keys != nil {
 		return r.keys, nil
@@ -60,13 +65,12 @@ func (r *FromFileKeyResolver) ResolveKeys(context.Context) ([]interface{}, error
 		if pub == nil {
 			return nil, stacktrace.NewError("Failed to decode key file")
 		}
-		parsedKey, err := x509.ParsePKIXPublicKey(pub.Bytes)
+		key, err := x509.ParsePKIXPublicKey(pub.Bytes)
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "Error parsing key as x509 public key")
 		}
-		key, ok := parsedKey.(*rsa.PublicKey)
-		if !ok {
-			return nil, stacktrace.NewError("Could not create RSA public key from %s", f)
+		if !isSupportedKey(key) {
+			return nil, stacktrace.NewError("Unsupported type of public key %T in %s", key, f)
 		}
 		r.keys = append(r.keys, key)
 	}
@@ -81,7 +85,9 @@ type JWKSResolver struct {
 	KeyIDs []string
 }
 
-// ResolveKeys resolves an RSA public key from file for verifying JWTs.
+// ResolveKeys resolves the RSA (kty RSA), ECDSA (kty EC) and Ed25519 (kty OKP)
+// public keys served by the JWKS endpoint for verifying JWTs. Other keys are
+// ignored.
 func (r *JWKSResolver) ResolveKeys(ctx context.Context) ([]interface{}, error) {
 	req := http.Request{
 		Method: http.MethodGet,
@@ -113,6 +119,9 @@ func (r *JWKSResolver) ResolveKeys(ctx context.Context) ([]interface{}, error) {
 		webKeys = append(webKeys, jkeys...)
 	}
 	for _, w := range webKeys {
+		if !w.IsPublic() || !isSupportedKey(w.Key) || (w.Use != "" && w.Use != "sig") {
+			continue
+		}
 		keys = append(keys, w.Key)
 	}
 	return keys, nil
@@ -120,10 +129,25 @@ func (r *JWKSResolver) ResolveKeys(ctx context.Context) ([]interface{}, error) {
 
 // Authorizer authorizes incoming requests.
 type Authorizer struct {
-	logger            *zap.Logger
+	logger   *zap.Logger
+	keyGuard sync.RWMutex
+	// issuers holds the verification parameters of the tokens of each
+	// configured issuer, indexed by their iss claim. The entry with an empty
+	// key, if any, applies to the tokens of any other issuer.
+	issuers     map[string]*issuer
+	revocations *RevocationList
+	// certificateBindings holds the client certificate identities bound to
+	// access token subjects.
+	certificateBindings map[string][]string
+}
+
+// issuer holds the parameters used to verify the tokens of an issuer.
+type issuer struct {
+	resolver          KeyResolver
 	keys              []interface{}
-	keyGuard          sync.RWMutex
 	acceptedAudiences map[string]bool
+	// allowedScopes is nil if the issuer may grant any scope.
+	allowedScopes map[string]bool
 }
 
 // Configuration bundles up creation-time parameters for an Authorizer instance.
@@ -131,26 +155,76 @@ type Configuration struct {
 	KeyResolver       KeyResolver   // Used to initialize and periodically refresh keys.
 	KeyRefreshTimeout time.Duration // Keys are refreshed on this cadence.
 	AcceptedAudiences []string      // AcceptedAudiences enforces the aud keyClaim on the jwt. An empty string allows no aud keyClaim.
+	// Issuers configures the verification of the tokens of specific issuers.
+	// KeyResolver and AcceptedAudiences, if KeyResolver is set, apply to the
+	// tokens of any issuer not listed in Issuers; tokens of other issuers are
+	// rejected otherwise.
+	Issuers []IssuerConfiguration
+	// Revocations, if set, is consulted to reject revoked tokens.
+	Revocations *RevocationList
+	// CertificateBindings binds access token subjects to client certificate
+	// identities (see PeerIdentities): the tokens of a bound subject are only
+	// accepted from requests presenting a verified client certificate with one
+	// of its identities.
+	CertificateBindings map[string][]string
 }
 
 // NewRSAAuthorizer returns an Authorizer instance using values from configuration.
+//
+// Deprecated: Authorizers are not limited to RSA keys; use NewAuthorizer.
 func NewRSAAuthorizer(ctx context.Context, configuration Configuration) (*Authorizer, error) {
+	return NewAuthorizer(ctx, configuration)
+}
+
+// NewAuthorizer returns an Authorizer instance using values from configuration.
+// The algorithm verifying each access token is given by its alg header, and
+// must match the type (and curve) of the key of its issuer.
+func NewAuthorizer(ctx context.Context, configuration Configuration) (*Authorizer, error) {
 	logger := logging.WithValuesFromContext(ctx, logging.Logger)
 
-	keys, err := configuration.KeyResolver.ResolveKeys(ctx)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "Unable to resolve keys")
+	issuers := map[string]*issuer{}
+	if configuration.KeyResolver != nil {
+		issuers[""] = &issuer{
+			resolver:          configuration.KeyResolver,
+			acceptedAudiences: toSet(configuration.AcceptedAudiences),
+		}
+	}
+	for _, ic := range configuration.Issuers {
+		if ic.Issuer == "" {
+			return nil, stacktrace.NewError("Missing issuer in issuer configuration")
+		}
+		if _, exists := issuers[ic.Issuer]; exists {
+			return nil, stacktrace.NewError("Duplicate configuration for issuer %s", ic.Issuer)
+		}
+		if ic.KeyResolver == nil {
+			return nil, stacktrace.NewError("Missing key resolver for issuer %s", ic.Issuer)
+		}
+		iss := &issuer{
+			resolver:          ic.KeyResolver,
+			acceptedAudiences: toSet(ic.AcceptedAudiences),
+		}
+		if len(ic.AllowedScopes) > 0 {
+			iss.allowedScopes = toSet(ic.AllowedScopes)
+		}
+		issuers[ic.Issuer] = iss
+	}
+	if len(issuers) == 0 {
+		return nil, stacktrace.NewError("No key resolver configured")
 	}
 
-	auds := make(map[string]bool)
-	for _, s := range configuration.AcceptedAudiences {
-		auds[s] = true
+	for name, iss := range issuers {
+		keys, err := iss.resolver.ResolveKeys(ctx)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Unable to resolve keys of issuer %q", name)
+		}
+		iss.keys = keys
 	}
 
 	authorizer := &Authorizer{
-		acceptedAudiences: auds,
-		logger:            logger,
-		keys:              keys,
+		logger:              logger,
+		issuers:             issuers,
+		revocations:         configuration.Revocations,
+		certificateBindings: configuration.CertificateBindings,
 	}
 
 	go func() {
@@ -160,12 +234,9 @@ func NewRSAAuthorizer(ctx context.Context, configuration Configuration) (*Author
 		for {
 			select {
 			case <-ticker.C:
-				keys, err := configuration.KeyResolver.ResolveKeys(ctx)
-				if err != nil {
+				if err := authorizer.RefreshKeys(ctx); err != nil {
 					logger.Panic("failed to refresh key", zap.Error(err))
 				}
-
-				authorizer.setKeys(keys)
 			case <-ctx.Done():
 				logger.Warn("finalizing key refresh worker", zap.Error(ctx.Err()))
 				return
@@ -176,22 +247,59 @@ func NewRSAAuthorizer(ctx context.Context, configuration Configuration) (*Author
 	return authorizer, nil
 }
 
-func (a *Authorizer) setKeys(keys []interface{}) {
+// RefreshKeys resolves again the keys of all the issuers, as is done
+// periodically. The keys of an issuer are left unchanged if they cannot be
+// resolved.
+func (a *Authorizer) RefreshKeys(ctx context.Context) error {
+	for name, iss := range a.issuers {
+		keys, err := iss.resolver.ResolveKeys(ctx)
+		if err != nil {
+			return stacktrace.Propagate(err, "Unable to resolve keys of issuer %q", name)
+		}
+		a.setKeys(iss, keys)
+	}
+	return nil
+}
+
+func (a *Authorizer) setKeys(iss *issuer, keys []interface{}) {
 	a.keyGuard.Lock()
-	a.keys = keys
+	iss.keys = keys
 	a.keyGuard.Unlock()
 }
 
+// issuerOf returns the verification parameters applying to the tokens issued
+// by name, or nil if its tokens are not accepted.
+func (a *Authorizer) issuerOf(name string) *issuer {
+	if iss, ok := a.issuers[name]; ok {
+		return iss
+	}
+	return a.issuers[""]
+}
+
 // Authorize extracts and verifies bearer tokens from a http.Request.
 func (a *Authorizer) Authorize(_ http.ResponseWriter, r *http.Request, authOptions []api.AuthorizationOption) api.AuthorizationResult {
 
 	tknStr, ok := getToken(r)
 	if !ok {
+		metrics.AuthorizationFailed("missing_token")
 		return api.AuthorizationResult{Error: stacktrace.NewErrorWithCode(dsserr.Unauthenticated, "Missing access token")}
 	}
 
+	// The issuer of the token is needed to select the keys verifying it, so it
+	// is first read without verification.
+	var unverified claims
+	if _, _, err := jwt.NewParser().ParseUnverified(tknStr, &unverified); err != nil {
+		metrics.AuthorizationFailed("invalid_token")
+		return api.AuthorizationResult{Error: stacktrace.PropagateWithCode(err, dsserr.Unauthenticated, "Access token validation failed")}
+	}
+	iss := a.issuerOf(unverified.Issuer)
+	if iss == nil {
+		metrics.AuthorizationFailed("unknown_issuer")
+		return api.AuthorizationResult{Error: stacktrace.NewErrorWithCode(dsserr.Unauthenticated, "Access token issuer not accepted: %s", unverified.Issuer)}
+	}
+
 	a.keyGuard.RLock()
-	keys := a.keys
+	keys := iss.keys
 	a.keyGuard.RUnlock()
 	validated := false
 	var err error
@@ -201,6 +309,9 @@ func (a *Authorizer) Authorize(_ http.ResponseWriter, r *http.Request, authOptio
 		keyClaims = claims{}
 		key := key
 		_, err = jwt.ParseWithClaims(tknStr, &keyClaims, func(token *jwt.Token) (interface{}, error) {
+			if !verifies(token.Method, key) {
+				return nil, stacktrace.NewError("Key of type %T cannot verify %s signatures", key, token.Method.Alg())
+			}
 			return key, nil
 		})
 		if err == nil {
@@ -209,14 +320,47 @@ func (a *Authorizer) Authorize(_ http.ResponseWriter, r *http.Request, authOptio
 		}
 	}
 	if !validated {
+		metrics.AuthorizationFailed("invalid_token")
 		return api.AuthorizationResult{Error: stacktrace.PropagateWithCode(err, dsserr.Unauthenticated, "Access token validation failed")}
 	}
 
-	if !a.acceptedAudiences[keyClaims.Audience] {
+	if a.revocations != nil {
+		var issuedAt time.Time
+		if keyClaims.IssuedAt != 0 {
+			issuedAt = time.Unix(keyClaims.IssuedAt, 0)
+		}
+		revoked, err := a.revocations.IsTokenRevoked(r.Context(), keyClaims.Issuer, keyClaims.Id, keyClaims.Subject, issuedAt)
+		if err != nil {
+			metrics.AuthorizationFailed("revocations_unavailable")
+			return api.AuthorizationResult{Error: stacktrace.Propagate(err, "Error checking access token revocation")}
+		}
+		if revoked {
+			metrics.AuthorizationFailed("revoked_token")
+			return api.AuthorizationResult{Error: stacktrace.NewErrorWithCode(dsserr.Unauthenticated, "Access token has been revoked")}
+		}
+	}
+
+	if identities, bound := a.certificateBindings[keyClaims.Subject]; bound && !presentsBoundCertificate(r, identities) {
+		metrics.AuthorizationFailed("certificate_mismatch")
+		return api.AuthorizationResult{Error: stacktrace.NewErrorWithCode(dsserr.Unauthenticated, "Access token subject %s must be presented with a client certificate bound to it", keyClaims.Subject)}
+	}
+
+	if !iss.acceptedAudiences[keyClaims.Audience] {
+		metrics.AuthorizationFailed("invalid_audience")
 		return api.AuthorizationResult{Error: stacktrace.NewErrorWithCode(dsserr.Unauthenticated, "Invalid access token audience: %v", keyClaims.Audience)}
 	}
 
+	if iss.allowedScopes != nil {
+		// Scopes the issuer is not allowed to grant are ignored.
+		for scope := range keyClaims.Scopes {
+			if !iss.allowedScopes[scope] {
+				delete(keyClaims.Scopes, scope)
+			}
+		}
+	}
+
 	if pass, missing := validateScopes(authOptions, keyClaims.Scopes); !pass {
+		metrics.AuthorizationFailed("missing_scopes")
 		return api.AuthorizationResult{Error: stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
 			"Access token missing scopes (%v) while expecting %v and got %v",
 			missing, describeAuthorizationExpectations(authOptions), strings.Join(keyClaims.Scopes.ToStringSlice(), ", "))}
@@ -293,6 +437,46 @@ func validateScopes(authOptions []api.AuthorizationOption, clientScopes ScopeSet
 	return false, strings.Join(validationFailures, " ; ")
 }
 
+// isSupportedKey returns whether key is a type of public key verifying access
+// tokens.
+func isSupportedKey(key interface{}) bool {
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return true
+	default:
+		return false
+	}
+}
+
+// verifies returns whether key may verify the signatures of method, so that the
+// algorithm in the header of a token cannot select an unintended verification
+// (e.g. with a different curve than the key's).
+func verifies(method jwt.SigningMethod, key interface{}) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		switch method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+			return true
+		}
+	case *ecdsa.PublicKey:
+		if m, ok := method.(*jwt.SigningMethodECDSA); ok {
+			return m.CurveBits == k.Curve.Params().BitSize
+		}
+	case ed25519.PublicKey:
+		_, ok := method.(*jwt.SigningMethodEd25519)
+		return ok
+	}
+	return false
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
 func getToken(r *http.Request) (string, bool) {
 	authHeader := r.Header.Get("authorization")
 	if len(authHeader) < 7 || strings.ToLower(authHeader[0:6]) != "bearer" {
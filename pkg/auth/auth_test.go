@@ -2,10 +2,22 @@ package auth
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -13,8 +25,11 @@ import (
 	"github.com/interuss/dss/pkg/api"
 	"github.com/interuss/dss/pkg/api/scdv1"
 	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
 	"github.com/interuss/stacktrace"
+	"github.com/jonboulle/clockwork"
 
+	"github.com/go-jose/go-jose/v4"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/require"
 )
@@ -233,3 +248,436 @@ func TestHasScope(t *testing.T) {
 	require.True(t, HasScope(scopes, scdv1.UtmConformanceMonitoringSaScope))
 	require.False(t, HasScope(scopes, scdv1.UtmAvailabilityArbitrationScope))
 }
+
+func issuerTokenReq(t *testing.T, key *rsa.PrivateKey, iss, aud, scope string) *http.Request {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"exp":   Now().Add(time.Minute).Unix(),
+		"sub":   "real_owner",
+		"iss":   iss,
+		"aud":   aud,
+		"scope": scope,
+	})
+	tokenString, err := token.SignedString(key)
+	require.NoError(t, err)
+	req := &http.Request{Header: make(http.Header)}
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	return req
+}
+
+func TestMultipleIssuers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key1, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+	key2, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	a, err := NewRSAAuthorizer(ctx, Configuration{
+		KeyRefreshTimeout: time.Minute,
+		Issuers: []IssuerConfiguration{
+			{
+				Issuer:            "https://auth1",
+				KeyResolver:       &fromMemoryKeyResolver{Keys: []interface{}{&key1.PublicKey}},
+				AcceptedAudiences: []string{"dss1"},
+			},
+			{
+				Issuer:            "https://auth2",
+				KeyResolver:       &fromMemoryKeyResolver{Keys: []interface{}{&key2.PublicKey}},
+				AcceptedAudiences: []string{"dss2"},
+				AllowedScopes:     []string{string(scdv1.UtmStrategicCoordinationScope)},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	coordination := []api.AuthorizationOption{{"Auth": {scdv1.UtmStrategicCoordinationScope}}}
+	arbitration := []api.AuthorizationOption{{"Auth": {scdv1.UtmAvailabilityArbitrationScope}}}
+	scopes := string(scdv1.UtmStrategicCoordinationScope) + " " + string(scdv1.UtmAvailabilityArbitrationScope)
+
+	var tests = []struct {
+		name        string
+		req         *http.Request
+		authOptions []api.AuthorizationOption
+		code        stacktrace.ErrorCode
+	}{
+		{"issuer 1", issuerTokenReq(t, key1, "https://auth1", "dss1", scopes), arbitration, stacktrace.NoCode},
+		{"issuer 2", issuerTokenReq(t, key2, "https://auth2", "dss2", scopes), coordination, stacktrace.NoCode},
+		{"key of other issuer", issuerTokenReq(t, key2, "https://auth1", "dss1", scopes), coordination, dsserr.Unauthenticated},
+		{"audience of other issuer", issuerTokenReq(t, key1, "https://auth1", "dss2", scopes), coordination, dsserr.Unauthenticated},
+		{"unknown issuer", issuerTokenReq(t, key1, "https://auth3", "dss1", scopes), coordination, dsserr.Unauthenticated},
+		{"scope not allowed", issuerTokenReq(t, key2, "https://auth2", "dss2", scopes), arbitration, dsserr.PermissionDenied},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := a.Authorize(nil, test.req, test.authOptions)
+			require.Equal(t, test.code, stacktrace.GetCode(res.Error), "%v", res.Error)
+		})
+	}
+}
+
+func TestLoadIssuersFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issuers.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"issuers": [
+			{
+				"issuer": "https://auth1",
+				"public_key_files": ["auth1.pem"],
+				"accepted_audiences": ["dss"]
+			},
+			{
+				"issuer": "https://auth2",
+				"jwks_endpoint": "https://auth2/.well-known/jwks.json",
+				"jwks_key_ids": ["key1"],
+				"accepted_audiences": ["dss"],
+				"allowed_scopes": ["utm.strategic_coordination"]
+			}
+		]
+	}`), 0600))
+
+	issuers, err := LoadIssuersFile(path)
+	require.NoError(t, err)
+	require.Len(t, issuers, 2)
+	require.Equal(t, "https://auth1", issuers[0].Issuer)
+	require.Equal(t, &FromFileKeyResolver{KeyFiles: []string{"auth1.pem"}}, issuers[0].KeyResolver)
+	require.Equal(t, []string{"dss"}, issuers[0].AcceptedAudiences)
+	require.Empty(t, issuers[0].AllowedScopes)
+	jwks, ok := issuers[1].KeyResolver.(*JWKSResolver)
+	require.True(t, ok)
+	require.Equal(t, "https://auth2/.well-known/jwks.json", jwks.Endpoint.String())
+	require.Equal(t, []string{"key1"}, jwks.KeyIDs)
+	require.Equal(t, []string{"utm.strategic_coordination"}, issuers[1].AllowedScopes)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"issuers": [{"issuer": "https://auth1"}]}`), 0600))
+	_, err = LoadIssuersFile(path)
+	require.Error(t, err)
+}
+
+// revocationStore is a RevocationStore failing with err if set.
+type revocationStore struct {
+	revocations []*Revocation
+	err         error
+}
+
+func (s *revocationStore) IsTokenRevoked(ctx context.Context, issuer, jti, subject string, issuedAt time.Time) (bool, error) {
+	return false, errors.New("not implemented")
+}
+
+func (s *revocationStore) InsertRevocation(ctx context.Context, r *Revocation) (*Revocation, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.revocations = append(s.revocations, r)
+	return r, nil
+}
+
+func (s *revocationStore) ListRevocations(ctx context.Context) ([]*Revocation, error) {
+	return s.revocations, s.err
+}
+
+func (s *revocationStore) DeleteRevocation(ctx context.Context, id dssmodels.ID) (*Revocation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestRevokedTokens(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	revokedAt := Now().Add(-time.Minute)
+	revocations, err := NewRevocationList(ctx, &revocationStore{revocations: []*Revocation{
+		{JTI: "leaked", RevokedAt: revokedAt},
+		{Subject: "uss1", RevokedAt: revokedAt},
+		{Issuer: "https://auth1", Subject: "uss2", RevokedAt: revokedAt},
+	}}, time.Minute, 0)
+	require.NoError(t, err)
+	a, err := NewRSAAuthorizer(ctx, Configuration{
+		KeyResolver:       &fromMemoryKeyResolver{Keys: []interface{}{&key.PublicKey}},
+		KeyRefreshTimeout: time.Minute,
+		AcceptedAudiences: []string{"dss"},
+		Revocations:       revocations,
+	})
+	require.NoError(t, err)
+
+	tokenReq := func(iss, sub, jti string, iat time.Time) *http.Request {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"exp": Now().Add(time.Minute).Unix(),
+			"iat": iat.Unix(),
+			"sub": sub,
+			"iss": iss,
+			"aud": "dss",
+			"jti": jti,
+		})
+		tokenString, err := token.SignedString(key)
+		require.NoError(t, err)
+		req := &http.Request{Header: make(http.Header)}
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		return req
+	}
+
+	before := revokedAt.Add(-time.Second)
+	after := revokedAt.Add(time.Second)
+	var tests = []struct {
+		name string
+		req  *http.Request
+		code stacktrace.ErrorCode
+	}{
+		{"revoked jti", tokenReq("https://auth1", "uss3", "leaked", after), dsserr.Unauthenticated},
+		{"other jti", tokenReq("https://auth1", "uss3", "other", after), stacktrace.NoCode},
+		{"subject issued before revocation", tokenReq("https://auth1", "uss1", "", before), dsserr.Unauthenticated},
+		{"subject issued after revocation", tokenReq("https://auth1", "uss1", "", after), stacktrace.NoCode},
+		{"subject of revoked issuer", tokenReq("https://auth1", "uss2", "", before), dsserr.Unauthenticated},
+		{"subject of other issuer", tokenReq("https://auth2", "uss2", "", before), stacktrace.NoCode},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := a.Authorize(nil, test.req, nil)
+			require.Equal(t, test.code, stacktrace.GetCode(res.Error), "%v", res.Error)
+		})
+	}
+}
+
+func TestRevocationList(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := &revocationStore{}
+	l, err := NewRevocationList(ctx, store, time.Hour, time.Minute)
+	require.NoError(t, err)
+	clock := clockwork.NewFakeClock()
+	l.clock = clock
+	require.NoError(t, l.Refresh(ctx))
+
+	revoked, err := l.IsTokenRevoked(ctx, "https://auth1", "leaked", "uss1", time.Time{})
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	// Changes made through the list apply immediately.
+	_, err = l.InsertRevocation(ctx, &Revocation{JTI: "leaked"})
+	require.NoError(t, err)
+	revoked, err = l.IsTokenRevoked(ctx, "https://auth1", "leaked", "uss1", time.Time{})
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// The last loaded revocations are enforced while the store is unavailable,
+	// until they get too stale.
+	store.err = errors.New("store unavailable")
+	require.Error(t, l.Refresh(ctx))
+	clock.Advance(30 * time.Second)
+	revoked, err = l.IsTokenRevoked(ctx, "https://auth1", "leaked", "uss1", time.Time{})
+	require.NoError(t, err)
+	require.True(t, revoked)
+	clock.Advance(time.Minute)
+	_, err = l.IsTokenRevoked(ctx, "https://auth1", "other", "uss1", time.Time{})
+	require.Equal(t, dsserr.Unavailable, stacktrace.GetCode(err))
+
+	store.err = nil
+	require.NoError(t, l.Refresh(ctx))
+	revoked, err = l.IsTokenRevoked(ctx, "https://auth1", "other", "uss1", time.Time{})
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	// A store unavailable at startup fails the creation of the list.
+	store.err = errors.New("store unavailable")
+	_, err = NewRevocationList(ctx, store, time.Hour, time.Minute)
+	require.Error(t, err)
+}
+
+func TestCertificateBindings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	a, err := NewAuthorizer(ctx, Configuration{
+		KeyResolver:       &fromMemoryKeyResolver{Keys: []interface{}{&key.PublicKey}},
+		KeyRefreshTimeout: time.Minute,
+		AcceptedAudiences: []string{"dss"},
+		CertificateBindings: map[string][]string{
+			"uss1": {"uss1.example.com", "spiffe://example.com/uss1"},
+		},
+	})
+	require.NoError(t, err)
+
+	tokenReq := func(sub string, cert *x509.Certificate) *http.Request {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"exp": Now().Add(time.Minute).Unix(),
+			"sub": sub,
+			"iss": "baz",
+			"aud": "dss",
+		})
+		tokenString, err := token.SignedString(key)
+		require.NoError(t, err)
+		req := &http.Request{Header: make(http.Header)}
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		if cert != nil {
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		}
+		return req
+	}
+	spiffe, err := url.Parse("spiffe://example.com/uss1")
+	require.NoError(t, err)
+
+	var tests = []struct {
+		name string
+		req  *http.Request
+		code stacktrace.ErrorCode
+	}{
+		{"bound subject without certificate", tokenReq("uss1", nil), dsserr.Unauthenticated},
+		{"bound subject with DNS name", tokenReq("uss1", &x509.Certificate{DNSNames: []string{"uss1.example.com"}}), stacktrace.NoCode},
+		{"bound subject with URI", tokenReq("uss1", &x509.Certificate{URIs: []*url.URL{spiffe}}), stacktrace.NoCode},
+		{"bound subject with other certificate", tokenReq("uss1", &x509.Certificate{Subject: pkix.Name{CommonName: "uss2.example.com"}}), dsserr.Unauthenticated},
+		{"bound subject with common name only", tokenReq("uss1", &x509.Certificate{Subject: pkix.Name{CommonName: "uss1.example.com"}}), dsserr.Unauthenticated},
+		{"bound subject with email address", tokenReq("uss1", &x509.Certificate{EmailAddresses: []string{"uss1.example.com"}}), dsserr.Unauthenticated},
+		{"unbound subject without certificate", tokenReq("uss2", nil), stacktrace.NoCode},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := a.Authorize(nil, test.req, nil)
+			require.Equal(t, test.code, stacktrace.GetCode(res.Error), "%v", res.Error)
+		})
+	}
+}
+
+func TestLoadCertificateBindingsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bindings.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"bindings": [
+			{"subject": "uss1", "identities": ["uss1.example.com"]},
+			{"subject": "uss2", "identities": ["uss2.example.com", "spiffe://example.com/uss2"]}
+		]
+	}`), 0600))
+
+	bindings, err := LoadCertificateBindingsFile(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"uss1": {"uss1.example.com"},
+		"uss2": {"uss2.example.com", "spiffe://example.com/uss2"},
+	}, bindings)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"bindings": [{"subject": "uss1"}]}`), 0600))
+	_, err = LoadCertificateBindingsFile(path)
+	require.Error(t, err)
+}
+
+func TestRefreshKeys(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+	rotated, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	resolver := &fromMemoryKeyResolver{Keys: []interface{}{&key.PublicKey}}
+	a, err := NewAuthorizer(ctx, Configuration{
+		KeyResolver:       resolver,
+		KeyRefreshTimeout: time.Hour,
+		AcceptedAudiences: []string{""},
+	})
+	require.NoError(t, err)
+
+	req := rsaTokenReq(rotated, Now().Add(time.Minute).Unix(), 0)
+	require.Error(t, a.Authorize(nil, req, nil).Error)
+
+	resolver.Keys = []interface{}{&rotated.PublicKey}
+	require.NoError(t, a.RefreshKeys(ctx))
+	require.NoError(t, a.Authorize(nil, req, nil).Error)
+}
+
+func TestKeyTypes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherECKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	// Keys are resolved from PEM files.
+	dir := t.TempDir()
+	var files []string
+	for i, key := range []interface{}{&rsaKey.PublicKey, &ecKey.PublicKey, edPublicKey} {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		require.NoError(t, err)
+		path := filepath.Join(dir, strconv.Itoa(i)+".pem")
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+		files = append(files, path)
+	}
+
+	a, err := NewAuthorizer(ctx, Configuration{
+		KeyResolver:       &FromFileKeyResolver{KeyFiles: files},
+		KeyRefreshTimeout: time.Minute,
+		AcceptedAudiences: []string{""},
+	})
+	require.NoError(t, err)
+
+	tokenReq := func(method jwt.SigningMethod, key interface{}) *http.Request {
+		token := jwt.NewWithClaims(method, jwt.MapClaims{
+			"exp": Now().Add(time.Minute).Unix(),
+			"sub": "real_owner",
+			"iss": "baz",
+		})
+		tokenString, err := token.SignedString(key)
+		require.NoError(t, err)
+		req := &http.Request{Header: make(http.Header)}
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		return req
+	}
+
+	var tests = []struct {
+		name string
+		req  *http.Request
+		code stacktrace.ErrorCode
+	}{
+		{"RS256", tokenReq(jwt.SigningMethodRS256, rsaKey), stacktrace.NoCode},
+		{"PS256", tokenReq(jwt.SigningMethodPS256, rsaKey), stacktrace.NoCode},
+		{"ES256", tokenReq(jwt.SigningMethodES256, ecKey), stacktrace.NoCode},
+		{"EdDSA", tokenReq(jwt.SigningMethodEdDSA, edKey), stacktrace.NoCode},
+		{"unknown key", tokenReq(jwt.SigningMethodES384, otherECKey), dsserr.Unauthenticated},
+		{"HS256 with public key", tokenReq(jwt.SigningMethodHS256, x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)), dsserr.Unauthenticated},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := a.Authorize(nil, test.req, nil)
+			require.Equal(t, test.code, stacktrace.GetCode(res.Error), "%v", res.Error)
+		})
+	}
+
+	require.False(t, verifies(jwt.SigningMethodES384, &ecKey.PublicKey))
+	require.False(t, verifies(jwt.SigningMethodRS256, &ecKey.PublicKey))
+	require.False(t, verifies(jwt.SigningMethodEdDSA, &rsaKey.PublicKey))
+}
+
+func TestJWKSResolverKeyTypes(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &ecKey.PublicKey, KeyID: "ec", Algorithm: "ES256", Use: "sig"},
+		{Key: edPublicKey, KeyID: "okp", Algorithm: "EdDSA"},
+		{Key: []byte("secret"), KeyID: "oct", Algorithm: "HS256"},
+		{Key: &ecKey.PublicKey, KeyID: "enc", Algorithm: "ECDH-ES", Use: "enc"},
+	}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(jwks))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	keys, err := (&JWKSResolver{Endpoint: u}).ResolveKeys(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.IsType(t, &ecdsa.PublicKey{}, keys[0])
+	require.IsType(t, ed25519.PublicKey{}, keys[1])
+}
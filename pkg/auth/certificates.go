@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/interuss/stacktrace"
+)
+
+// PeerIdentities returns the identities of the client certificate verified
+// during the TLS handshake of r: its DNS names and URIs. Its common name and
+// other subject alternative names are ignored, since certificate authorities
+// do not validate them as identities of the client. It returns nil if r was
+// not received over TLS or without a verified client certificate.
+func PeerIdentities(r *http.Request) []string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	cert := r.TLS.VerifiedChains[0][0]
+
+	identities := append([]string{}, cert.DNSNames...)
+	for _, u := range cert.URIs {
+		identities = append(identities, u.String())
+	}
+	return identities
+}
+
+// certificateBindingsFile is the format of the file read by
+// LoadCertificateBindingsFile.
+type certificateBindingsFile struct {
+	Bindings []struct {
+		Subject    string   `json:"subject"`
+		Identities []string `json:"identities"`
+	} `json:"bindings"`
+}
+
+// LoadCertificateBindingsFile reads from the JSON file at path the client
+// certificate identities bound to access token subjects, for example:
+//
+//	{
+//	  "bindings": [
+//	    {
+//	      "subject": "uss1",
+//	      "identities": ["uss1.example.com", "spiffe://example.com/uss1"]
+//	    }
+//	  ]
+//	}
+func LoadCertificateBindingsFile(path string) (map[string][]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error reading certificate bindings file")
+	}
+
+	var file certificateBindingsFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, stacktrace.Propagate(err, "Error parsing certificate bindings file %s", path)
+	}
+
+	bindings := make(map[string][]string, len(file.Bindings))
+	for i, entry := range file.Bindings {
+		if entry.Subject == "" {
+			return nil, stacktrace.NewError("Missing subject in entry %d of %s", i, path)
+		}
+		if len(entry.Identities) == 0 {
+			return nil, stacktrace.NewError("Missing identities for subject %s in %s", entry.Subject, path)
+		}
+		if _, exists := bindings[entry.Subject]; exists {
+			return nil, stacktrace.NewError("Duplicate bindings for subject %s in %s", entry.Subject, path)
+		}
+		bindings[entry.Subject] = entry.Identities
+	}
+	return bindings, nil
+}
+
+// presentsBoundCertificate returns whether r presents a verified client
+// certificate with one of identities.
+func presentsBoundCertificate(r *http.Request, identities []string) bool {
+	for _, peer := range PeerIdentities(r) {
+		for _, identity := range identities {
+			if peer == identity {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+// Package cockroach implements the revocation store of the authorizer on a
+// CockroachDB database.
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/interuss/dss/pkg/auth"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	dssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+)
+
+const revocationFields = "id, COALESCE(issuer, ''), COALESCE(jti, ''), COALESCE(subject, ''), reason, revoked_by, revoked_at"
+
+// RevocationStore is an implementation of auth.RevocationStore on the
+// token_revocations table of the database q is connected to.
+type RevocationStore struct {
+	q dssql.Queryable
+}
+
+// NewRevocationStore returns a RevocationStore querying q.
+func NewRevocationStore(q dssql.Queryable) *RevocationStore {
+	return &RevocationStore{q: q}
+}
+
+// IsTokenRevoked implements auth.RevocationStore.
+func (s *RevocationStore) IsTokenRevoked(ctx context.Context, issuer, jti, subject string, issuedAt time.Time) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1 FROM token_revocations
+			WHERE
+				(issuer IS NULL OR issuer = $1)
+			AND
+				(jti = $2 OR (subject = $3 AND revoked_at >= $4)))`
+
+	var revoked bool
+	if err := s.q.QueryRow(ctx, query, issuer, jti, subject, issuedAt).Scan(&revoked); err != nil {
+		return false, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return revoked, nil
+}
+
+// InsertRevocation implements auth.RevocationStore.
+func (s *RevocationStore) InsertRevocation(ctx context.Context, r *auth.Revocation) (*auth.Revocation, error) {
+	var query = fmt.Sprintf(`
+		INSERT INTO
+			token_revocations
+			(id, issuer, jti, subject, reason, revoked_by, revoked_at)
+		VALUES
+			($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), $5, $6, transaction_timestamp())
+		RETURNING
+			%s`, revocationFields)
+
+	id, err := r.ID.PgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	result, err := s.fetchRevocations(ctx, query, id, r.Issuer, r.JTI, r.Subject, r.Reason, r.RevokedBy)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if len(result) != 1 {
+		return nil, stacktrace.NewError("Inserting revocation returned %d rows", len(result))
+	}
+	return result[0], nil
+}
+
+// ListRevocations implements auth.RevocationStore.
+func (s *RevocationStore) ListRevocations(ctx context.Context) ([]*auth.Revocation, error) {
+	var query = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			token_revocations
+		ORDER BY
+			revoked_at DESC`, revocationFields)
+
+	return s.fetchRevocations(ctx, query)
+}
+
+// DeleteRevocation implements auth.RevocationStore.
+func (s *RevocationStore) DeleteRevocation(ctx context.Context, id dssmodels.ID) (*auth.Revocation, error) {
+	var query = fmt.Sprintf(`
+		DELETE FROM
+			token_revocations
+		WHERE
+			id = $1
+		RETURNING
+			%s`, revocationFields)
+
+	uid, err := id.PgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	result, err := s.fetchRevocations(ctx, query, uid)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result[0], nil
+}
+
+func (s *RevocationStore) fetchRevocations(ctx context.Context, query string, args ...interface{}) ([]*auth.Revocation, error) {
+	rows, err := s.q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	var payload []*auth.Revocation
+	for rows.Next() {
+		r := new(auth.Revocation)
+		err := rows.Scan(
+			&r.ID,
+			&r.Issuer,
+			&r.JTI,
+			&r.Subject,
+			&r.Reason,
+			&r.RevokedBy,
+			&r.RevokedAt,
+		)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning Revocation row")
+		}
+		payload = append(payload, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return payload, nil
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+
+	"github.com/interuss/stacktrace"
+)
+
+// IssuerConfiguration bundles up the parameters used to verify the access
+// tokens of a single issuer.
+type IssuerConfiguration struct {
+	// Issuer is the value of the iss claim of the tokens of the issuer.
+	Issuer string
+	// KeyResolver resolves the keys signing the tokens of the issuer.
+	KeyResolver KeyResolver
+	// AcceptedAudiences enforces the aud claim of the tokens of the issuer. An
+	// empty string allows no aud claim.
+	AcceptedAudiences []string
+	// AllowedScopes are the scopes the issuer may grant; other scopes of its
+	// tokens are ignored. All scopes are allowed if empty.
+	AllowedScopes []string
+}
+
+// issuersFile is the format of the file read by LoadIssuersFile.
+type issuersFile struct {
+	Issuers []struct {
+		Issuer            string   `json:"issuer"`
+		PublicKeyFiles    []string `json:"public_key_files"`
+		JWKSEndpoint      string   `json:"jwks_endpoint"`
+		JWKSKeyIDs        []string `json:"jwks_key_ids"`
+		AcceptedAudiences []string `json:"accepted_audiences"`
+		AllowedScopes     []string `json:"allowed_scopes"`
+	} `json:"issuers"`
+}
+
+// LoadIssuersFile reads the configuration of the accepted token issuers from
+// the JSON file at path. Its keys are resolved either from public_key_files or
+// from jwks_endpoint, for example:
+//
+//	{
+//	  "issuers": [
+//	    {
+//	      "issuer": "https://auth.example.com",
+//	      "jwks_endpoint": "https://auth.example.com/.well-known/jwks.json",
+//	      "accepted_audiences": ["dss.example.com"],
+//	      "allowed_scopes": ["utm.strategic_coordination"]
+//	    }
+//	  ]
+//	}
+func LoadIssuersFile(path string) ([]IssuerConfiguration, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error reading issuers file")
+	}
+
+	var file issuersFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, stacktrace.Propagate(err, "Error parsing issuers file %s", path)
+	}
+
+	result := make([]IssuerConfiguration, 0, len(file.Issuers))
+	for i, entry := range file.Issuers {
+		if entry.Issuer == "" {
+			return nil, stacktrace.NewError("Missing issuer in entry %d of %s", i, path)
+		}
+		ic := IssuerConfiguration{
+			Issuer:            entry.Issuer,
+			AcceptedAudiences: entry.AcceptedAudiences,
+			AllowedScopes:     entry.AllowedScopes,
+		}
+		switch {
+		case len(entry.PublicKeyFiles) > 0 && entry.JWKSEndpoint != "":
+			return nil, stacktrace.NewError("Both public_key_files and jwks_endpoint are set for issuer %s", entry.Issuer)
+		case len(entry.PublicKeyFiles) > 0:
+			ic.KeyResolver = &FromFileKeyResolver{KeyFiles: entry.PublicKeyFiles}
+		case entry.JWKSEndpoint != "":
+			u, err := url.Parse(entry.JWKSEndpoint)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Error parsing JWKS URL of issuer %s", entry.Issuer)
+			}
+			ic.KeyResolver = &JWKSResolver{Endpoint: u, KeyIDs: entry.JWKSKeyIDs}
+		default:
+			return nil, stacktrace.NewError("Neither public_key_files nor jwks_endpoint is set for issuer %s", entry.Issuer)
+		}
+		result = append(result, ic)
+	}
+	return result, nil
+}
@@ -0,0 +1,79 @@
+// Package memory implements the revocation store of the authorizer in process
+// memory.
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/interuss/dss/pkg/auth"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/jonboulle/clockwork"
+)
+
+// RevocationStore is an implementation of auth.RevocationStore keeping its
+// data in process memory.
+type RevocationStore struct {
+	clock clockwork.Clock
+
+	mu          sync.RWMutex
+	revocations map[dssmodels.ID]*auth.Revocation
+}
+
+// NewRevocationStore returns an empty RevocationStore.
+func NewRevocationStore() *RevocationStore {
+	return &RevocationStore{
+		clock:       clockwork.NewRealClock(),
+		revocations: map[dssmodels.ID]*auth.Revocation{},
+	}
+}
+
+// IsTokenRevoked implements auth.RevocationStore.
+func (s *RevocationStore) IsTokenRevoked(ctx context.Context, issuer, jti, subject string, issuedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, r := range s.revocations {
+		if r.Matches(issuer, jti, subject, issuedAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// InsertRevocation implements auth.RevocationStore.
+func (s *RevocationStore) InsertRevocation(ctx context.Context, r *auth.Revocation) (*auth.Revocation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *r
+	stored.RevokedAt = s.clock.Now()
+	s.revocations[stored.ID] = &stored
+	result := stored
+	return &result, nil
+}
+
+// ListRevocations implements auth.RevocationStore.
+func (s *RevocationStore) ListRevocations(ctx context.Context) ([]*auth.Revocation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]*auth.Revocation, 0, len(s.revocations))
+	for _, r := range s.revocations {
+		c := *r
+		result = append(result, &c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].RevokedAt.After(result[j].RevokedAt) })
+	return result, nil
+}
+
+// DeleteRevocation implements auth.RevocationStore.
+func (s *RevocationStore) DeleteRevocation(ctx context.Context, id dssmodels.ID) (*auth.Revocation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.revocations[id]
+	if !ok {
+		return nil, nil
+	}
+	delete(s.revocations, id)
+	return r, nil
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/logging"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/stacktrace"
+	"github.com/jonboulle/clockwork"
+	"go.uber.org/zap"
+)
+
+// Revocation denylists either the access token with a given jti claim, or all
+// the access tokens issued to a given subject (client ID) until the time of
+// the revocation. Exactly one of JTI and Subject is set.
+type Revocation struct {
+	ID dssmodels.ID
+	// Issuer restricts the revocation to the tokens of this issuer. An empty
+	// Issuer applies to the tokens of every issuer.
+	Issuer  string
+	JTI     string
+	Subject string
+	// Reason is a free-form explanation of the revocation.
+	Reason string
+	// RevokedBy is the client ID of the administrator creating the revocation.
+	RevokedBy string
+	RevokedAt time.Time
+}
+
+// Matches returns whether r revokes the token with the given claims.
+func (r *Revocation) Matches(issuer, jti, subject string, issuedAt time.Time) bool {
+	if r.Issuer != "" && r.Issuer != issuer {
+		return false
+	}
+	if r.JTI != "" {
+		return r.JTI == jti
+	}
+	return r.Subject == subject && !issuedAt.After(r.RevokedAt)
+}
+
+// RevocationStore persists the Revocations consulted by the Authorizer.
+type RevocationStore interface {
+	// IsTokenRevoked returns whether a Revocation matches the token with the
+	// given claims. issuedAt is the zero time if the token has no iat claim.
+	IsTokenRevoked(ctx context.Context, issuer, jti, subject string, issuedAt time.Time) (bool, error)
+
+	// InsertRevocation inserts r, revoked at the current time, and returns it.
+	InsertRevocation(ctx context.Context, r *Revocation) (*Revocation, error)
+
+	// ListRevocations returns all the Revocations, most recent first.
+	ListRevocations(ctx context.Context) ([]*Revocation, error)
+
+	// DeleteRevocation deletes and returns the Revocation identified by id, or
+	// returns nil if it does not exist.
+	DeleteRevocation(ctx context.Context, id dssmodels.ID) (*Revocation, error)
+}
+
+// RevocationList serves the Revocations of a RevocationStore from memory, so
+// that checking a token does not query the store. The list is reloaded from
+// the store periodically and after each change made through it; the changes
+// made through other instances sharing the store apply from their next reload.
+//
+// When the store cannot be reached, the last loaded Revocations keep being
+// enforced. Once they are older than the configured maximum staleness, tokens
+// are rejected instead with a dsserr.Unavailable error, since revocations made
+// in the meantime may be missed.
+type RevocationList struct {
+	store        RevocationStore
+	maxStaleness time.Duration
+	clock        clockwork.Clock
+	logger       *zap.Logger
+
+	mu          sync.RWMutex
+	revocations []*Revocation
+	loadedAt    time.Time
+}
+
+// NewRevocationList returns a RevocationList of the Revocations of store,
+// loaded initially and then every refreshInterval until ctx is done. Tokens are
+// rejected when the last successful load is older than maxStaleness, unless
+// maxStaleness is 0.
+func NewRevocationList(ctx context.Context, store RevocationStore, refreshInterval, maxStaleness time.Duration) (*RevocationList, error) {
+	l := &RevocationList{
+		store:        store,
+		maxStaleness: maxStaleness,
+		clock:        clockwork.NewRealClock(),
+		logger:       logging.WithValuesFromContext(ctx, logging.Logger),
+	}
+	if err := l.Refresh(ctx); err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := l.Refresh(ctx); err != nil {
+					l.logger.Warn("failed to refresh access token revocations", zap.Error(err))
+				}
+			case <-ctx.Done():
+				l.logger.Warn("finalizing access token revocation refresh worker", zap.Error(ctx.Err()))
+				return
+			}
+		}
+	}()
+
+	return l, nil
+}
+
+// Refresh reloads the Revocations from the store.
+func (l *RevocationList) Refresh(ctx context.Context) error {
+	revocations, err := l.store.ListRevocations(ctx)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error loading access token revocations")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.revocations = revocations
+	l.loadedAt = l.clock.Now()
+	return nil
+}
+
+// IsTokenRevoked implements RevocationStore from the Revocations in memory.
+func (l *RevocationList) IsTokenRevoked(ctx context.Context, issuer, jti, subject string, issuedAt time.Time) (bool, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.maxStaleness > 0 {
+		if age := l.clock.Since(l.loadedAt); age > l.maxStaleness {
+			return false, stacktrace.NewErrorWithCode(dsserr.Unavailable, "Access token revocations were last loaded %s ago", age.Round(time.Second))
+		}
+	}
+	for _, r := range l.revocations {
+		if r.Matches(issuer, jti, subject, issuedAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// InsertRevocation implements RevocationStore.
+func (l *RevocationList) InsertRevocation(ctx context.Context, r *Revocation) (*Revocation, error) {
+	inserted, err := l.store.InsertRevocation(ctx, r)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	l.refreshAfterChange(ctx)
+	return inserted, nil
+}
+
+// ListRevocations implements RevocationStore.
+func (l *RevocationList) ListRevocations(ctx context.Context) ([]*Revocation, error) {
+	return l.store.ListRevocations(ctx)
+}
+
+// DeleteRevocation implements RevocationStore.
+func (l *RevocationList) DeleteRevocation(ctx context.Context, id dssmodels.ID) (*Revocation, error) {
+	deleted, err := l.store.DeleteRevocation(ctx, id)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	l.refreshAfterChange(ctx)
+	return deleted, nil
+}
+
+// refreshAfterChange reloads the Revocations after a change was stored. The
+// change is persisted either way, so a failure only delays its enforcement to
+// the next periodic reload.
+func (l *RevocationList) refreshAfterChange(ctx context.Context) {
+	if err := l.Refresh(ctx); err != nil {
+		l.logger.Warn("failed to refresh access token revocations after a change", zap.Error(err))
+	}
+}
@@ -0,0 +1,129 @@
+package aux
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/interuss/dss/pkg/api"
+	restapi "github.com/interuss/dss/pkg/api/auxv1"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+)
+
+// ListDSSReports returns the most recent reports submitted to the DSS.
+func (a *Server) ListDSSReports(ctx context.Context, req *restapi.ListDSSReportsRequest) restapi.ListDSSReportsResponseSet {
+	if req.Auth.Error != nil {
+		resp := restapi.ListDSSReportsResponseSet{}
+		setAuthError(ctx, req.Auth.Error, &resp.Response401, &resp.Response403, &resp.Response500)
+		return resp
+	}
+	if a.SCDStore == nil {
+		return restapi.ListDSSReportsResponseSet{Response404: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.NotFound, "Strategic conflict detection is not enabled"))}}
+	}
+
+	var reporter dssmodels.Manager
+	if req.Reporter != nil {
+		reporter = dssmodels.Manager(*req.Reporter)
+	}
+	earliest, err := parseOptionalTime(req.EarliestTime)
+	if err != nil {
+		return restapi.ListDSSReportsResponseSet{Response400: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid earliest_time"))}}
+	}
+	latest, err := parseOptionalTime(req.LatestTime)
+	if err != nil {
+		return restapi.ListDSSReportsResponseSet{Response400: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid latest_time"))}}
+	}
+
+	r, err := a.SCDStore.Interact(ctx)
+	if err != nil {
+		return restapi.ListDSSReportsResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Unable to interact with store"))}}
+	}
+	reports, err := r.ListDSSReports(ctx, reporter, earliest, latest, dssmodels.MaxResultLimit)
+	if err != nil {
+		return restapi.ListDSSReportsResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Unable to list DSS reports"))}}
+	}
+
+	response := &restapi.ListDSSReportsResponse{Reports: make([]restapi.DSSReport, 0, len(reports))}
+	for _, report := range reports {
+		p, err := reportToRest(report)
+		if err != nil {
+			return restapi.ListDSSReportsResponseSet{Response500: &api.InternalServerErrorBody{
+				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Unable to convert DSS report %s", report.ID))}}
+		}
+		response.Reports = append(response.Reports, *p)
+	}
+	return restapi.ListDSSReportsResponseSet{Response200: response}
+}
+
+// GetDSSReport returns a report submitted to the DSS.
+func (a *Server) GetDSSReport(ctx context.Context, req *restapi.GetDSSReportRequest) restapi.GetDSSReportResponseSet {
+	if req.Auth.Error != nil {
+		resp := restapi.GetDSSReportResponseSet{}
+		setAuthError(ctx, req.Auth.Error, &resp.Response401, &resp.Response403, &resp.Response500)
+		return resp
+	}
+	if a.SCDStore == nil {
+		return restapi.GetDSSReportResponseSet{Response404: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.NotFound, "Strategic conflict detection is not enabled"))}}
+	}
+
+	id, err := dssmodels.IDFromString(req.ReportId)
+	if err != nil {
+		return restapi.GetDSSReportResponseSet{Response400: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", req.ReportId))}}
+	}
+
+	r, err := a.SCDStore.Interact(ctx)
+	if err != nil {
+		return restapi.GetDSSReportResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Unable to interact with store"))}}
+	}
+	report, err := r.GetDSSReport(ctx, id)
+	switch {
+	case err != nil:
+		return restapi.GetDSSReportResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Unable to get DSS report"))}}
+	case report == nil:
+		return restapi.GetDSSReportResponseSet{Response404: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.NotFound, "DSS report %s not found", id))}}
+	}
+
+	p, err := reportToRest(report)
+	if err != nil {
+		return restapi.GetDSSReportResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Unable to convert DSS report"))}}
+	}
+	return restapi.GetDSSReportResponseSet{Response200: p}
+}
+
+func reportToRest(report *scdmodels.DSSReport) (*restapi.DSSReport, error) {
+	var submitted restapi.SubmittedReport
+	if err := json.Unmarshal(report.Report, &submitted); err != nil {
+		return nil, stacktrace.Propagate(err, "Error deserializing report")
+	}
+	return &restapi.DSSReport{
+		ReportId:   report.ID.String(),
+		Reporter:   report.Reporter.String(),
+		ReceivedAt: report.ReceivedAt.UTC().Format(time.RFC3339Nano),
+		Report:     submitted,
+	}, nil
+}
+
+func parseOptionalTime(s *string) (*time.Time, error) {
+	if s == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, *s)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error parsing time")
+	}
+	return &t, nil
+}
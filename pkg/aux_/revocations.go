@@ -0,0 +1,145 @@
+package aux
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/interuss/dss/pkg/api"
+	restapi "github.com/interuss/dss/pkg/api/auxv1"
+	"github.com/interuss/dss/pkg/auth"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/logging"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/stacktrace"
+	"go.uber.org/zap"
+)
+
+// ListTokenRevocations returns all the access token revocations.
+func (a *Server) ListTokenRevocations(ctx context.Context, req *restapi.ListTokenRevocationsRequest) restapi.ListTokenRevocationsResponseSet {
+	if req.Auth.Error != nil {
+		resp := restapi.ListTokenRevocationsResponseSet{}
+		setAuthError(ctx, req.Auth.Error, &resp.Response401, &resp.Response403, &resp.Response500)
+		return resp
+	}
+	if a.Revocations == nil {
+		return restapi.ListTokenRevocationsResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.NewError("Token revocation is not configured"))}}
+	}
+
+	revocations, err := a.Revocations.ListRevocations(ctx)
+	if err != nil {
+		return restapi.ListTokenRevocationsResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Unable to list token revocations"))}}
+	}
+
+	response := &restapi.ListTokenRevocationsResponse{Revocations: make([]restapi.TokenRevocation, 0, len(revocations))}
+	for _, r := range revocations {
+		response.Revocations = append(response.Revocations, *revocationToRest(r))
+	}
+	return restapi.ListTokenRevocationsResponseSet{Response200: response}
+}
+
+// CreateTokenRevocation revokes either an access token or all the access
+// tokens issued to a subject until now.
+func (a *Server) CreateTokenRevocation(ctx context.Context, req *restapi.CreateTokenRevocationRequest) restapi.CreateTokenRevocationResponseSet {
+	if req.Auth.Error != nil {
+		resp := restapi.CreateTokenRevocationResponseSet{}
+		setAuthError(ctx, req.Auth.Error, &resp.Response401, &resp.Response403, &resp.Response500)
+		return resp
+	}
+	if req.BodyParseError != nil {
+		return restapi.CreateTokenRevocationResponseSet{Response400: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.PropagateWithCode(req.BodyParseError, dsserr.BadRequest, "Malformed params"))}}
+	}
+	if req.Auth.ClientID == nil {
+		return restapi.CreateTokenRevocationResponseSet{Response403: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Missing client ID"))}}
+	}
+	if a.Revocations == nil {
+		return restapi.CreateTokenRevocationResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.NewError("Token revocation is not configured"))}}
+	}
+
+	revocation := &auth.Revocation{
+		ID:        dssmodels.ID(uuid.New().String()),
+		Issuer:    valueOrEmpty(req.Body.Issuer),
+		JTI:       valueOrEmpty(req.Body.Jti),
+		Subject:   valueOrEmpty(req.Body.Subject),
+		Reason:    valueOrEmpty(req.Body.Reason),
+		RevokedBy: *req.Auth.ClientID,
+	}
+	if (revocation.JTI == "") == (revocation.Subject == "") {
+		return restapi.CreateTokenRevocationResponseSet{Response400: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Exactly one of jti and subject must be specified"))}}
+	}
+
+	revocation, err := a.Revocations.InsertRevocation(ctx, revocation)
+	if err != nil {
+		return restapi.CreateTokenRevocationResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Unable to insert token revocation"))}}
+	}
+	logging.WithValuesFromContext(ctx, logging.Logger).Info("Revoked access tokens",
+		zap.String("revocation_id", revocation.ID.String()),
+		zap.String("issuer", revocation.Issuer),
+		zap.String("jti", revocation.JTI),
+		zap.String("subject", revocation.Subject),
+		zap.String("revoked_by", revocation.RevokedBy))
+	return restapi.CreateTokenRevocationResponseSet{Response200: revocationToRest(revocation)}
+}
+
+// DeleteTokenRevocation deletes an access token revocation.
+func (a *Server) DeleteTokenRevocation(ctx context.Context, req *restapi.DeleteTokenRevocationRequest) restapi.DeleteTokenRevocationResponseSet {
+	if req.Auth.Error != nil {
+		resp := restapi.DeleteTokenRevocationResponseSet{}
+		setAuthError(ctx, req.Auth.Error, &resp.Response401, &resp.Response403, &resp.Response500)
+		return resp
+	}
+	if a.Revocations == nil {
+		return restapi.DeleteTokenRevocationResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.NewError("Token revocation is not configured"))}}
+	}
+
+	id, err := dssmodels.IDFromString(req.RevocationId)
+	if err != nil {
+		return restapi.DeleteTokenRevocationResponseSet{Response400: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", req.RevocationId))}}
+	}
+
+	revocation, err := a.Revocations.DeleteRevocation(ctx, id)
+	switch {
+	case err != nil:
+		return restapi.DeleteTokenRevocationResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Unable to delete token revocation"))}}
+	case revocation == nil:
+		return restapi.DeleteTokenRevocationResponseSet{Response404: &restapi.ErrorResponse{
+			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.NotFound, "Token revocation %s not found", id))}}
+	}
+	return restapi.DeleteTokenRevocationResponseSet{Response200: revocationToRest(revocation)}
+}
+
+func revocationToRest(r *auth.Revocation) *restapi.TokenRevocation {
+	result := &restapi.TokenRevocation{
+		RevocationId: r.ID.String(),
+		Reason:       r.Reason,
+		RevokedBy:    r.RevokedBy,
+		RevokedAt:    r.RevokedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if r.Issuer != "" {
+		result.Issuer = &r.Issuer
+	}
+	if r.JTI != "" {
+		result.Jti = &r.JTI
+	}
+	if r.Subject != "" {
+		result.Subject = &r.Subject
+	}
+	return result
+}
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -5,13 +5,21 @@ import (
 
 	"github.com/interuss/dss/pkg/api"
 	restapi "github.com/interuss/dss/pkg/api/auxv1"
+	"github.com/interuss/dss/pkg/auth"
 	dsserr "github.com/interuss/dss/pkg/errors"
+	scdstore "github.com/interuss/dss/pkg/scd/store"
 	"github.com/interuss/dss/pkg/version"
 	"github.com/interuss/stacktrace"
 )
 
 // Server implements auxv1.Implementation.
-type Server struct{}
+type Server struct {
+	// SCDStore is the store of strategic conflict detection data, nil if
+	// strategic conflict detection is not enabled.
+	SCDStore scdstore.Store
+	// Revocations is the store of access token revocations.
+	Revocations auth.RevocationStore
+}
 
 // GetVersion returns information about the version of the server.
 func (a *Server) GetVersion(context.Context, *restapi.GetVersionRequest) restapi.GetVersionResponseSet {
@@ -24,14 +32,7 @@ func (a *Server) ValidateOauth(ctx context.Context, req *restapi.ValidateOauthRe
 
 	if req.Auth.Error != nil {
 		resp := restapi.ValidateOauthResponseSet{}
-		switch stacktrace.GetCode(req.Auth.Error) {
-		case dsserr.Unauthenticated:
-			resp.Response401 = &restapi.ErrorResponse{Message: dsserr.Handle(ctx, stacktrace.Propagate(req.Auth.Error, "Authentication failed"))}
-		case dsserr.PermissionDenied:
-			resp.Response403 = &restapi.ErrorResponse{Message: dsserr.Handle(ctx, stacktrace.Propagate(req.Auth.Error, "Authorization failed"))}
-		default:
-			resp.Response500 = &api.InternalServerErrorBody{ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(req.Auth.Error, "Could not perform authorization"))}
-		}
+		setAuthError(ctx, req.Auth.Error, &resp.Response401, &resp.Response403, &resp.Response500)
 		return resp
 	}
 
@@ -43,3 +44,14 @@ func (a *Server) ValidateOauth(ctx context.Context, req *restapi.ValidateOauthRe
 	}
 	return restapi.ValidateOauthResponseSet{Response200: &api.EmptyResponseBody{}}
 }
+
+func setAuthError(ctx context.Context, authErr error, resp401, resp403 **restapi.ErrorResponse, resp500 **api.InternalServerErrorBody) {
+	switch stacktrace.GetCode(authErr) {
+	case dsserr.Unauthenticated:
+		*resp401 = &restapi.ErrorResponse{Message: dsserr.Handle(ctx, stacktrace.Propagate(authErr, "Authentication failed"))}
+	case dsserr.PermissionDenied:
+		*resp403 = &restapi.ErrorResponse{Message: dsserr.Handle(ctx, stacktrace.Propagate(authErr, "Authorization failed"))}
+	default:
+		*resp500 = &api.InternalServerErrorBody{ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(authErr, "Could not perform authorization"))}
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/dss/pkg/api/auxv1"
+	"github.com/interuss/dss/pkg/api/ridv1"
+	"github.com/interuss/dss/pkg/api/ridv2"
+	"github.com/interuss/dss/pkg/api/scdv1"
+	"github.com/interuss/stacktrace"
+)
+
+// Configuration configures a Client.
+type Configuration struct {
+	// BaseURL is the URL of the DSS, without trailing slash.
+	BaseURL string
+	// HTTPClient sends the requests; http.DefaultClient is used if nil.
+	HTTPClient *http.Client
+	// TokenSource provides the access tokens authorizing the requests, which
+	// are sent without access token if nil.
+	TokenSource api.TokenSource
+	// MaxAttempts is the maximum number of times a request is sent, whether
+	// it is retried because of the load of the DSS or because of a conflict.
+	MaxAttempts int
+	// MaxRetryWait bounds the time waited before retrying a request rejected
+	// because of the load of the DSS, whatever its Retry-After.
+	MaxRetryWait time.Duration
+	// OVNResolver obtains the OVNs missing from the key of operational intent
+	// references; conflicts caused by missing OVNs are not retried if nil.
+	OVNResolver OVNResolver
+	// RetryVersionMismatches retries the changes of entities rejected because
+	// the version presented is not their current version with the current
+	// version. As only their manager may change entities, mismatches
+	// typically happen when the response to a previous change was lost.
+	RetryVersionMismatches bool
+}
+
+// DefaultConfiguration retries requests up to 3 times, waiting at most 10
+// seconds between attempts.
+var DefaultConfiguration = Configuration{
+	MaxAttempts:            3,
+	MaxRetryWait:           10 * time.Second,
+	RetryVersionMismatches: true,
+}
+
+// Client is a client of the DSS APIs. The generated client of each API may be
+// used directly for the operations without retry beyond the load of the DSS.
+type Client struct {
+	Aux   *auxv1.Client
+	RIDV1 *ridv1.Client
+	RIDV2 *ridv2.Client
+	SCD   *scdv1.Client
+
+	configuration Configuration
+}
+
+// New returns a Client configured by configuration.
+func New(configuration Configuration) *Client {
+	if configuration.MaxAttempts < 1 {
+		configuration.MaxAttempts = 1
+	}
+	httpClient := &http.Client{}
+	if configuration.HTTPClient != nil {
+		*httpClient = *configuration.HTTPClient
+	}
+	httpClient.Transport = &retryingTransport{
+		next:        httpClient.Transport,
+		maxAttempts: configuration.MaxAttempts,
+		maxWait:     configuration.MaxRetryWait,
+	}
+	configuration.HTTPClient = httpClient
+
+	return &Client{
+		Aux:           &auxv1.Client{BaseURL: configuration.BaseURL, HTTPClient: httpClient, TokenSource: configuration.TokenSource},
+		RIDV1:         &ridv1.Client{BaseURL: configuration.BaseURL, HTTPClient: httpClient, TokenSource: configuration.TokenSource},
+		RIDV2:         &ridv2.Client{BaseURL: configuration.BaseURL, HTTPClient: httpClient, TokenSource: configuration.TokenSource},
+		SCD:           &scdv1.Client{BaseURL: configuration.BaseURL, HTTPClient: httpClient, TokenSource: configuration.TokenSource},
+		configuration: configuration,
+	}
+}
+
+// responseError returns the error of the response populated in responseSet,
+// a pointer to the response set of an operation, as an *api.ResponseError
+// whose body is the response encoded in JSON.
+func responseError(responseSet interface{}) error {
+	v := reflect.ValueOf(responseSet).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.IsNil() {
+			continue
+		}
+		code, err := strconv.Atoi(strings.TrimPrefix(v.Type().Field(i).Name, "Response"))
+		if err != nil {
+			return stacktrace.Propagate(err, "Invalid response field %s", v.Type().Field(i).Name)
+		}
+		body, err := json.Marshal(field.Interface())
+		if err != nil {
+			return stacktrace.Propagate(err, "Error encoding %d response", code)
+		}
+		return &api.ResponseError{StatusCode: code, Body: string(body)}
+	}
+	return stacktrace.NewError("No response was populated")
+}
@@ -0,0 +1,248 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/dss/pkg/api/ridv2"
+	"github.com/interuss/dss/pkg/api/scdv1"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/rid/application"
+	ridserver "github.com/interuss/dss/pkg/rid/server/v2"
+	ridm "github.com/interuss/dss/pkg/rid/store/memory"
+	"github.com/interuss/dss/pkg/scd"
+	scdm "github.com/interuss/dss/pkg/scd/store/memory"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// bearerAuthorizer authorizes requests for the client ID given as access
+// token, granting all the scopes of the operation.
+type bearerAuthorizer struct{}
+
+func (bearerAuthorizer) Authorize(w http.ResponseWriter, r *http.Request, authOptions []api.AuthorizationOption) api.AuthorizationResult {
+	clientID := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if clientID == "" {
+		return api.AuthorizationResult{Error: stacktrace.NewErrorWithCode(dsserr.Unauthenticated, "Missing access token")}
+	}
+	var scopes []string
+	for _, option := range authOptions {
+		for _, required := range option {
+			for _, scope := range required {
+				scopes = append(scopes, string(scope))
+			}
+		}
+	}
+	return api.AuthorizationResult{ClientID: &clientID, Scopes: scopes}
+}
+
+// newTestDSS serves the SCD and RID v2 APIs backed by memory stores, validating
+// requests against the API schemas.
+func newTestDSS(t *testing.T) *httptest.Server {
+	scdServer := &scd.Server{Store: scdm.NewStore(), Timeout: 10 * time.Second, AllowHTTPBaseUrls: true}
+	ridServer := &ridserver.Server{App: application.NewFromTransactor(ridm.NewStore(), zap.NewNop()), Timeout: 10 * time.Second, AllowHTTPBaseUrls: true}
+	router := api.NewRouter(scdv1.MakeAPIRouter(scdServer, bearerAuthorizer{}, api.WithRequestValidation(true)).Routes...)
+	router.Add(ridv2.MakeAPIRouter(ridServer, bearerAuthorizer{}, api.WithRequestValidation(true)).Routes...)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(dss *httptest.Server, clientID string) *Client {
+	configuration := DefaultConfiguration
+	configuration.BaseURL = dss.URL
+	configuration.TokenSource = api.StaticTokenSource(clientID)
+	configuration.OVNResolver = &USSResolver{TokenSource: api.StaticTokenSource(clientID)}
+	return New(configuration)
+}
+
+func testOperationalIntent(ussBaseURL string) *scdv1.PutOperationalIntentReferenceParameters {
+	var (
+		now  = time.Now().UTC()
+		lats = []scdv1.Latitude{37.0, 37.0, 37.1}
+		lngs = []scdv1.Longitude{-122.0, -122.1, -122.0}
+	)
+	polygon := &scdv1.Polygon{}
+	for i := range lats {
+		polygon.Vertices = append(polygon.Vertices, scdv1.LatLngPoint{Lat: lats[i], Lng: lngs[i]})
+	}
+	return &scdv1.PutOperationalIntentReferenceParameters{
+		Extents: []scdv1.Volume4D{{
+			Volume: scdv1.Volume3D{
+				OutlinePolygon: polygon,
+				AltitudeLower:  &scdv1.Altitude{Value: 0, Reference: "W84", Units: "M"},
+				AltitudeUpper:  &scdv1.Altitude{Value: 100, Reference: "W84", Units: "M"},
+			},
+			TimeStart: &scdv1.Time{Value: now.Add(time.Minute).Format(time.RFC3339), Format: "RFC3339"},
+			TimeEnd:   &scdv1.Time{Value: now.Add(time.Hour).Format(time.RFC3339), Format: "RFC3339"},
+		}},
+		State:           scdv1.OperationalIntentState_Accepted,
+		UssBaseUrl:      scdv1.OperationalIntentUssBaseURL(ussBaseURL),
+		NewSubscription: &scdv1.ImplicitSubscriptionParameters{UssBaseUrl: scdv1.SubscriptionUssBaseURL(ussBaseURL)},
+	}
+}
+
+func TestPutOperationalIntentReferenceRefreshesKey(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		dss       = newTestDSS(t)
+		uss1      = newTestClient(dss, "uss1")
+		uss2      = newTestClient(dss, "uss2")
+		uss1OVN   scdv1.EntityOVN
+		requested []string
+	)
+	// uss1 serves the details of its operational intent to uss2.
+	uss1Server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.Header.Get("Authorization")+" "+r.URL.Path)
+		var details operationalIntentDetails
+		details.OperationalIntent.Reference.Ovn = uss1OVN
+		api.WriteJSON(w, http.StatusOK, details)
+	}))
+	defer uss1Server.Close()
+
+	created, err := uss1.PutOperationalIntentReference(ctx, "00000000-0000-4000-8000-000000000001", "", testOperationalIntent(uss1Server.URL))
+	require.NoError(t, err)
+	uss1OVN = *created.OperationalIntentReference.Ovn
+
+	// Without the OVN of the operational intent of uss1, the request of uss2
+	// is rejected once, then retried with the OVN obtained from uss1.
+	_, err = uss2.PutOperationalIntentReference(ctx, "00000000-0000-4000-8000-000000000002", "", testOperationalIntent("http://uss2.example.com"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"Bearer uss2 /uss/v1/operational_intents/00000000-0000-4000-8000-000000000001"}, requested)
+}
+
+func TestPutOperationalIntentReferenceRetriesVersionMismatch(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		dss    = newTestDSS(t)
+		uss1   = newTestClient(dss, "uss1")
+		id     = scdv1.EntityID("00000000-0000-4000-8000-000000000001")
+		params = testOperationalIntent("http://uss1.example.com")
+	)
+
+	_, err := uss1.PutOperationalIntentReference(ctx, id, "", params)
+	require.NoError(t, err)
+	// Creating the operational intent again, as if the response to the first
+	// request was lost, updates it from its current OVN.
+	updated, err := uss1.PutOperationalIntentReference(ctx, id, "", params)
+	require.NoError(t, err)
+	require.Equal(t, scdv1.EntityVersion(2), updated.OperationalIntentReference.Version)
+
+	uss1.configuration.RetryVersionMismatches = false
+	_, err = uss1.PutOperationalIntentReference(ctx, id, "stale-ovn-00000000", params)
+	require.Error(t, err)
+	require.Equal(t, http.StatusConflict, err.(*api.ResponseError).StatusCode)
+}
+
+func TestPutIdentificationServiceAreaRetriesVersionMismatch(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		dss  = newTestDSS(t)
+		uss1 = newTestClient(dss, "uss1")
+		id   = ridv2.EntityUUID("00000000-0000-4000-8000-000000000001")
+		now  = time.Now().UTC()
+	)
+	params := &ridv2.UpdateIdentificationServiceAreaParameters{
+		Extents: ridv2.Volume4D{
+			Volume: ridv2.Volume3D{
+				OutlinePolygon: &ridv2.Polygon{Vertices: []ridv2.LatLngPoint{{Lat: 37.0, Lng: -122.0}, {Lat: 37.0, Lng: -122.1}, {Lat: 37.1, Lng: -122.0}}},
+				AltitudeLower:  &ridv2.Altitude{Value: 0, Reference: "W84", Units: "M"},
+				AltitudeUpper:  &ridv2.Altitude{Value: 100, Reference: "W84", Units: "M"},
+			},
+			TimeStart: &ridv2.Time{Value: now.Format(time.RFC3339), Format: "RFC3339"},
+			TimeEnd:   &ridv2.Time{Value: now.Add(time.Hour).Format(time.RFC3339), Format: "RFC3339"},
+		},
+		UssBaseUrl: "http://uss1.example.com/flights",
+	}
+
+	created, err := uss1.PutIdentificationServiceArea(ctx, id, "", params)
+	require.NoError(t, err)
+	updated, err := uss1.PutIdentificationServiceArea(ctx, id, "stale", params)
+	require.NoError(t, err)
+	require.NotEqual(t, created.ServiceArea.Version, updated.ServiceArea.Version)
+}
+
+func TestGetReturnsSubmittedExtents(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		dss    = newTestDSS(t)
+		uss1   = newTestClient(dss, "uss1")
+		id     = scdv1.EntityID("00000000-0000-4000-8000-000000000001")
+		params = testOperationalIntent("http://uss1.example.com")
+	)
+	_, err := uss1.PutOperationalIntentReference(ctx, id, "", params)
+	require.NoError(t, err)
+	op, err := uss1.SCD.GetOperationalIntentReference(ctx, &scdv1.GetOperationalIntentReferenceRequest{Entityid: id})
+	require.NoError(t, err)
+	require.NotNil(t, op.Response200)
+	require.NotNil(t, op.Response200.Extents)
+	require.Equal(t, params.Extents, *op.Response200.Extents)
+
+	volume := ridv2.Volume3D{
+		OutlineCircle: &ridv2.Circle{Center: &ridv2.LatLngPoint{Lat: 37.0, Lng: -122.0}, Radius: &ridv2.Radius{Value: 500, Units: "M"}},
+		AltitudeLower: &ridv2.Altitude{Value: 0, Reference: "W84", Units: "M"},
+		AltitudeUpper: &ridv2.Altitude{Value: 100, Reference: "W84", Units: "M"},
+	}
+	now := time.Now().UTC()
+	_, err = uss1.PutIdentificationServiceArea(ctx, ridv2.EntityUUID(id), "", &ridv2.UpdateIdentificationServiceAreaParameters{
+		Extents: ridv2.Volume4D{
+			Volume:    volume,
+			TimeStart: &ridv2.Time{Value: now.Format(time.RFC3339), Format: "RFC3339"},
+			TimeEnd:   &ridv2.Time{Value: now.Add(time.Hour).Format(time.RFC3339), Format: "RFC3339"},
+		},
+		UssBaseUrl: "http://uss1.example.com/flights",
+	})
+	require.NoError(t, err)
+	isa, err := uss1.RIDV2.GetIdentificationServiceArea(ctx, &ridv2.GetIdentificationServiceAreaRequest{Id: ridv2.EntityUUID(id)})
+	require.NoError(t, err)
+	require.NotNil(t, isa.Response200)
+	require.NotNil(t, isa.Response200.Extents)
+	require.Equal(t, volume, isa.Response200.Extents.Volume)
+}
+
+func TestRetriesOverloadedRequests(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		data, _ := json.Marshal(body)
+		bodies = append(bodies, string(data))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			api.WriteJSON(w, http.StatusTooManyRequests, scdv1.ErrorResponse{})
+			return
+		}
+		api.WriteJSON(w, http.StatusOK, scdv1.QueryOperationalIntentReferenceResponse{})
+	}))
+	defer server.Close()
+
+	configuration := DefaultConfiguration
+	configuration.BaseURL = server.URL
+	resp, err := New(configuration).SCD.QueryOperationalIntentReferences(context.Background(), &scdv1.QueryOperationalIntentReferencesRequest{
+		Body: &scdv1.QueryOperationalIntentReferenceParameters{}})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Response200)
+	// The body is sent again
+	require.Len(t, bodies, 2)
+	require.Equal(t, bodies[0], bodies[1])
+
+	// Undeclared responses are errors
+	configuration.MaxAttempts = 1
+	bodies = nil
+	_, err = New(configuration).Aux.GetVersion(context.Background(), nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusTooManyRequests, err.(*api.ResponseError).StatusCode)
+}
+
+func TestRetryAfter(t *testing.T) {
+	require.Equal(t, 3*time.Second, retryAfter(http.Header{"Retry-After": {"3"}}))
+	require.Equal(t, defaultRetryWait, retryAfter(http.Header{}))
+	require.Zero(t, retryAfter(http.Header{"Retry-After": {time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}}))
+}
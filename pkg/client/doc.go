@@ -0,0 +1,9 @@
+// Package client provides a client of the DSS APIs built on the clients
+// generated from their OpenAPI specifications.
+//
+// On top of the generated clients, it retries the requests rejected because
+// of the load of the DSS (429 and 503 responses), the changes of operational
+// intent references rejected because their key misses the OVNs of
+// intersecting entities, and the changes of entities rejected because the
+// version presented is not their current version.
+package client
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/dss/pkg/api/scdv1"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+)
+
+// USS-to-USS scopes required to retrieve the details of operational intents
+// and constraints from the USSs managing them.
+const (
+	strategicCoordinationScope = api.RequiredScope("utm.strategic_coordination")
+	constraintProcessingScope  = api.RequiredScope("utm.constraint_processing")
+)
+
+// OVNResolver obtains the current OVNs of the operational intents and
+// constraints missing from the key of an operational intent reference.
+type OVNResolver interface {
+	ResolveOVNs(ctx context.Context, conflict *scdv1.AirspaceConflictResponse) ([]scdv1.EntityOVN, error)
+}
+
+// USSResolver is an OVNResolver retrieving the details of the missing
+// operational intents and constraints from the USSs managing them, through
+// the ASTM F3548 USS-to-USS API. The OVNs given by the DSS, which are those of
+// the entities managed by the client, are used as is.
+type USSResolver struct {
+	// HTTPClient sends the requests; http.DefaultClient is used if nil.
+	HTTPClient *http.Client
+	// TokenSource provides the access tokens presented to the USSs, which are
+	// requested without access token if nil.
+	TokenSource api.TokenSource
+}
+
+// operationalIntentDetails is the part of the USS-to-USS response to
+// GET /uss/v1/operational_intents/{entityid} holding the OVN.
+type operationalIntentDetails struct {
+	OperationalIntent struct {
+		Reference struct {
+			Ovn scdv1.EntityOVN `json:"ovn"`
+		} `json:"reference"`
+	} `json:"operational_intent"`
+}
+
+// constraintDetails is the part of the USS-to-USS response to
+// GET /uss/v1/constraints/{entityid} holding the OVN.
+type constraintDetails struct {
+	Constraint struct {
+		Reference struct {
+			Ovn scdv1.EntityOVN `json:"ovn"`
+		} `json:"reference"`
+	} `json:"constraint"`
+}
+
+// ResolveOVNs implements OVNResolver.
+func (r *USSResolver) ResolveOVNs(ctx context.Context, conflict *scdv1.AirspaceConflictResponse) ([]scdv1.EntityOVN, error) {
+	var ovns []scdv1.EntityOVN
+	if conflict.MissingOperationalIntents != nil {
+		for _, ref := range *conflict.MissingOperationalIntents {
+			if ref.Ovn != nil && *ref.Ovn != scdmodels.NoOvnPhrase {
+				ovns = append(ovns, *ref.Ovn)
+				continue
+			}
+			var details operationalIntentDetails
+			if err := r.get(ctx, string(ref.UssBaseUrl), "/uss/v1/operational_intents/", ref.Id, strategicCoordinationScope, &details); err != nil {
+				return nil, stacktrace.Propagate(err, "Error retrieving details of operational intent %s", ref.Id)
+			}
+			ovns = append(ovns, details.OperationalIntent.Reference.Ovn)
+		}
+	}
+	if conflict.MissingConstraints != nil {
+		for _, ref := range *conflict.MissingConstraints {
+			if ref.Ovn != nil && *ref.Ovn != scdmodels.NoOvnPhrase {
+				ovns = append(ovns, *ref.Ovn)
+				continue
+			}
+			var details constraintDetails
+			if err := r.get(ctx, string(ref.UssBaseUrl), "/uss/v1/constraints/", ref.Id, constraintProcessingScope, &details); err != nil {
+				return nil, stacktrace.Propagate(err, "Error retrieving details of constraint %s", ref.Id)
+			}
+			ovns = append(ovns, details.Constraint.Reference.Ovn)
+		}
+	}
+	return ovns, nil
+}
+
+// get retrieves the details of the entity id at path of the USS at baseURL
+// into details.
+func (r *USSResolver) get(ctx context.Context, baseURL string, path string, id scdv1.EntityID, scope api.RequiredScope, details interface{}) error {
+	authOptions := []api.AuthorizationOption{{"Authority": {scope}}}
+	resp, err := api.SendRequest(ctx, r.HTTPClient, r.TokenSource, authOptions, http.MethodGet,
+		strings.TrimSuffix(baseURL, "/")+path+url.PathEscape(string(id)), nil, nil)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error requesting details from %s", baseURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return api.NewResponseError(resp)
+	}
+	return api.ReadJSON(resp, details)
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+
+	"github.com/interuss/dss/pkg/api/ridv2"
+	"github.com/interuss/stacktrace"
+)
+
+// PutIdentificationServiceArea creates the identification service area id if
+// version is empty, or updates it from version otherwise. If version is not
+// the current version of the identification service area, the request is
+// retried with its current version if RetryVersionMismatches is set.
+func (c *Client) PutIdentificationServiceArea(ctx context.Context, id ridv2.EntityUUID, version string, params *ridv2.UpdateIdentificationServiceAreaParameters) (*ridv2.PutIdentificationServiceAreaResponse, error) {
+	for attempt := 1; ; attempt++ {
+		var (
+			ok       *ridv2.PutIdentificationServiceAreaResponse
+			conflict *ridv2.ErrorResponse
+			failure  interface{}
+		)
+		if version == "" {
+			createParams := ridv2.CreateIdentificationServiceAreaParameters(*params)
+			resp, err := c.RIDV2.CreateIdentificationServiceArea(ctx, &ridv2.CreateIdentificationServiceAreaRequest{Id: id, Body: &createParams})
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Error creating identification service area %s", id)
+			}
+			ok, conflict, failure = resp.Response200, resp.Response409, resp
+		} else {
+			resp, err := c.RIDV2.UpdateIdentificationServiceArea(ctx, &ridv2.UpdateIdentificationServiceAreaRequest{Id: id, Version: version, Body: params})
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Error updating identification service area %s", id)
+			}
+			ok, conflict, failure = resp.Response200, resp.Response409, resp
+		}
+		if ok != nil {
+			return ok, nil
+		}
+		if conflict == nil || !c.configuration.RetryVersionMismatches || attempt >= c.configuration.MaxAttempts {
+			return nil, responseError(failure)
+		}
+
+		resp, err := c.RIDV2.GetIdentificationServiceArea(ctx, &ridv2.GetIdentificationServiceAreaRequest{Id: id})
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error retrieving identification service area %s", id)
+		}
+		if resp.Response200 == nil || string(resp.Response200.ServiceArea.Version) == version {
+			return nil, responseError(failure)
+		}
+		version = string(resp.Response200.ServiceArea.Version)
+	}
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+
+	"github.com/interuss/dss/pkg/api/scdv1"
+	"github.com/interuss/stacktrace"
+)
+
+// PutOperationalIntentReference creates the operational intent reference id
+// if ovn is empty, or updates it from its version ovn otherwise.
+//
+// If the key of params misses the OVNs of intersecting entities, they are
+// obtained from the OVNResolver and the request is retried with them. If ovn
+// is not the current OVN of the operational intent reference, the request is
+// retried with its current OVN if RetryVersionMismatches is set.
+func (c *Client) PutOperationalIntentReference(ctx context.Context, id scdv1.EntityID, ovn scdv1.EntityOVN, params *scdv1.PutOperationalIntentReferenceParameters) (*scdv1.ChangeOperationalIntentReferenceResponse, error) {
+	p := *params
+	for attempt := 1; ; attempt++ {
+		var (
+			ok       *scdv1.ChangeOperationalIntentReferenceResponse
+			conflict *scdv1.AirspaceConflictResponse
+			failure  interface{}
+		)
+		if ovn == "" {
+			resp, err := c.SCD.CreateOperationalIntentReference(ctx, &scdv1.CreateOperationalIntentReferenceRequest{Entityid: id, Body: &p})
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Error creating operational intent reference %s", id)
+			}
+			ok, conflict, failure = resp.Response201, resp.Response409, resp
+		} else {
+			resp, err := c.SCD.UpdateOperationalIntentReference(ctx, &scdv1.UpdateOperationalIntentReferenceRequest{Entityid: id, Ovn: ovn, Body: &p})
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Error updating operational intent reference %s", id)
+			}
+			ok, conflict, failure = resp.Response200, resp.Response409, resp
+		}
+		if ok != nil {
+			return ok, nil
+		}
+		if conflict == nil || attempt >= c.configuration.MaxAttempts {
+			return nil, responseError(failure)
+		}
+
+		if missingOVNs(conflict) {
+			if c.configuration.OVNResolver == nil {
+				return nil, responseError(failure)
+			}
+			ovns, err := c.configuration.OVNResolver.ResolveOVNs(ctx, conflict)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Error resolving OVNs missing from key of operational intent reference %s", id)
+			}
+			key, added := extendKey(p.Key, ovns)
+			if !added {
+				return nil, responseError(failure)
+			}
+			p.Key = &key
+			continue
+		}
+
+		if !c.configuration.RetryVersionMismatches {
+			return nil, responseError(failure)
+		}
+		resp, err := c.SCD.GetOperationalIntentReference(ctx, &scdv1.GetOperationalIntentReferenceRequest{Entityid: id})
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error retrieving operational intent reference %s", id)
+		}
+		if resp.Response200 == nil || resp.Response200.OperationalIntentReference.Ovn == nil || *resp.Response200.OperationalIntentReference.Ovn == ovn {
+			return nil, responseError(failure)
+		}
+		ovn = *resp.Response200.OperationalIntentReference.Ovn
+	}
+}
+
+// PutConstraintReference creates the constraint reference id if ovn is
+// empty, or updates it from its version ovn otherwise. If ovn is not the
+// current OVN of the constraint reference, the request is retried with its
+// current OVN if RetryVersionMismatches is set.
+func (c *Client) PutConstraintReference(ctx context.Context, id scdv1.EntityID, ovn scdv1.EntityOVN, params *scdv1.PutConstraintReferenceParameters) (*scdv1.ChangeConstraintReferenceResponse, error) {
+	for attempt := 1; ; attempt++ {
+		var (
+			ok       *scdv1.ChangeConstraintReferenceResponse
+			conflict *scdv1.ErrorResponse
+			failure  interface{}
+		)
+		if ovn == "" {
+			resp, err := c.SCD.CreateConstraintReference(ctx, &scdv1.CreateConstraintReferenceRequest{Entityid: id, Body: params})
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Error creating constraint reference %s", id)
+			}
+			ok, conflict, failure = resp.Response201, resp.Response409, resp
+		} else {
+			resp, err := c.SCD.UpdateConstraintReference(ctx, &scdv1.UpdateConstraintReferenceRequest{Entityid: id, Ovn: ovn, Body: params})
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Error updating constraint reference %s", id)
+			}
+			ok, conflict, failure = resp.Response200, resp.Response409, resp
+		}
+		if ok != nil {
+			return ok, nil
+		}
+		if conflict == nil || !c.configuration.RetryVersionMismatches || attempt >= c.configuration.MaxAttempts {
+			return nil, responseError(failure)
+		}
+
+		resp, err := c.SCD.GetConstraintReference(ctx, &scdv1.GetConstraintReferenceRequest{Entityid: id})
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error retrieving constraint reference %s", id)
+		}
+		if resp.Response200 == nil || resp.Response200.ConstraintReference.Ovn == nil || *resp.Response200.ConstraintReference.Ovn == ovn {
+			return nil, responseError(failure)
+		}
+		ovn = *resp.Response200.ConstraintReference.Ovn
+	}
+}
+
+// missingOVNs reports whether conflict was caused by OVNs missing from a key.
+func missingOVNs(conflict *scdv1.AirspaceConflictResponse) bool {
+	return (conflict.MissingOperationalIntents != nil && len(*conflict.MissingOperationalIntents) > 0) ||
+		(conflict.MissingConstraints != nil && len(*conflict.MissingConstraints) > 0)
+}
+
+// extendKey returns a copy of key with ovns, and whether any of ovns was not
+// already in key.
+func extendKey(key *scdv1.Key, ovns []scdv1.EntityOVN) (scdv1.Key, bool) {
+	var result scdv1.Key
+	present := map[scdv1.EntityOVN]bool{}
+	if key != nil {
+		result = append(result, *key...)
+		for _, ovn := range *key {
+			present[ovn] = true
+		}
+	}
+	added := false
+	for _, ovn := range ovns {
+		if !present[ovn] {
+			present[ovn] = true
+			result = append(result, ovn)
+			added = true
+		}
+	}
+	return result, added
+}
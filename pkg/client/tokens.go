@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/stacktrace"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// ClientCredentials is an api.TokenSource obtaining access tokens from an
+// OAuth server with the client credentials grant. A token is requested (and
+// cached until it expires) for every combination of audience and scopes.
+type ClientCredentials struct {
+	// TokenURL is the token endpoint of the OAuth server.
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	// AudienceParameter is the name of the token request parameter carrying
+	// the intended audience; "audience" if empty.
+	AudienceParameter string
+	// Scopes are the scopes the client may be granted. The scopes of the first
+	// authorization option of an operation whose scopes are all among Scopes
+	// are requested; the scopes of its first option are requested if Scopes
+	// is empty.
+	Scopes []string
+
+	mu      sync.Mutex
+	sources map[string]oauth2.TokenSource
+}
+
+// Token implements api.TokenSource.
+func (c *ClientCredentials) Token(ctx context.Context, audience string, authOptions []api.AuthorizationOption) (string, error) {
+	scopes, err := c.selectScopes(authOptions)
+	if err != nil {
+		return "", err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	token, err := c.tokenSource(audience, scopes).Token()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "Error obtaining access token for %s with scopes %v", audience, scopes)
+	}
+	return token.AccessToken, nil
+}
+
+// selectScopes returns the sorted scopes of the first option of authOptions
+// the client may be granted.
+func (c *ClientCredentials) selectScopes(authOptions []api.AuthorizationOption) ([]string, error) {
+	allowed := make(map[string]bool, len(c.Scopes))
+	for _, scope := range c.Scopes {
+		allowed[scope] = true
+	}
+	for _, option := range authOptions {
+		var scopes []string
+		granted := true
+		for _, required := range option {
+			for _, scope := range required {
+				granted = granted && (len(allowed) == 0 || allowed[string(scope)])
+				scopes = append(scopes, string(scope))
+			}
+		}
+		if granted {
+			sort.Strings(scopes)
+			return scopes, nil
+		}
+	}
+	if len(authOptions) == 0 {
+		return nil, nil
+	}
+	return nil, stacktrace.NewError("None of the authorization options of the operation is granted by scopes %v", c.Scopes)
+}
+
+func (c *ClientCredentials) tokenSource(audience string, scopes []string) oauth2.TokenSource {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := audience + " " + strings.Join(scopes, " ")
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+
+	parameter := c.AudienceParameter
+	if parameter == "" {
+		parameter = "audience"
+	}
+	config := &clientcredentials.Config{
+		ClientID:       c.ClientID,
+		ClientSecret:   c.ClientSecret,
+		TokenURL:       c.TokenURL,
+		Scopes:         scopes,
+		EndpointParams: url.Values{parameter: []string{audience}},
+	}
+	if c.sources == nil {
+		c.sources = map[string]oauth2.TokenSource{}
+	}
+	source := config.TokenSource(context.Background())
+	c.sources[key] = source
+	return source
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRetryWait is the time waited before retrying a request whose
+// response has no valid Retry-After.
+const defaultRetryWait = time.Second
+
+// retryingTransport is an http.RoundTripper retrying the requests rejected
+// with 429 Too Many Requests or 503 Service Unavailable after the time given
+// by the Retry-After of the response.
+type retryingTransport struct {
+	// next sends the requests; http.DefaultTransport is used if nil.
+	next        http.RoundTripper
+	maxAttempts int
+	// maxWait bounds the time waited before retrying, unless it is zero.
+	maxWait time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := next.RoundTrip(req)
+		if err != nil || attempt >= t.maxAttempts || !retryable(resp.StatusCode) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			// The body was consumed and cannot be sent again.
+			return resp, nil
+		}
+
+		wait := retryAfter(resp.Header)
+		if t.maxWait > 0 && wait > t.maxWait {
+			wait = t.maxWait
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// retryAfter returns the time to wait given by the Retry-After of header, in
+// either of its formats.
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return defaultRetryWait
+}
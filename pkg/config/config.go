@@ -0,0 +1,244 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/interuss/stacktrace"
+	"github.com/spf13/pflag"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the name of the environment variable setting a flag,
+// whose name is otherwise the upper-cased name of the flag; e.g. DSS_LOG_LEVEL
+// sets the log_level flag.
+const EnvPrefix = "DSS_"
+
+// flagSet abstracts the flag sets of the standard library and of pflag.
+type flagSet interface {
+	// has returns whether the flag name is defined.
+	has(name string) bool
+	// changed returns whether the flag name was set on the command line.
+	changed(name string) bool
+	get(name string) string
+	set(name, value string) error
+	visitAll(fn func(name, value string))
+}
+
+type goFlagSet struct {
+	fs      *flag.FlagSet
+	visited map[string]bool
+}
+
+func newGoFlagSet(fs *flag.FlagSet) *goFlagSet {
+	s := &goFlagSet{fs: fs, visited: map[string]bool{}}
+	fs.Visit(func(f *flag.Flag) { s.visited[f.Name] = true })
+	return s
+}
+
+func (s *goFlagSet) has(name string) bool     { return s.fs.Lookup(name) != nil }
+func (s *goFlagSet) changed(name string) bool { return s.visited[name] }
+func (s *goFlagSet) get(name string) string {
+	return s.fs.Lookup(name).Value.String()
+}
+func (s *goFlagSet) set(name, value string) error {
+	return s.fs.Set(name, value)
+}
+func (s *goFlagSet) visitAll(fn func(name, value string)) {
+	s.fs.VisitAll(func(f *flag.Flag) { fn(f.Name, f.Value.String()) })
+}
+
+type pFlagSet struct {
+	fs *pflag.FlagSet
+}
+
+func (s pFlagSet) has(name string) bool { return s.fs.Lookup(name) != nil }
+func (s pFlagSet) changed(name string) bool {
+	return s.fs.Changed(name)
+}
+func (s pFlagSet) get(name string) string {
+	return s.fs.Lookup(name).Value.String()
+}
+func (s pFlagSet) set(name, value string) error {
+	return s.fs.Set(name, value)
+}
+func (s pFlagSet) visitAll(fn func(name, value string)) {
+	s.fs.VisitAll(func(f *pflag.Flag) { fn(f.Name, f.Value.String()) })
+}
+
+// LoadFlags sets the flags of fs which were not set on the command line from
+// their environment variable (see EnvPrefix) or, failing that, from the
+// configuration file whose path is the value of the flag fileFlag of fs, which
+// may itself be set on the command line or by its environment variable. No
+// configuration file is read if fileFlag is empty or the path is empty. fs
+// must have been parsed.
+//
+// The configuration file maps flag names to their value, lists being joined
+// with commas for the flags holding comma-separated values, for example:
+//
+//	addr: ":8080"
+//	enable_scd: true
+//	accepted_jwt_audiences: [dss.example.com, localhost]
+//	cockroach_host: crdb.example.com
+//
+// The configuration file may not set fileFlag itself, which it ignores so that
+// the output of PrintFlags may be loaded back. All the invalid values and
+// unknown names are reported in the returned error.
+func LoadFlags(fs *flag.FlagSet, fileFlag string) error {
+	return load(newGoFlagSet(fs), fileFlag)
+}
+
+// LoadPFlags is the equivalent of LoadFlags for a pflag flag set.
+func LoadPFlags(fs *pflag.FlagSet, fileFlag string) error {
+	return load(pFlagSet{fs: fs}, fileFlag)
+}
+
+// FlagValues returns the effective value of every flag of fs, by name.
+func FlagValues(fs *flag.FlagSet) map[string]string {
+	return values(newGoFlagSet(fs))
+}
+
+// PrintFlags writes the effective value of every flag of fs but the omitted
+// ones to w, in the format of the configuration file.
+func PrintFlags(w io.Writer, fs *flag.FlagSet, omitted ...string) error {
+	return printValues(w, newGoFlagSet(fs), omitted)
+}
+
+// PrintPFlags is the equivalent of PrintFlags for a pflag flag set.
+func PrintPFlags(w io.Writer, fs *pflag.FlagSet, omitted ...string) error {
+	return printValues(w, pFlagSet{fs: fs}, omitted)
+}
+
+func load(fs flagSet, fileFlag string) error {
+	var path string
+	if fileFlag != "" {
+		if !fs.has(fileFlag) {
+			return stacktrace.NewError("Unknown configuration file flag %s", fileFlag)
+		}
+		if value, set := os.LookupEnv(envName(fileFlag)); set && !fs.changed(fileFlag) {
+			if err := fs.set(fileFlag, value); err != nil {
+				return stacktrace.Propagate(err, "Invalid value of environment variable %s", envName(fileFlag))
+			}
+		}
+		path = fs.get(fileFlag)
+	}
+
+	fileValues := map[string]string{}
+	if path != "" {
+		var err error
+		fileValues, err = readFile(path)
+		if err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
+		}
+	}
+
+	var errs error
+	names := make([]string, 0, len(fileValues))
+	for name := range fileValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !fs.has(name) {
+			errs = multierr.Append(errs, fmt.Errorf("%s: unknown setting %s", path, name))
+		}
+	}
+
+	fs.visitAll(func(name, _ string) {
+		if fs.changed(name) || name == fileFlag {
+			return
+		}
+		source := path
+		value, ok := fileValues[name]
+		if envValue, set := os.LookupEnv(envName(name)); set {
+			source, value, ok = "environment variable "+envName(name), envValue, true
+		}
+		if !ok {
+			return
+		}
+		if err := fs.set(name, value); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("%s: invalid value %q for %s: %v", source, value, name, err))
+		}
+	})
+
+	if errs != nil {
+		return stacktrace.Propagate(errs, "Invalid configuration")
+	}
+	return nil
+}
+
+// envName returns the name of the environment variable setting the flag name.
+func envName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// readFile reads the flag values of the configuration file at path.
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error reading configuration file")
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, stacktrace.Propagate(err, "Error parsing configuration file %s", path)
+	}
+	result := map[string]string{}
+	if len(document.Content) == 0 {
+		// The file is empty.
+		return result, nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, stacktrace.NewError("Configuration file %s must map setting names to values", path)
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		name, value := root.Content[i].Value, root.Content[i+1]
+		switch value.Kind {
+		case yaml.ScalarNode:
+			result[name] = value.Value
+		case yaml.SequenceNode:
+			items := make([]string, len(value.Content))
+			for j, item := range value.Content {
+				if item.Kind != yaml.ScalarNode {
+					return nil, stacktrace.NewError("%s: items of %s must be scalar values (line %d)", path, name, item.Line)
+				}
+				items[j] = item.Value
+			}
+			result[name] = strings.Join(items, ",")
+		default:
+			return nil, stacktrace.NewError("%s: %s must be a scalar value or a list (line %d)", path, name, value.Line)
+		}
+	}
+	return result, nil
+}
+
+func values(fs flagSet) map[string]string {
+	result := map[string]string{}
+	fs.visitAll(func(name, value string) {
+		result[name] = value
+	})
+	return result
+}
+
+func printValues(w io.Writer, fs flagSet, omitted []string) error {
+	printed := values(fs)
+	for _, name := range omitted {
+		delete(printed, name)
+	}
+	// Marshalling a map sorts its keys.
+	content, err := yaml.Marshal(printed)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error encoding configuration")
+	}
+	if _, err := w.Write(content); err != nil {
+		return stacktrace.Propagate(err, "Error writing configuration")
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+type testFlags struct {
+	fs         *flag.FlagSet
+	configFile *string
+	addr       *string
+	enableSCD  *bool
+	timeout    *time.Duration
+	audiences  *string
+	maxResults *int
+}
+
+func newTestFlags() *testFlags {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	return &testFlags{
+		fs:         fs,
+		configFile: fs.String("config_file", "", ""),
+		addr:       fs.String("addr", ":8080", ""),
+		enableSCD:  fs.Bool("enable_scd", false, ""),
+		timeout:    fs.Duration("server_timeout", 10*time.Second, ""),
+		audiences:  fs.String("accepted_jwt_audiences", "", ""),
+		maxResults: fs.Int("max_result_limit", 10000, ""),
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadFlagsPrecedence(t *testing.T) {
+	path := writeFile(t, "dss.yaml", `
+addr: ":9090"
+enable_scd: true
+server_timeout: 30s
+accepted_jwt_audiences: [dss.example.com, localhost]
+max_result_limit: 100
+`)
+	t.Setenv("DSS_SERVER_TIMEOUT", "1m")
+	t.Setenv("DSS_ADDR", ":7070")
+
+	f := newTestFlags()
+	require.NoError(t, f.fs.Parse([]string{"--config_file", path, "--addr", ":6060"}))
+	require.NoError(t, LoadFlags(f.fs, "config_file"))
+
+	require.Equal(t, ":6060", *f.addr)
+	require.Equal(t, time.Minute, *f.timeout)
+	require.True(t, *f.enableSCD)
+	require.Equal(t, "dss.example.com,localhost", *f.audiences)
+	require.Equal(t, 100, *f.maxResults)
+}
+
+func TestLoadFlagsJSON(t *testing.T) {
+	path := writeFile(t, "dss.json", `{"enable_scd": true, "accepted_jwt_audiences": ["a", "b"]}`)
+	t.Setenv("DSS_CONFIG_FILE", path)
+
+	f := newTestFlags()
+	require.NoError(t, f.fs.Parse(nil))
+	require.NoError(t, LoadFlags(f.fs, "config_file"))
+
+	require.True(t, *f.enableSCD)
+	require.Equal(t, "a,b", *f.audiences)
+	require.Equal(t, ":8080", *f.addr)
+}
+
+func TestLoadFlagsWithoutFile(t *testing.T) {
+	t.Setenv("DSS_ENABLE_SCD", "true")
+
+	f := newTestFlags()
+	require.NoError(t, f.fs.Parse(nil))
+	require.NoError(t, LoadFlags(f.fs, "config_file"))
+
+	require.True(t, *f.enableSCD)
+}
+
+func TestLoadFlagsReportsAllErrors(t *testing.T) {
+	path := writeFile(t, "dss.yaml", `
+adress: ":9090"
+enable_scd: maybe
+`)
+	t.Setenv("DSS_MAX_RESULT_LIMIT", "many")
+
+	f := newTestFlags()
+	require.NoError(t, f.fs.Parse([]string{"--config_file", path}))
+	err := LoadFlags(f.fs, "config_file")
+	require.Error(t, err)
+	for _, expected := range []string{"unknown setting adress", "for enable_scd", "DSS_MAX_RESULT_LIMIT"} {
+		require.Contains(t, err.Error(), expected)
+	}
+}
+
+func TestLoadFlagsRejectsNestedValues(t *testing.T) {
+	for _, content := range []string{"- addr", "addr:\n  host: localhost", "addr: [[a]]"} {
+		path := writeFile(t, "dss.yaml", content)
+		f := newTestFlags()
+		require.NoError(t, f.fs.Parse([]string{"--config_file", path}))
+		require.Error(t, LoadFlags(f.fs, "config_file"), content)
+	}
+}
+
+func TestLoadFlagsMissingFile(t *testing.T) {
+	f := newTestFlags()
+	require.NoError(t, f.fs.Parse([]string{"--config_file", filepath.Join(t.TempDir(), "missing.yaml")}))
+	require.Error(t, LoadFlags(f.fs, "config_file"))
+}
+
+func TestLoadPFlags(t *testing.T) {
+	path := writeFile(t, "db-manager.yaml", "schemas_dir: /db-schemas/rid\ndb_version: 4.0.0\n")
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	configFile := fs.String("config_file", "", "")
+	schemasDir := fs.String("schemas_dir", "", "")
+	dbVersion := fs.String("db_version", "", "")
+	require.NoError(t, fs.Parse([]string{"--config_file", path, "--db_version", "latest"}))
+	require.NoError(t, LoadPFlags(fs, "config_file"))
+
+	require.Equal(t, path, *configFile)
+	require.Equal(t, "/db-schemas/rid", *schemasDir)
+	require.Equal(t, "latest", *dbVersion)
+}
+
+func TestPrintFlagsRoundTrip(t *testing.T) {
+	f := newTestFlags()
+	require.NoError(t, f.fs.Parse([]string{"--addr", ":9090", "--accepted_jwt_audiences", "a,b", "--server_timeout", "1m"}))
+
+	var printed bytes.Buffer
+	require.NoError(t, PrintFlags(&printed, f.fs, "max_result_limit"))
+	require.NotContains(t, printed.String(), "max_result_limit")
+	require.Contains(t, printed.String(), "addr: :9090\n")
+
+	path := writeFile(t, "dss.yaml", printed.String())
+	g := newTestFlags()
+	require.NoError(t, g.fs.Parse([]string{"--config_file", path}))
+	require.NoError(t, LoadFlags(g.fs, "config_file"))
+	expected := FlagValues(f.fs)
+	expected["config_file"] = path
+	expected["max_result_limit"] = "10000"
+	require.Equal(t, expected, FlagValues(g.fs))
+}
@@ -0,0 +1,3 @@
+// Package config combines the command line flags of the DSS commands with the
+// values of a YAML (or JSON) configuration file and of environment variables.
+package config
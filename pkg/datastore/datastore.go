@@ -59,6 +59,9 @@ func initDatastore(ctx context.Context, pool *pgxpool.Pool) (*Datastore, error)
 	if version.Type == Yugabyte {
 		return &Datastore{Version: version, Pool: pool}, nil
 	}
+	if version.Type == Postgres {
+		return &Datastore{Version: version, Pool: pool}, nil
+	}
 	return nil, stacktrace.NewError("%s is not implemented yet", version.Type)
 }
 
@@ -105,6 +108,9 @@ func (ds *Datastore) GetSchemaVersion(ctx context.Context, dbName string) (*semv
 	if ds.Version.Type == Yugabyte && dbName != ds.Pool.Config().ConnConfig.Database {
 		return nil, stacktrace.NewError("Yugabyte do not support switching databases with the same connection. Unable to retrieve schema version for database %s while connected to %s.", dbName, ds.Pool.Config().ConnConfig.Database)
 	}
+	if ds.Version.Type == Postgres && dbName != ds.Pool.Config().ConnConfig.Database {
+		return nil, stacktrace.NewError("PostgreSQL does not support cross-database references. Unable to retrieve schema version for database %s while connected to %s.", dbName, ds.Pool.Config().ConnConfig.Database)
+	}
 
 	var (
 		checkTableQuery = fmt.Sprintf(`
@@ -1,3 +1,3 @@
 // Package cockroach bundles up types and functions required to connect to
-// CRDB or Yugabyte instance.
+// CRDB, Yugabyte or PostgreSQL instance.
 package datastore
@@ -0,0 +1,52 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb"
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbpgxv5"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// serializationFailure is the SQLSTATE reported when a serializable
+	// transaction conflicts with a concurrent one.
+	serializationFailure = "40001"
+	// deadlockDetected is the SQLSTATE reported when a transaction is aborted
+	// to break a deadlock.
+	deadlockDetected = "40P01"
+)
+
+// ExecuteTx runs fn in a serializable transaction and retries the transaction
+// up to maxRetries times when it fails because of contention.
+//
+// CockroachDB and Yugabyte transactions are retried through a savepoint by
+// the cockroach-go library. PostgreSQL keeps the snapshot of a serializable
+// transaction across savepoints, so the whole transaction is restarted
+// instead.
+func (ds *Datastore) ExecuteTx(ctx context.Context, maxRetries int, fn func(pgx.Tx) error) error {
+	if ds.Version.Type != Postgres {
+		return crdbpgx.ExecuteTx(crdb.WithMaxRetries(ctx, maxRetries), ds.Pool, pgx.TxOptions{}, fn)
+	}
+
+	for retries := 0; ; retries++ {
+		err := pgx.BeginTxFunc(ctx, ds.Pool, pgx.TxOptions{IsoLevel: pgx.Serializable}, fn)
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		if retries >= maxRetries {
+			return stacktrace.Propagate(err, "Transaction failed after %d retries", retries)
+		}
+	}
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/interuss/stacktrace"
 	"go.uber.org/multierr"
 	"regexp"
+	"strings"
 )
 
 type Type string
@@ -12,6 +13,7 @@ type Type string
 const (
 	CockroachDB Type = "cockroachdb"
 	Yugabyte    Type = "yugabyte"
+	Postgres    Type = "postgres"
 )
 
 type Version struct {
@@ -22,11 +24,18 @@ type Version struct {
 var cockroachDBRegex = regexp.MustCompile(`v((0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*))`)
 var yugabyteRegex = regexp.MustCompile(`-YB-((0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*))`)
 
+// PostgreSQL versions only have major and minor components since version 10,
+// the patch component is therefore optional.
+var postgresRegex = regexp.MustCompile(`^PostgreSQL ((0|[1-9]\d*)\.(0|[1-9]\d*)(\.(0|[1-9]\d*))?)`)
+
 func parseVersion(fullVersion string, regex *regexp.Regexp) (*semver.Version, error) {
 	match := regex.FindStringSubmatch(fullVersion)
 	if len(match) < 2 {
 		return nil, stacktrace.NewError("Unable to extract version from %s using %s", fullVersion, regex.String())
 	}
+	if strings.Count(match[1], ".") == 1 {
+		return semver.NewVersion(match[1] + ".0")
+	}
 	return semver.NewVersion(match[1])
 }
 
@@ -41,5 +50,12 @@ func NewVersion(fullVersion string) (*Version, error) {
 		return &Version{v, Yugabyte}, nil
 	}
 
-	return nil, stacktrace.Propagate(multierr.Combine(err, err2), "Unable to extract datastore type and version")
+	// Yugabyte reports a PostgreSQL version as well, so vanilla PostgreSQL is
+	// only considered once the other datastores have been ruled out.
+	v, err3 := parseVersion(fullVersion, postgresRegex)
+	if err3 == nil {
+		return &Version{v, Postgres}, nil
+	}
+
+	return nil, stacktrace.Propagate(multierr.Combine(err, err2, err3), "Unable to extract datastore type and version")
 }
@@ -0,0 +1,54 @@
+package datastore
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewVersion(t *testing.T) {
+	cases := []struct {
+		name        string
+		fullVersion string
+		typ         Type
+		semVer      string
+	}{
+		{
+			name:        "cockroachdb",
+			fullVersion: "CockroachDB CCL v24.1.3 (x86_64-pc-linux-gnu, built 2024/07/15 18:13:05, go1.22.5 X:nocoverageredesign)",
+			typ:         CockroachDB,
+			semVer:      "24.1.3",
+		},
+		{
+			name:        "yugabyte",
+			fullVersion: "PostgreSQL 11.2-YB-2024.1.2.0-b0 on x86_64-pc-linux-gnu, compiled by clang version 17.0.6 (https://github.com/yugabyte/llvm-project.git 9b881774e40024e901fc6f3d313607b071c08631), 64-bit",
+			typ:         Yugabyte,
+			semVer:      "2024.1.2",
+		},
+		{
+			name:        "postgres",
+			fullVersion: "PostgreSQL 16.4 (Debian 16.4-1.pgdg120+1) on x86_64-pc-linux-gnu, compiled by gcc (Debian 12.2.0-14) 12.2.0, 64-bit",
+			typ:         Postgres,
+			semVer:      "16.4.0",
+		},
+		{
+			name:        "postgres with patch version",
+			fullVersion: "PostgreSQL 9.6.24 on x86_64-pc-linux-gnu, compiled by gcc (GCC) 4.8.5, 64-bit",
+			typ:         Postgres,
+			semVer:      "9.6.24",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := NewVersion(c.fullVersion)
+			require.NoError(t, err)
+			require.Equal(t, c.typ, v.Type)
+			require.Equal(t, c.semVer, v.SemVer.String())
+		})
+	}
+}
+
+func TestNewVersionUnknownDatastore(t *testing.T) {
+	_, err := NewVersion("MySQL 8.0.39")
+	require.Error(t, err)
+}
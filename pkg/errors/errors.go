@@ -42,6 +42,10 @@ const (
 
 	// Unauthenticated is used when an OAuth token is invalid or not supplied.
 	Unauthenticated
+
+	// Unavailable is used when a dependency required to serve a request, such
+	// as a store, cannot currently be reached.
+	Unavailable
 )
 
 func init() {
@@ -82,6 +82,11 @@ func DistanceMetersToAngle(distance float64) s1.Angle {
 	return s1.Angle(distance / radiusEarthMeter)
 }
 
+// CellUnionAreaKm2 returns the approximate area of cells in km².
+func CellUnionAreaKm2(cells s2.CellUnion) float64 {
+	return (cells.ApproxArea() * earthAreaKm2) / (4.0 * math.Pi)
+}
+
 func loopAreaKm2(loop *s2.Loop) float64 {
 	if loop.IsEmpty() {
 		return 0
@@ -195,6 +200,19 @@ func Covering(points []s2.Point) (s2.CellUnion, error) {
 // TODO(tvoss):
 // * Agree and implement a maximum number of points in area
 func AreaToCellIDs(area string) (s2.CellUnion, error) {
+	points, err := AreaToPoints(area)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	return Covering(points)
+}
+
+// AreaToPoints parses "area" in the format 'lat0,lon0,lat1,lon1,...' and
+// returns its vertices, or else:
+// * ErrOddNumberOfCoordinatesInAreaString
+// * ErrNotEnoughPointsInPolygon
+// * ErrBadCoordSet
+func AreaToPoints(area string) ([]s2.Point, error) {
 	var (
 		lat, lng float64
 		points   = []s2.Point{}
@@ -230,5 +248,5 @@ func AreaToCellIDs(area string) (s2.CellUnion, error) {
 
 		counter++
 	}
-	return Covering(points)
+	return points, nil
 }
@@ -0,0 +1,62 @@
+// Package cockroach implements the lease store of the leader election on a
+// CockroachDB database.
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	dssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgx/v5"
+)
+
+// LeaseStore is an implementation of leader.LeaseStore on the job_leases
+// table of the database q is connected to. Lease expirations are computed
+// from the time of the database, so that the clocks of the DSS instances need
+// not be synchronized.
+type LeaseStore struct {
+	q dssql.Queryable
+}
+
+// NewLeaseStore returns a LeaseStore querying q.
+func NewLeaseStore(q dssql.Queryable) *LeaseStore {
+	return &LeaseStore{q: q}
+}
+
+// AcquireLease implements leader.LeaseStore.
+func (s *LeaseStore) AcquireLease(ctx context.Context, name string, holder string, duration time.Duration) (bool, error) {
+	const query = `
+		INSERT INTO
+			job_leases
+			(name, holder, expires_at)
+		VALUES
+			($1, $2, transaction_timestamp() + $3::INT8 * INTERVAL '1 millisecond')
+		ON CONFLICT (name) DO UPDATE
+			SET (holder, expires_at) = (excluded.holder, excluded.expires_at)
+			WHERE job_leases.holder = excluded.holder OR job_leases.expires_at <= transaction_timestamp()
+		RETURNING
+			holder`
+
+	var current string
+	err := s.q.QueryRow(ctx, query, name, holder, duration.Milliseconds()).Scan(&current)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// The lease is held by another holder.
+		return false, nil
+	case err != nil:
+		return false, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return current == holder, nil
+}
+
+// ReleaseLease implements leader.LeaseStore.
+func (s *LeaseStore) ReleaseLease(ctx context.Context, name string, holder string) error {
+	const query = `DELETE FROM job_leases WHERE name = $1 AND holder = $2`
+
+	if _, err := s.q.Exec(ctx, query, name, holder); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+// Package leader elects, among the DSS instances of a pool, the one running a
+// periodic job.
+//
+// Instances compete for a lease named after the job in a datastore shared by
+// the pool. The instance holding an unexpired lease is the leader of the job
+// and renews the lease every time it runs the job; the other instances skip
+// their runs until the leader fails to renew its lease before it expires.
+package leader
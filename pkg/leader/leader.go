@@ -0,0 +1,103 @@
+package leader
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/interuss/stacktrace"
+	"github.com/robfig/cron/v3"
+)
+
+// LeaseStore persists the leases through which DSS instances elect the leader
+// of each job.
+type LeaseStore interface {
+	// AcquireLease grants the lease on name to holder until duration from now
+	// if the lease is free, expired or already held by holder, and returns
+	// whether holder holds the lease.
+	AcquireLease(ctx context.Context, name string, holder string, duration time.Duration) (bool, error)
+
+	// ReleaseLease frees the lease on name if it is held by holder.
+	ReleaseLease(ctx context.Context, name string, holder string) error
+}
+
+// Elector campaigns on behalf of a DSS instance for the leadership of jobs.
+type Elector struct {
+	// Store is the LeaseStore shared by the DSS instances of the pool.
+	Store LeaseStore
+	// Holder identifies the DSS instance uniquely within the pool.
+	Holder string
+	// LeaseDuration is the time for which leadership is held after each run
+	// of a job. It should exceed the period of the jobs, so that the leader
+	// keeps its leadership from one run to the next.
+	LeaseDuration time.Duration
+
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+// IsLeader acquires or renews the lease on the job name, and returns whether
+// this instance is the leader of the job.
+func (e *Elector) IsLeader(ctx context.Context, name string) (bool, error) {
+	leader, err := e.Store.AcquireLease(ctx, name, e.Holder, e.LeaseDuration)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "Failed to acquire lease on %s", name)
+	}
+	e.setHeld(name, leader)
+	return leader, nil
+}
+
+// Resign releases the leadership of the job name, if held, so that another
+// instance may take over without waiting for the lease to expire.
+func (e *Elector) Resign(ctx context.Context, name string) error {
+	if err := e.Store.ReleaseLease(ctx, name, e.Holder); err != nil {
+		return stacktrace.Propagate(err, "Failed to release lease on %s", name)
+	}
+	e.setHeld(name, false)
+	return nil
+}
+
+// Held returns the names of the jobs whose leadership this instance acquired
+// and has not resigned, in alphabetical order.
+func (e *Elector) Held() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	names := make([]string, 0, len(e.held))
+	for name := range e.held {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (e *Elector) setHeld(name string, held bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !held {
+		delete(e.held, name)
+		return
+	}
+	if e.held == nil {
+		e.held = map[string]bool{}
+	}
+	e.held[name] = true
+}
+
+// OnlyIfLeader returns a cron.JobWrapper running jobs only when this instance
+// is the leader of the job name.
+func (e *Elector) OnlyIfLeader(ctx context.Context, name string, logger cron.Logger) cron.JobWrapper {
+	return func(j cron.Job) cron.Job {
+		return cron.FuncJob(func() {
+			leader, err := e.IsLeader(ctx, name)
+			switch {
+			case err != nil:
+				logger.Error(err, "skip", "job", name)
+			case !leader:
+				logger.Info("skip", "job", name, "reason", "not leader")
+			default:
+				j.Run()
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/leader/memory"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElection(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		store  = memory.NewLeaseStore()
+		first  = &Elector{Store: store, Holder: "first", LeaseDuration: time.Hour}
+		second = &Elector{Store: store, Holder: "second", LeaseDuration: time.Hour}
+	)
+
+	leader, err := first.IsLeader(ctx, "job")
+	require.NoError(t, err)
+	require.True(t, leader)
+	leader, err = second.IsLeader(ctx, "job")
+	require.NoError(t, err)
+	require.False(t, leader)
+
+	// Leases are per job, and renewed by their holder.
+	leader, err = second.IsLeader(ctx, "other job")
+	require.NoError(t, err)
+	require.True(t, leader)
+	leader, err = first.IsLeader(ctx, "job")
+	require.NoError(t, err)
+	require.True(t, leader)
+
+	require.Equal(t, []string{"job"}, first.Held())
+	require.Equal(t, []string{"other job"}, second.Held())
+
+	require.NoError(t, second.Resign(ctx, "job"))
+	leader, err = second.IsLeader(ctx, "job")
+	require.NoError(t, err)
+	require.False(t, leader)
+
+	require.NoError(t, first.Resign(ctx, "job"))
+	require.Empty(t, first.Held())
+	leader, err = second.IsLeader(ctx, "job")
+	require.NoError(t, err)
+	require.True(t, leader)
+	require.Equal(t, []string{"job", "other job"}, second.Held())
+}
+
+func TestExpiredLeaseIsTakenOver(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		store  = memory.NewLeaseStore()
+		first  = &Elector{Store: store, Holder: "first", LeaseDuration: 0}
+		second = &Elector{Store: store, Holder: "second", LeaseDuration: time.Hour}
+	)
+
+	leader, err := first.IsLeader(ctx, "job")
+	require.NoError(t, err)
+	require.True(t, leader)
+	leader, err = second.IsLeader(ctx, "job")
+	require.NoError(t, err)
+	require.True(t, leader)
+	leader, err = first.IsLeader(ctx, "job")
+	require.NoError(t, err)
+	require.False(t, leader)
+}
+
+func TestOnlyIfLeader(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		store  = memory.NewLeaseStore()
+		first  = &Elector{Store: store, Holder: "first", LeaseDuration: time.Hour}
+		second = &Elector{Store: store, Holder: "second", LeaseDuration: time.Hour}
+		runs   = map[string]int{}
+	)
+
+	for i := 0; i < 2; i++ {
+		for _, e := range []*Elector{first, second} {
+			holder := e.Holder
+			job := cron.NewChain(e.OnlyIfLeader(ctx, "job", cron.DiscardLogger)).Then(cron.FuncJob(func() { runs[holder]++ }))
+			job.Run()
+		}
+	}
+	require.Equal(t, map[string]int{"first": 2}, runs)
+}
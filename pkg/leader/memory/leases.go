@@ -0,0 +1,55 @@
+// Package memory implements the lease store of the leader election in process
+// memory.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+type lease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// LeaseStore is an implementation of leader.LeaseStore keeping its data in
+// process memory.
+type LeaseStore struct {
+	clock clockwork.Clock
+
+	mu     sync.Mutex
+	leases map[string]lease
+}
+
+// NewLeaseStore returns a LeaseStore without any lease.
+func NewLeaseStore() *LeaseStore {
+	return &LeaseStore{
+		clock:  clockwork.NewRealClock(),
+		leases: map[string]lease{},
+	}
+}
+
+// AcquireLease implements leader.LeaseStore.
+func (s *LeaseStore) AcquireLease(ctx context.Context, name string, holder string, duration time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	if l, ok := s.leases[name]; ok && l.holder != holder && l.expiresAt.After(now) {
+		return false, nil
+	}
+	s.leases[name] = lease{holder: holder, expiresAt: now.Add(duration)}
+	return true, nil
+}
+
+// ReleaseLease implements leader.LeaseStore.
+func (s *LeaseStore) ReleaseLease(ctx context.Context, name string, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l, ok := s.leases[name]; ok && l.holder == holder {
+		delete(s.leases, name)
+	}
+	return nil
+}
@@ -2,14 +2,73 @@ package logging
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/stacktrace"
 	"go.uber.org/zap"
 )
 
+// Redacted replaces the redacted header and JSON field values in logs.
+const Redacted = "REDACTED"
+
+// HTTPConfiguration configures the logging of HTTP requests by
+// HTTPMiddleware.
+type HTTPConfiguration struct {
+	// Dump logs the full body of requests and responses.
+	Dump bool
+	// RedactedHeaders are the names of the request headers whose value is
+	// redacted.
+	RedactedHeaders []string
+	// RedactedFields are the names of the JSON object fields whose value is
+	// redacted, at any depth, from dumped bodies.
+	RedactedFields []string
+	// SampleRatios maps route path templates to the fraction of their
+	// requests which are logged. The requests of other routes are all logged.
+	SampleRatios map[string]float64
+	// MinDuration is the duration below which requests are not logged.
+	MinDuration time.Duration
+}
+
+// DefaultHTTPConfiguration logs every request, redacting credentials and, in
+// dumped bodies, OVNs.
+var DefaultHTTPConfiguration = HTTPConfiguration{
+	RedactedHeaders: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"},
+	RedactedFields:  []string{"ovn", "key", "access_token", "refresh_token", "client_secret"},
+}
+
+// ParseSampleRatios parses the sample ratios of HTTPConfiguration from a
+// comma-separated list of <route path template>=<ratio> pairs.
+func ParseSampleRatios(s string) (map[string]float64, error) {
+	ratios := map[string]float64{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		route, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, stacktrace.NewError("Missing sample ratio of route in `%s`", pair)
+		}
+		ratio, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Invalid sample ratio of route %s", route)
+		}
+		if ratio < 0 || ratio > 1 {
+			return nil, stacktrace.NewError("Sample ratio of route %s must be between 0 and 1", route)
+		}
+		ratios[strings.TrimSpace(route)] = ratio
+	}
+	return ratios, nil
+}
+
 type tracingResponseWriter struct {
 	next       http.ResponseWriter
 	statusCode int
@@ -40,29 +99,45 @@ func (w *tracingResponseWriter) WriteHeader(statusCode int) {
 }
 
 // HTTPMiddleware installs a logging http.Handler that logs requests and
-// selected aspects of responses to 'logger'.
-func HTTPMiddleware(logger *zap.Logger, dump bool, handler http.Handler) http.Handler {
+// selected aspects of responses to 'logger', along with the route and the
+// authorized client of the operation handling them. Requests failing with a
+// server error are always logged; other requests are subject to the sampling
+// and minimum duration of configuration.
+func HTTPMiddleware(logger *zap.Logger, configuration HTTPConfiguration, handler http.Handler) http.Handler {
+	var (
+		redactedHeaders = make(map[string]bool, len(configuration.RedactedHeaders))
+		redactedFields  = make(map[string]bool, len(configuration.RedactedFields))
+	)
+	for _, name := range configuration.RedactedHeaders {
+		redactedHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	for _, name := range configuration.RedactedFields {
+		redactedFields[strings.ToLower(name)] = true
+	}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var (
-			logger = logger
+			logger = WithValuesFromContext(r.Context(), logger)
 			start  = time.Now()
 			trw    = &tracingResponseWriter{
-				dumpData: dump,
+				dumpData: configuration.Dump,
 				data:     new(bytes.Buffer),
 				next:     w,
 			}
+			reqData []byte
 		)
+		r = api.WithAuthorizationRecorder(api.WithRouteRecorder(r))
 
-		if dump {
+		if configuration.Dump {
 			// dump request in logs
-			reqData, err := io.ReadAll(r.Body)
+			var err error
+			reqData, err = io.ReadAll(r.Body)
 			if err != nil {
 				logger = logger.With(zap.NamedError("req_dump_err", err))
 			} else {
 				if err := r.Body.Close(); err != nil {
 					logger = logger.With(zap.NamedError("req_dump_err", err))
 				}
-				logger = logger.With(zap.ByteString("req_dump", reqData))
 
 				// replace req.Body with a copy
 				r.Body = io.NopCloser(bytes.NewReader(reqData))
@@ -71,24 +146,106 @@ func HTTPMiddleware(logger *zap.Logger, dump bool, handler http.Handler) http.Ha
 
 		handler.ServeHTTP(trw, r)
 
-		if dump {
-			// dump response in logs
-			respData, err := io.ReadAll(trw.data)
-			if err != nil {
-				logger = logger.With(zap.NamedError("resp_dump_err", err))
-			} else {
-				logger = logger.With(zap.ByteString("resp_dump", respData))
+		duration := time.Since(start)
+		route := api.MatchedRoute(r)
+		if trw.statusCode < http.StatusInternalServerError {
+			if duration < configuration.MinDuration {
+				return
+			}
+			if route != nil {
+				if ratio, ok := configuration.SampleRatios[route.Path]; ok && rand.Float64() >= ratio {
+					return
+				}
 			}
 		}
 
-		logger.Info(
-			fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, r.Proto),
-			zap.Any("req_headers", r.Header),
+		if configuration.Dump {
+			// dump request and response in logs
+			logger = logger.With(
+				zap.ByteString("req_dump", redactJSON(reqData, redactedFields)),
+				zap.ByteString("resp_dump", redactJSON(trw.data.Bytes(), redactedFields)))
+		}
+
+		fields := []zap.Field{
+			zap.Any("req_headers", redactHeaders(r.Header, redactedHeaders)),
 			zap.Int("resp_status_code", trw.statusCode),
 			zap.String("resp_status_text", http.StatusText(trw.statusCode)),
 			zap.String("peer_address", r.RemoteAddr),
 			zap.Time("start_time", start),
-			zap.Duration("duration", time.Since(start)),
-		)
+			zap.Duration("duration", duration),
+		}
+		if route != nil {
+			fields = append(fields, zap.String("route", route.Path))
+		}
+		if auth := api.RecordedAuthorization(r); auth != nil {
+			if auth.ClientID != nil {
+				fields = append(fields, zap.String("client_id", *auth.ClientID))
+			}
+			if auth.Scopes != nil {
+				fields = append(fields, zap.Strings("scopes", auth.Scopes))
+			}
+			if auth.Error != nil {
+				fields = append(fields, zap.String("auth_error", auth.Error.Error()))
+			}
+		}
+
+		logger.Info(fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, r.Proto), fields...)
 	})
 }
+
+// redactHeaders returns a copy of header in which the values of the redacted
+// headers are replaced.
+func redactHeaders(header http.Header, redacted map[string]bool) http.Header {
+	result := make(http.Header, len(header))
+	for name, values := range header {
+		if redacted[name] {
+			values = []string{Redacted}
+		}
+		result[name] = values
+	}
+	return result
+}
+
+// redactJSON returns data with the values of the redacted object fields
+// replaced if data is a JSON document. Otherwise, as the redacted fields cannot
+// be found, it returns a placeholder rather than data.
+func redactJSON(data []byte, redacted map[string]bool) []byte {
+	if len(redacted) == 0 || len(bytes.TrimSpace(data)) == 0 {
+		return data
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return unparseableBody(data)
+	}
+	result, err := json.Marshal(redactValue(document, redacted))
+	if err != nil {
+		return unparseableBody(data)
+	}
+	return result
+}
+
+// unparseableBody returns the placeholder logged instead of data when it
+// cannot be redacted.
+func unparseableBody(data []byte) []byte {
+	return []byte(fmt.Sprintf("<unparseable body, %d bytes>", len(data)))
+}
+
+func redactValue(value interface{}, redacted map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			if redacted[strings.ToLower(field)] {
+				v[field] = Redacted
+			} else {
+				v[field] = redactValue(fieldValue, redacted)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, redacted)
+		}
+	}
+	return value
+}
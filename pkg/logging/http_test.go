@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestHandler(configuration HTTPConfiguration, status int) (http.Handler, *observer.ObservedLogs) {
+	core, logs := observer.New(zap.InfoLevel)
+	clientID := "uss1"
+	router := api.NewRouter(&api.Route{
+		Method: http.MethodPut,
+		Path:   "/things/{id}",
+		Handler: func(params api.PathParams, w http.ResponseWriter, r *http.Request) {
+			api.RecordAuthorization(r, api.AuthorizationResult{ClientID: &clientID, Scopes: []string{"things.write"}})
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(status)
+			_, _ = w.Write(body)
+		},
+	})
+	return HTTPMiddleware(zap.New(core), configuration, router), logs
+}
+
+func serveThing(handler http.Handler) {
+	r := httptest.NewRequest(http.MethodPut, "/things/1", strings.NewReader(`{"thing":{"ovn":"secret","id":1},"key":["ovn1"]}`))
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set("User-Agent", "test")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+}
+
+func TestHTTPMiddlewareRedacts(t *testing.T) {
+	configuration := DefaultHTTPConfiguration
+	configuration.Dump = true
+	handler, logs := newTestHandler(configuration, http.StatusOK)
+
+	serveThing(handler)
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	headers := fields["req_headers"].(http.Header)
+	require.Equal(t, Redacted, headers.Get("Authorization"))
+	require.Equal(t, "test", headers.Get("User-Agent"))
+	expectedDump := `{"key":"REDACTED","thing":{"id":1,"ovn":"REDACTED"}}`
+	require.Equal(t, expectedDump, fields["req_dump"])
+	require.Equal(t, expectedDump, fields["resp_dump"])
+	require.Equal(t, "/things/{id}", fields["route"])
+	require.Equal(t, "uss1", fields["client_id"])
+	require.Equal(t, []interface{}{"things.write"}, fields["scopes"])
+}
+
+func TestRedactJSONHidesUnparseableBodies(t *testing.T) {
+	redacted := map[string]bool{"ovn": true}
+
+	require.Equal(t, `{"ovn":"REDACTED"}`, string(redactJSON([]byte(`{"ovn":"secret"}`), redacted)))
+	require.Equal(t, "<unparseable body, 15 bytes>", string(redactJSON([]byte(`{"ovn":"secret"`), redacted)))
+	require.Equal(t, `{"ovn":"secret"`, string(redactJSON([]byte(`{"ovn":"secret"`), nil)))
+}
+
+func TestHTTPMiddlewareSamples(t *testing.T) {
+	handler, logs := newTestHandler(HTTPConfiguration{SampleRatios: map[string]float64{"/things/{id}": 0}}, http.StatusOK)
+	serveThing(handler)
+	require.Equal(t, 0, logs.Len())
+
+	handler, logs = newTestHandler(HTTPConfiguration{SampleRatios: map[string]float64{"/things/{id}": 0}}, http.StatusInternalServerError)
+	serveThing(handler)
+	require.Equal(t, 1, logs.Len())
+
+	handler, logs = newTestHandler(HTTPConfiguration{SampleRatios: map[string]float64{"/other": 0}}, http.StatusOK)
+	serveThing(handler)
+	require.Equal(t, 1, logs.Len())
+}
+
+func TestHTTPMiddlewareLogsSlowRequestsOnly(t *testing.T) {
+	handler, logs := newTestHandler(HTTPConfiguration{MinDuration: time.Hour}, http.StatusOK)
+	serveThing(handler)
+	require.Equal(t, 0, logs.Len())
+
+	handler, logs = newTestHandler(HTTPConfiguration{MinDuration: time.Hour}, http.StatusBadGateway)
+	serveThing(handler)
+	require.Equal(t, 1, logs.Len())
+}
+
+func TestParseSampleRatios(t *testing.T) {
+	ratios, err := ParseSampleRatios(" /rid/v2/dss/identification_service_areas=0.01, /v1/dss/subscriptions/{subscriptionid}=1,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{
+		"/rid/v2/dss/identification_service_areas": 0.01,
+		"/v1/dss/subscriptions/{subscriptionid}":   1,
+	}, ratios)
+
+	for _, invalid := range []string{"/things", "/things=x", "/things=2"} {
+		_, err := ParseSampleRatios(invalid)
+		require.Error(t, err, invalid)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"os"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 var (
-	// DefaultLevel is the default log level.
+	// DefaultLevel is the default log level. It is shared by Logger, so that
+	// setting it changes the level of Logger at runtime.
 	DefaultLevel = zap.NewAtomicLevelAt(zapcore.InfoLevel)
 	// DefaultFormat is the default log format.
 	DefaultFormat = FormatJSON
@@ -77,6 +79,8 @@ func Configure(level string, format string) error {
 // WithValuesFromContext augments logger with relevant fields from ctx and returns
 // the resulting logger.
 func WithValuesFromContext(ctx context.Context, logger *zap.Logger) *zap.Logger {
-	// Naive implementation for now, meant to evolve over time.
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		logger = logger.With(zap.Stringer("trace_id", sc.TraceID()), zap.Stringer("span_id", sc.SpanID()))
+	}
 	return logger
 }
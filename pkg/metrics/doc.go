@@ -0,0 +1,6 @@
+// Package metrics defines the Prometheus metrics exposed by the DSS and the
+// helpers used to record them.
+//
+// All metrics are registered with the default Prometheus registry and are
+// prefixed with the "dss" namespace.
+package metrics
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/interuss/dss/pkg/api"
+)
+
+// unmatchedRoute is the route label of requests not handled by any api.Route.
+const unmatchedRoute = "unmatched"
+
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusRecorder) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *statusRecorder) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// HTTPMiddleware installs an http.Handler recording the number, latency and
+// status codes of the requests served by handler. Requests are labelled with
+// the path template of the api.Route that handled them.
+func HTTPMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			start = time.Now()
+			srw   = &statusRecorder{ResponseWriter: w}
+		)
+		r = api.WithRouteRecorder(r)
+
+		handler.ServeHTTP(srw, r)
+
+		route := unmatchedRoute
+		if matched := api.MatchedRoute(r); matched != nil {
+			route = matched.Path
+		}
+		if srw.statusCode == 0 {
+			srw.statusCode = http.StatusOK
+		}
+		httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(srw.statusCode)).Inc()
+		httpRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
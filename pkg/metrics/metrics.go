@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "dss"
+
+// Outcomes of an attempt to deliver a notification to a USS.
+const (
+	NotificationDelivered = "delivered"
+	NotificationRetried   = "retried"
+	NotificationDropped   = "dropped"
+	NotificationThrottled = "throttled"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests handled, by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Time spent handling HTTP requests, by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	transactionRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "datastore",
+		Name:      "transaction_retries_total",
+		Help:      "Number of times a transaction was retried, by store.",
+	}, []string{"store"})
+
+	garbageCollectorRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "garbage_collector",
+		Name:      "runs_total",
+		Help:      "Number of garbage collector runs, by store and result.",
+	}, []string{"store", "result"})
+
+	garbageCollectorDeletions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "garbage_collector",
+		Name:      "deletions_total",
+		Help:      "Number of expired entities deleted by the garbage collector, by store and entity.",
+	}, []string{"store", "entity"})
+
+	authFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "failures_total",
+		Help:      "Number of requests rejected by the authorizer, by reason.",
+	}, []string{"reason"})
+
+	rateLimitedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "rate_limited_requests_total",
+		Help:      "Number of requests rejected because their client exceeded its rate limit, by route class.",
+	}, []string{"class"})
+
+	shedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "shed_requests_total",
+		Help:      "Number of requests shed because of the saturation of the connection pool of a database, by database.",
+	}, []string{"database"})
+
+	notifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notifications",
+		Name:      "processed_total",
+		Help:      "Number of notification delivery attempts, by outbox and result.",
+	}, []string{"outbox", "result"})
+)
+
+// Handler returns the http.Handler serving the metrics in the Prometheus
+// exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// TransactionRetried records that a transaction of store had to be retried.
+func TransactionRetried(store string) {
+	transactionRetries.WithLabelValues(store).Inc()
+}
+
+// GarbageCollectorRan records a garbage collector run on store, successful
+// if err is nil.
+func GarbageCollectorRan(store string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	garbageCollectorRuns.WithLabelValues(store, result).Inc()
+}
+
+// GarbageCollected records that the garbage collector deleted count expired
+// entities of store.
+func GarbageCollected(store string, entity string, count int) {
+	garbageCollectorDeletions.WithLabelValues(store, entity).Add(float64(count))
+}
+
+// AuthorizationFailed records a request rejected by the authorizer for reason.
+func AuthorizationFailed(reason string) {
+	authFailures.WithLabelValues(reason).Inc()
+}
+
+// RequestRateLimited records a request of class rejected because its client
+// exceeded its rate limit.
+func RequestRateLimited(class string) {
+	rateLimitedRequests.WithLabelValues(class).Inc()
+}
+
+// RequestShed records a request shed because the connection pool of database
+// was saturated.
+func RequestShed(database string) {
+	shedRequests.WithLabelValues(database).Inc()
+}
+
+// NotificationProcessed records the result of an attempt to deliver a
+// notification of outbox.
+func NotificationProcessed(outbox string, result string) {
+	notifications.WithLabelValues(outbox, result).Inc()
+}
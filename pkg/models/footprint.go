@@ -0,0 +1,173 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/interuss/dss/pkg/geo"
+	"github.com/interuss/stacktrace"
+)
+
+// encodedFootprint is the compact form in which submitted footprints are
+// persisted next to their cells.
+type encodedFootprint struct {
+	// Polygon holds the [lat, lng] vertices of a GeoPolygon.
+	Polygon [][2]float64 `json:"p,omitempty"`
+	// Center holds the [lat, lng] center of a GeoCircle.
+	Center *[2]float64 `json:"c,omitempty"`
+	// RadiusMeter holds the radius of a GeoCircle.
+	RadiusMeter float32 `json:"r,omitempty"`
+}
+
+// EncodeFootprint returns the compact form of footprint, or nil if footprint
+// is nil or is not a GeoPolygon or GeoCircle (e.g. a footprint reconstructed
+// from cells).
+func EncodeFootprint(footprint Geometry) ([]byte, error) {
+	var encoded encodedFootprint
+	switch t := footprint.(type) {
+	case *GeoPolygon:
+		if t == nil {
+			return nil, nil
+		}
+		encoded.Polygon = make([][2]float64, 0, len(t.Vertices))
+		for _, v := range t.Vertices {
+			encoded.Polygon = append(encoded.Polygon, [2]float64{v.Lat, v.Lng})
+		}
+	case *GeoCircle:
+		if t == nil {
+			return nil, nil
+		}
+		encoded.Center = &[2]float64{t.Center.Lat, t.Center.Lng}
+		encoded.RadiusMeter = t.RadiusMeter
+	default:
+		return nil, nil
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error encoding footprint")
+	}
+	return data, nil
+}
+
+// DecodeFootprint returns the footprint stored in data by EncodeFootprint, or
+// nil if data is empty.
+func DecodeFootprint(data []byte) (Geometry, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var encoded encodedFootprint
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, stacktrace.Propagate(err, "Error decoding footprint")
+	}
+
+	switch {
+	case encoded.Center != nil:
+		return &GeoCircle{
+			Center:      LatLngPoint{Lat: encoded.Center[0], Lng: encoded.Center[1]},
+			RadiusMeter: encoded.RadiusMeter,
+		}, nil
+	case len(encoded.Polygon) > 0:
+		result := &GeoPolygon{Vertices: make([]*LatLngPoint, 0, len(encoded.Polygon))}
+		for _, v := range encoded.Polygon {
+			result.Vertices = append(result.Vertices, &LatLngPoint{Lat: v[0], Lng: v[1]})
+		}
+		return result, nil
+	}
+	return nil, nil
+}
+
+// FootprintsIntersect reports whether the exact outlines of a and b share at
+// least one point. Footprints that cannot be evaluated exactly (nil, derived
+// from cells, or degenerate) are conservatively reported as intersecting so
+// that callers refining a cell-based search never drop a candidate they
+// cannot rule out.
+func FootprintsIntersect(a, b Geometry) bool {
+	loopA, capA, okA := exactShape(a)
+	loopB, capB, okB := exactShape(b)
+	if !okA || !okB {
+		return true
+	}
+
+	switch {
+	case loopA != nil && loopB != nil:
+		return s2.PolygonFromLoops([]*s2.Loop{loopA}).Intersects(s2.PolygonFromLoops([]*s2.Loop{loopB}))
+	case loopA != nil:
+		return loopIntersectsCap(loopA, capB)
+	case loopB != nil:
+		return loopIntersectsCap(loopB, capA)
+	default:
+		return capA.center.Distance(capB.center) <= capA.radius+capB.radius
+	}
+}
+
+type exactCap struct {
+	center s2.Point
+	radius s1.Angle
+}
+
+// exactShape converts g into either a loop enclosing the smaller of the two
+// areas its vertices delimit, or a spherical cap. ok is false when g cannot be
+// represented exactly.
+func exactShape(g Geometry) (loop *s2.Loop, circle exactCap, ok bool) {
+	switch t := g.(type) {
+	case *GeoPolygon:
+		if t == nil || len(t.Vertices) < 3 {
+			return nil, circle, false
+		}
+		points := make([]s2.Point, 0, len(t.Vertices))
+		for _, v := range t.Vertices {
+			points = append(points, s2.PointFromLatLng(s2.LatLngFromDegrees(v.Lat, v.Lng)))
+		}
+		loop = s2.LoopFromPoints(points)
+		if loop.Validate() != nil {
+			return nil, circle, false
+		}
+		loop.Normalize()
+		if loop.Area() <= 0 {
+			return nil, circle, false
+		}
+		return loop, circle, true
+	case *GeoCircle:
+		if t == nil || !(t.RadiusMeter > 0) {
+			return nil, circle, false
+		}
+		return nil, exactCap{
+			center: s2.PointFromLatLng(s2.LatLngFromDegrees(t.Center.Lat, t.Center.Lng)),
+			radius: geo.DistanceMetersToAngle(float64(t.RadiusMeter)),
+		}, true
+	}
+	return nil, circle, false
+}
+
+// loopIntersectsCap reports whether the area enclosed by loop shares a point
+// with c.
+func loopIntersectsCap(loop *s2.Loop, c exactCap) bool {
+	if loop.ContainsPoint(c.center) {
+		return true
+	}
+	n := loop.NumVertices()
+	for i := 0; i < n; i++ {
+		if s2.DistanceFromSegment(c.center, loop.Vertex(i), loop.Vertex((i+1)%n)) <= c.radius {
+			return true
+		}
+	}
+	return false
+}
+
+// GeoPolygonFromArea parses "area" in the format 'lat0,lon0,lat1,lon1,...'
+// used by search endpoints into a GeoPolygon.
+func GeoPolygonFromArea(area string) (*GeoPolygon, error) {
+	points, err := geo.AreaToPoints(area)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	result := &GeoPolygon{Vertices: make([]*LatLngPoint, 0, len(points))}
+	for _, p := range points {
+		ll := s2.LatLngFromPoint(p)
+		result.Vertices = append(result.Vertices, &LatLngPoint{Lat: ll.Lat.Degrees(), Lng: ll.Lng.Degrees()})
+	}
+	return result, nil
+}
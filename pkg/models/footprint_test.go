@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func triangle(lat, lng float64) *GeoPolygon {
+	return &GeoPolygon{Vertices: []*LatLngPoint{
+		{Lat: lat, Lng: lng},
+		{Lat: lat + 0.0001, Lng: lng},
+		{Lat: lat, Lng: lng + 0.0001},
+	}}
+}
+
+func TestFootprintEncoding(t *testing.T) {
+	for _, footprint := range []Geometry{
+		triangle(37.427, -122.17),
+		&GeoCircle{Center: LatLngPoint{Lat: 37.427, Lng: -122.169}, RadiusMeter: 50},
+	} {
+		data, err := EncodeFootprint(footprint)
+		require.NoError(t, err)
+		decoded, err := DecodeFootprint(data)
+		require.NoError(t, err)
+		require.Equal(t, footprint, decoded)
+	}
+
+	// Footprints without compact form are not stored.
+	data, err := EncodeFootprint(GeometryFunc(nil))
+	require.NoError(t, err)
+	require.Nil(t, data)
+	decoded, err := DecodeFootprint(nil)
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+}
+
+func TestFootprintsIntersect(t *testing.T) {
+	var (
+		a           = triangle(37.427, -122.17)
+		overlapping = triangle(37.42705, -122.16995)
+		disjoint    = triangle(37.427, -122.1695)
+		farCircle   = &GeoCircle{Center: LatLngPoint{Lat: 37.427, Lng: -122.169}, RadiusMeter: 50}
+		nearCircle  = &GeoCircle{Center: LatLngPoint{Lat: 37.427, Lng: -122.169}, RadiusMeter: 100}
+		inside      = &GeoCircle{Center: LatLngPoint{Lat: 37.42702, Lng: -122.16998}, RadiusMeter: 1}
+	)
+
+	require.True(t, FootprintsIntersect(a, overlapping))
+	require.False(t, FootprintsIntersect(a, disjoint))
+	require.False(t, FootprintsIntersect(a, farCircle))
+	require.True(t, FootprintsIntersect(nearCircle, a))
+	require.True(t, FootprintsIntersect(a, inside))
+	require.True(t, FootprintsIntersect(farCircle, nearCircle))
+	require.False(t, FootprintsIntersect(inside, farCircle))
+
+	// Unknown footprints are never ruled out.
+	require.True(t, FootprintsIntersect(nil, a))
+	require.True(t, FootprintsIntersect(a, GeometryFunc(nil)))
+}
+
+func TestGeoPolygonFromArea(t *testing.T) {
+	polygon, err := GeoPolygonFromArea("37.427,-122.17,37.4271,-122.17,37.427,-122.1699")
+	require.NoError(t, err)
+	require.Len(t, polygon.Vertices, 3)
+	require.InDelta(t, 37.4271, polygon.Vertices[1].Lat, 1e-9)
+	require.InDelta(t, -122.1699, polygon.Vertices[2].Lng, 1e-9)
+
+	_, err = GeoPolygonFromArea("37.427,-122.17")
+	require.Error(t, err)
+}
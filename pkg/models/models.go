@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	dsserr "github.com/interuss/dss/pkg/errors"
 	"github.com/interuss/stacktrace"
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -34,10 +35,24 @@ const (
 	// although changes to this will result in RMW errors.
 	versionBase = 32
 
-	// Set a max limit for the SELECT query result
+	// MaxResultLimit is the default maximum number of entities returned by a
+	// search, and the maximum number of entities listed at once by the
+	// garbage collectors and the list queries.
 	MaxResultLimit = 10000
 )
 
+// CheckCompleteResults returns an error with the dsserr.Exhausted code if
+// results of a search for entities of kind, requested with a limit of
+// MaxResultLimit+1, hold more than MaxResultLimit entities. Searches whose
+// results must be complete, such as those validating keys or finding the
+// subscribers to notify, fail with it rather than truncating their results.
+func CheckCompleteResults[T any](results []T, kind string) error {
+	if len(results) > MaxResultLimit {
+		return stacktrace.NewErrorWithCode(dsserr.Exhausted, "Too many %s in the area (limit is %d)", kind, MaxResultLimit)
+	}
+	return nil
+}
+
 // PgUUID converts an ID to a pgtype.UUID.
 // If the ID this is called on is nil, nil will be returned
 func (id *ID) PgUUID() (*pgtype.UUID, error) {
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"testing"
 
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/stacktrace"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 )
@@ -137,3 +139,11 @@ func TestID_PgUUID(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckCompleteResults(t *testing.T) {
+	assert.NoError(t, CheckCompleteResults(make([]ID, MaxResultLimit), "IDs"))
+
+	err := CheckCompleteResults(make([]ID, MaxResultLimit+1), "IDs")
+	assert.Error(t, err)
+	assert.Equal(t, dsserr.Exhausted, stacktrace.GetCode(err))
+}
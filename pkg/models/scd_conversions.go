@@ -197,7 +197,7 @@ func (gp *GeoPolygon) ToSCDRest() *restapi.Polygon {
 	}
 
 	result := &restapi.Polygon{
-		Vertices: make([]restapi.LatLngPoint, len(gp.Vertices)),
+		Vertices: make([]restapi.LatLngPoint, 0, len(gp.Vertices)),
 	}
 
 	for _, pt := range gp.Vertices {
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+
+	restapi "github.com/interuss/dss/pkg/api/scdv1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoPolygonToSCDRest(t *testing.T) {
+	polygon := &GeoPolygon{Vertices: []*LatLngPoint{
+		{Lat: 12, Lng: 34},
+		{Lat: 12.0001, Lng: 34},
+		{Lat: 12, Lng: 34.0001},
+	}}
+	require.Equal(t, &restapi.Polygon{Vertices: []restapi.LatLngPoint{
+		{Lat: 12, Lng: 34},
+		{Lat: 12.0001, Lng: 34},
+		{Lat: 12, Lng: 34.0001},
+	}}, polygon.ToSCDRest())
+}
@@ -0,0 +1,69 @@
+package notifications
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/interuss/stacktrace"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// ClientCredentialsAuthenticator obtains the access tokens presented to USSs
+// from an OAuth server with the client credentials grant.
+//
+// ASTM USS-to-USS requests carry a token whose audience is the host of the
+// receiving USS, so a token is requested (and cached until it expires) for
+// every combination of audience and scope.
+type ClientCredentialsAuthenticator struct {
+	// TokenURL is the token endpoint of the OAuth server.
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	// AudienceParameter is the name of the token request parameter carrying
+	// the intended audience; "audience" if empty.
+	AudienceParameter string
+
+	mu      sync.Mutex
+	sources map[string]oauth2.TokenSource
+}
+
+// Authenticate implements Authenticator.
+func (a *ClientCredentialsAuthenticator) Authenticate(req *http.Request, scope string) error {
+	token, err := a.tokenSource(req.URL.Hostname(), scope).Token()
+	if err != nil {
+		return stacktrace.Propagate(err, "Error obtaining access token for %s", req.URL.Hostname())
+	}
+	token.SetAuthHeader(req)
+	return nil
+}
+
+func (a *ClientCredentialsAuthenticator) tokenSource(audience string, scope string) oauth2.TokenSource {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := audience + " " + scope
+	if source, ok := a.sources[key]; ok {
+		return source
+	}
+
+	parameter := a.AudienceParameter
+	if parameter == "" {
+		parameter = "audience"
+	}
+	config := &clientcredentials.Config{
+		ClientID:       a.ClientID,
+		ClientSecret:   a.ClientSecret,
+		TokenURL:       a.TokenURL,
+		Scopes:         []string{scope},
+		EndpointParams: url.Values{parameter: []string{audience}},
+	}
+	if a.sources == nil {
+		a.sources = map[string]oauth2.TokenSource{}
+	}
+	source := config.TokenSource(context.Background())
+	a.sources[key] = source
+	return source
+}
@@ -0,0 +1,129 @@
+// Package cockroach implements the notification outbox of a DSS store backed
+// by a CockroachDB database.
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
+	dssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+)
+
+const notificationFields = "id, url, scope, payload, attempts, next_attempt_at, last_error, created_at"
+
+// Outbox is an implementation of notifications.Outbox on the
+// notification_outbox table of the database q is connected to.
+type Outbox struct {
+	q dssql.Queryable
+}
+
+// NewOutbox returns an Outbox querying q, which may be a connection pool or a
+// transaction.
+func NewOutbox(q dssql.Queryable) *Outbox {
+	return &Outbox{q: q}
+}
+
+// Enqueue adds ns to the outbox, due immediately.
+func (o *Outbox) Enqueue(ctx context.Context, ns []*notifications.Notification) error {
+	const query = `
+		INSERT INTO
+			notification_outbox
+			(id, url, scope, payload, next_attempt_at, created_at)
+		VALUES
+			($1, $2, $3, $4, transaction_timestamp(), transaction_timestamp())`
+
+	for _, n := range ns {
+		id, err := n.ID.PgUUID()
+		if err != nil {
+			return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+		}
+		if _, err := o.q.Exec(ctx, query, id, n.URL, n.Scope, n.Payload); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", query)
+		}
+	}
+	return nil
+}
+
+// ClaimNotifications implements notifications.Outbox.
+func (o *Outbox) ClaimNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*notifications.Notification, error) {
+	var query = fmt.Sprintf(`
+		UPDATE
+			notification_outbox
+		SET
+			next_attempt_at = $2
+		WHERE
+			id IN (
+				SELECT id FROM notification_outbox
+				WHERE next_attempt_at <= $1
+				ORDER BY next_attempt_at
+				LIMIT $3)
+		RETURNING
+			%s`, notificationFields)
+
+	rows, err := o.q.Query(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	var payload []*notifications.Notification
+	for rows.Next() {
+		n := new(notifications.Notification)
+		err := rows.Scan(
+			&n.ID,
+			&n.URL,
+			&n.Scope,
+			&n.Payload,
+			&n.Attempts,
+			&n.NextAttemptAt,
+			&n.LastError,
+			&n.CreatedAt,
+		)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning Notification row")
+		}
+		payload = append(payload, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return payload, nil
+}
+
+// DeleteNotification implements notifications.Outbox.
+func (o *Outbox) DeleteNotification(ctx context.Context, id dssmodels.ID) error {
+	const query = `DELETE FROM notification_outbox WHERE id = $1`
+
+	uid, err := id.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if _, err := o.q.Exec(ctx, query, uid); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
+
+// RescheduleNotification implements notifications.Outbox.
+func (o *Outbox) RescheduleNotification(ctx context.Context, n *notifications.Notification) error {
+	const query = `
+		UPDATE
+			notification_outbox
+		SET
+			(attempts, next_attempt_at, last_error) = ($2, $3, $4)
+		WHERE
+			id = $1`
+
+	uid, err := n.ID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if _, err := o.q.Exec(ctx, query, uid, n.Attempts, n.NextAttemptAt, n.LastError); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/metrics"
+	"github.com/interuss/dss/pkg/tracing"
+	"github.com/interuss/stacktrace"
+	"github.com/jonboulle/clockwork"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// DefaultClock is what is used as the Dispatcher's clock, returned from
+// NewDispatcher.
+var DefaultClock = clockwork.NewRealClock()
+
+// Authenticator adds to req the credentials expected by the USS receiving a
+// notification requiring scope.
+type Authenticator interface {
+	Authenticate(req *http.Request, scope string) error
+}
+
+// Configuration holds the delivery parameters of a Dispatcher.
+type Configuration struct {
+	// BatchSize is the maximum number of notifications claimed per call to
+	// DeliverDue.
+	BatchSize int
+	// Lease is the delay after which a claimed notification that has been
+	// neither delivered nor rescheduled, e.g. because the DSS instance
+	// delivering it stopped, becomes due again.
+	Lease time.Duration
+	// RequestTimeout bounds the duration of a single delivery attempt.
+	RequestTimeout time.Duration
+	// MaxAttempts is the number of failed attempts after which a notification
+	// is dropped.
+	MaxAttempts int
+	// MinBackoff and MaxBackoff bound the exponentially increasing delay
+	// between two attempts to deliver a notification.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// RateLimit is the maximum sustained number of notifications per second
+	// sent to each USS, and RateBurst the number that may be sent at once. A
+	// RateLimit of zero disables rate limiting.
+	RateLimit float64
+	RateBurst int
+}
+
+// DefaultConfiguration is a reasonable Configuration for a Dispatcher.
+var DefaultConfiguration = Configuration{
+	BatchSize:      100,
+	Lease:          time.Minute,
+	RequestTimeout: 10 * time.Second,
+	MaxAttempts:    10,
+	MinBackoff:     5 * time.Second,
+	MaxBackoff:     10 * time.Minute,
+	RateLimit:      10,
+	RateBurst:      20,
+}
+
+// Dispatcher delivers the notifications of an Outbox.
+type Dispatcher struct {
+	name          string
+	outbox        Outbox
+	config        Configuration
+	client        *http.Client
+	authenticator Authenticator
+	clock         clockwork.Clock
+	logger        *zap.Logger
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewDispatcher returns a Dispatcher delivering the notifications of outbox.
+// name identifies the outbox in logs and metrics. authenticator may be nil if
+// the receiving USSs do not require access tokens.
+func NewDispatcher(name string, outbox Outbox, config Configuration, authenticator Authenticator, logger *zap.Logger) *Dispatcher {
+	return &Dispatcher{
+		name:          name,
+		outbox:        outbox,
+		config:        config,
+		client:        &http.Client{Timeout: config.RequestTimeout},
+		authenticator: authenticator,
+		clock:         DefaultClock,
+		logger:        logger.With(zap.String("outbox", name)),
+		limiters:      map[string]*rate.Limiter{},
+	}
+}
+
+// DeliverDue claims the notifications currently due and attempts to deliver
+// them, concurrently across USSs and sequentially for a given USS. It is meant
+// to be called periodically.
+func (d *Dispatcher) DeliverDue(ctx context.Context) error {
+	pending, err := d.outbox.ClaimNotifications(ctx, d.clock.Now(), d.config.Lease, d.config.BatchSize)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error claiming notifications from %s outbox", d.name)
+	}
+
+	byUSS := map[string][]*Notification{}
+	for _, n := range pending {
+		host := ussOf(n)
+		byUSS[host] = append(byUSS[host], n)
+	}
+
+	var wg sync.WaitGroup
+	for host, ns := range byUSS {
+		wg.Add(1)
+		go func(limiter *rate.Limiter, ns []*Notification) {
+			defer wg.Done()
+			for _, n := range ns {
+				d.process(ctx, limiter, n)
+			}
+		}(d.limiter(host), ns)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (d *Dispatcher) process(ctx context.Context, limiter *rate.Limiter, n *Notification) {
+	logger := logging.WithValuesFromContext(ctx, d.logger).With(zap.String("notification", n.ID.String()), zap.String("url", n.URL))
+	now := d.clock.Now()
+
+	if r := limiter.ReserveN(now, 1); r.DelayFrom(now) > 0 {
+		// Leave the budget to the notifications already waiting for this USS
+		// and retry once it is replenished, without counting an attempt.
+		delay := r.DelayFrom(now)
+		r.CancelAt(now)
+		n.NextAttemptAt = now.Add(delay)
+		if err := d.outbox.RescheduleNotification(ctx, n); err != nil {
+			logger.Warn("Failed to reschedule throttled notification", zap.Error(err))
+		}
+		metrics.NotificationProcessed(d.name, metrics.NotificationThrottled)
+		return
+	}
+
+	err := d.deliver(ctx, n)
+	if err == nil {
+		if err := d.outbox.DeleteNotification(ctx, n.ID); err != nil {
+			logger.Warn("Failed to remove delivered notification from outbox", zap.Error(err))
+		}
+		metrics.NotificationProcessed(d.name, metrics.NotificationDelivered)
+		return
+	}
+
+	n.Attempts++
+	if n.Attempts >= d.config.MaxAttempts {
+		logger.Warn("Dropping notification after too many failed attempts", zap.Int("attempts", n.Attempts), zap.Error(err))
+		if err := d.outbox.DeleteNotification(ctx, n.ID); err != nil {
+			logger.Warn("Failed to remove dropped notification from outbox", zap.Error(err))
+		}
+		metrics.NotificationProcessed(d.name, metrics.NotificationDropped)
+		return
+	}
+
+	logger.Info("Failed to deliver notification; will retry", zap.Int("attempts", n.Attempts), zap.Error(err))
+	msg := err.Error()
+	n.LastError = &msg
+	n.NextAttemptAt = now.Add(d.backoff(n.Attempts))
+	if err := d.outbox.RescheduleNotification(ctx, n); err != nil {
+		logger.Warn("Failed to reschedule notification", zap.Error(err))
+	}
+	metrics.NotificationProcessed(d.name, metrics.NotificationRetried)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, n *Notification) (err error) {
+	ctx, span := tracing.Start(ctx, "notifications.Deliver", attribute.String("url.full", n.URL))
+	defer func() { tracing.End(span, err) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(n.Payload))
+	if err != nil {
+		return stacktrace.Propagate(err, "Error creating notification request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if d.authenticator != nil {
+		if err := d.authenticator.Authenticate(req, n.Scope); err != nil {
+			return stacktrace.Propagate(err, "Error authenticating notification request")
+		}
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error sending notification")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return stacktrace.NewError("USS responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// backoff returns the delay before the attempt following the given number of
+// failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.config.MinBackoff
+	for i := 1; i < attempts && delay < d.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.config.MaxBackoff {
+		delay = d.config.MaxBackoff
+	}
+	return delay
+}
+
+func (d *Dispatcher) limiter(uss string) *rate.Limiter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	limiter, ok := d.limiters[uss]
+	if !ok {
+		limit := rate.Limit(d.config.RateLimit)
+		if d.config.RateLimit <= 0 {
+			limit = rate.Inf
+		}
+		limiter = rate.NewLimiter(limit, max(d.config.RateBurst, 1))
+		d.limiters[uss] = limiter
+	}
+	return limiter
+}
+
+// ussOf returns the key identifying the USS receiving n for rate limiting
+// purposes.
+func ussOf(n *Notification) string {
+	u, err := url.Parse(n.URL)
+	if err != nil || u.Host == "" {
+		return n.URL
+	}
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+}
@@ -0,0 +1,131 @@
+package notifications_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
+	"github.com/interuss/dss/pkg/notifications/memory"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type outbox struct {
+	mu    sync.Mutex
+	queue memory.Queue
+}
+
+func (o *outbox) ClaimNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*notifications.Notification, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.queue.Claim(now, lease, limit), nil
+}
+
+func (o *outbox) DeleteNotification(ctx context.Context, id dssmodels.ID) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.queue.Delete(id)
+	return nil
+}
+
+func (o *outbox) RescheduleNotification(ctx context.Context, n *notifications.Notification) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.queue.Reschedule(n)
+	return nil
+}
+
+func setUpDispatcher(t *testing.T, config notifications.Configuration, handler http.HandlerFunc) (*notifications.Dispatcher, *outbox, clockwork.FakeClock, string) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	clock := clockwork.NewFakeClock()
+	notifications.DefaultClock = clock
+	o := &outbox{}
+	return notifications.NewDispatcher("test", o, config, nil, zap.L()), o, clock, server.URL
+}
+
+func enqueue(t *testing.T, o *outbox, now time.Time, url string) *notifications.Notification {
+	n, err := notifications.New(url, "scope", map[string]string{"key": "value"})
+	require.NoError(t, err)
+	o.queue.Enqueue(now, []*notifications.Notification{n})
+	return n
+}
+
+func TestDeliverDueDeletesDelivered(t *testing.T) {
+	var bodies []string
+	d, o, clock, url := setUpDispatcher(t, notifications.DefaultConfiguration, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	enqueue(t, o, clock.Now(), url+"/uss/v1/operational_intents")
+
+	require.NoError(t, d.DeliverDue(context.Background()))
+	require.Equal(t, []string{`{"key":"value"}`}, bodies)
+	require.Equal(t, 0, o.queue.Len())
+}
+
+func TestDeliverDueRetriesWithBackoff(t *testing.T) {
+	config := notifications.DefaultConfiguration
+	config.MaxAttempts = 2
+	d, o, clock, url := setUpDispatcher(t, config, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	ctx := context.Background()
+	enqueue(t, o, clock.Now(), url)
+
+	require.NoError(t, d.DeliverDue(ctx))
+	require.Equal(t, 1, o.queue.Len())
+
+	// Not due before the backoff elapses.
+	clock.Advance(config.MinBackoff - time.Second)
+	pending := o.queue.Claim(clock.Now(), 0, 10)
+	require.Empty(t, pending)
+
+	clock.Advance(time.Second)
+	pending = o.queue.Claim(clock.Now(), 0, 10)
+	require.Len(t, pending, 1)
+	require.Equal(t, 1, pending[0].Attempts)
+	require.NotNil(t, pending[0].LastError)
+
+	// The second failed attempt reaches MaxAttempts.
+	require.NoError(t, d.DeliverDue(ctx))
+	require.Equal(t, 0, o.queue.Len())
+}
+
+func TestDeliverDueThrottlesPerUSS(t *testing.T) {
+	config := notifications.DefaultConfiguration
+	config.RateLimit = 1
+	config.RateBurst = 1
+	delivered := 0
+	d, o, clock, url := setUpDispatcher(t, config, func(w http.ResponseWriter, r *http.Request) {
+		delivered++
+	})
+	ctx := context.Background()
+	enqueue(t, o, clock.Now(), url+"/first")
+	enqueue(t, o, clock.Now(), url+"/second")
+
+	require.NoError(t, d.DeliverDue(ctx))
+	require.Equal(t, 1, delivered)
+	require.Equal(t, 1, o.queue.Len())
+
+	clock.Advance(time.Second)
+	pending := o.queue.Claim(clock.Now(), 0, 10)
+	require.Len(t, pending, 1)
+	require.Equal(t, 0, pending[0].Attempts)
+
+	require.NoError(t, d.DeliverDue(ctx))
+	require.Equal(t, 2, delivered)
+	require.Equal(t, 0, o.queue.Len())
+}
@@ -0,0 +1,9 @@
+// Package notifications implements the optional delivery of ASTM notifications
+// from the DSS to the USSs whose Subscriptions are affected by a change.
+//
+// Notifications are written to a durable outbox in the same transaction as the
+// change that triggers them, so that they are sent if and only if the change
+// is committed. A Dispatcher then delivers the pending notifications, retrying
+// failed deliveries with an exponential backoff and rate limiting the requests
+// sent to each USS.
+package notifications
@@ -0,0 +1,94 @@
+// Package memory implements the notification outbox of a DSS store keeping
+// its data in process memory.
+package memory
+
+import (
+	"sort"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
+)
+
+// Queue holds the notifications of an in-memory outbox. It is meant to be
+// part of the data of an in-memory store so that notifications are enqueued
+// atomically with the changes they are about; it performs no locking itself.
+type Queue struct {
+	notifications map[dssmodels.ID]*notifications.Notification
+}
+
+// Clone returns a deep copy of q.
+func (q Queue) Clone() Queue {
+	c := Queue{notifications: make(map[dssmodels.ID]*notifications.Notification, len(q.notifications))}
+	for id, n := range q.notifications {
+		c.notifications[id] = clone(n)
+	}
+	return c
+}
+
+// Enqueue adds ns to q, due at now.
+func (q *Queue) Enqueue(now time.Time, ns []*notifications.Notification) {
+	if q.notifications == nil {
+		q.notifications = map[dssmodels.ID]*notifications.Notification{}
+	}
+	for _, n := range ns {
+		n = clone(n)
+		n.Attempts = 0
+		n.NextAttemptAt = now
+		n.LastError = nil
+		n.CreatedAt = now
+		q.notifications[n.ID] = n
+	}
+}
+
+// Claim returns up to limit notifications due at now, deferring their next
+// attempt to now+lease.
+func (q *Queue) Claim(now time.Time, lease time.Duration, limit int) []*notifications.Notification {
+	var due []*notifications.Notification
+	for _, n := range q.notifications {
+		if !n.NextAttemptAt.After(now) {
+			due = append(due, n)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	result := make([]*notifications.Notification, len(due))
+	for i, n := range due {
+		n.NextAttemptAt = now.Add(lease)
+		result[i] = clone(n)
+	}
+	return result
+}
+
+// Delete removes the notification identified by id from q.
+func (q *Queue) Delete(id dssmodels.ID) {
+	delete(q.notifications, id)
+}
+
+// Reschedule updates the Attempts, NextAttemptAt and LastError fields of the
+// notification of q identified by n.ID.
+func (q *Queue) Reschedule(n *notifications.Notification) {
+	if stored, ok := q.notifications[n.ID]; ok {
+		stored.Attempts = n.Attempts
+		stored.NextAttemptAt = n.NextAttemptAt
+		stored.LastError = n.LastError
+	}
+}
+
+// Len returns the number of notifications in q.
+func (q *Queue) Len() int {
+	return len(q.notifications)
+}
+
+func clone(n *notifications.Notification) *notifications.Notification {
+	c := *n
+	c.Payload = append([]byte(nil), n.Payload...)
+	if n.LastError != nil {
+		msg := *n.LastError
+		c.LastError = &msg
+	}
+	return &c
+}
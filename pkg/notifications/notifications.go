@@ -0,0 +1,64 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/stacktrace"
+)
+
+// Notification is a request to be POSTed to a USS on behalf of the DSS.
+type Notification struct {
+	ID dssmodels.ID
+	// URL is the USS endpoint the notification is POSTed to.
+	URL string
+	// Scope is the OAuth scope of the access token presented to the USS.
+	Scope string
+	// Payload is the JSON body of the notification.
+	Payload []byte
+	// Attempts is the number of failed delivery attempts so far.
+	Attempts int
+	// NextAttemptAt is the earliest time at which the notification may be
+	// (re)delivered.
+	NextAttemptAt time.Time
+	// LastError describes the failure of the last delivery attempt, if any.
+	LastError *string
+	CreatedAt time.Time
+}
+
+// New returns a Notification of payload, serialized as JSON, to be POSTed to
+// url with an access token granting scope.
+func New(url string, scope string, payload interface{}) (*Notification, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error serializing notification payload")
+	}
+	return &Notification{
+		ID:      dssmodels.ID(uuid.New().String()),
+		URL:     url,
+		Scope:   scope,
+		Payload: data,
+	}, nil
+}
+
+// Outbox is the durable queue of notifications pending delivery.
+//
+// Notifications are enqueued by the repositories of the store holding the
+// entities they are about, in the transaction changing those entities.
+type Outbox interface {
+	// ClaimNotifications returns up to limit notifications due at now, and
+	// defers their next attempt to now+lease so that concurrent Dispatchers
+	// do not deliver them at the same time.
+	ClaimNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Notification, error)
+
+	// DeleteNotification removes the notification identified by id from the
+	// outbox.
+	DeleteNotification(ctx context.Context, id dssmodels.ID) error
+
+	// RescheduleNotification persists the Attempts, NextAttemptAt and LastError
+	// fields of n.
+	RescheduleNotification(ctx context.Context, n *Notification) error
+}
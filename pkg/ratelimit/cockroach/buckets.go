@@ -0,0 +1,97 @@
+// Package cockroach implements the token buckets of the rate limits on a
+// CockroachDB database, so that the DSS instances of a pool enforce the limits
+// together.
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/interuss/dss/pkg/ratelimit"
+	dssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgx/v5"
+)
+
+// BucketStore is an implementation of ratelimit.BucketStore on the
+// rate_limit_buckets table of the database q is connected to. Buckets are
+// refilled according to the time of the database, so that the clocks of the
+// DSS instances need not be synchronized.
+type BucketStore struct {
+	q dssql.Queryable
+}
+
+// NewBucketStore returns a BucketStore querying q.
+func NewBucketStore(q dssql.Queryable) *BucketStore {
+	return &BucketStore{q: q}
+}
+
+// TakeToken implements ratelimit.BucketStore.
+func (s *BucketStore) TakeToken(ctx context.Context, key string, limit ratelimit.Limit) (time.Duration, error) {
+	// A new bucket is full, and a token is only taken from an existing bucket
+	// if at least one is available once it is refilled.
+	const (
+		takeQuery = `
+			INSERT INTO
+				rate_limit_buckets
+				(name, tokens, updated_at)
+			VALUES
+				($1, $3::FLOAT8 - 1, transaction_timestamp())
+			ON CONFLICT (name) DO UPDATE
+				SET (tokens, updated_at) = (
+					LEAST($3::FLOAT8, rate_limit_buckets.tokens + EXTRACT(EPOCH FROM transaction_timestamp() - rate_limit_buckets.updated_at)::FLOAT8 * $2::FLOAT8) - 1,
+					transaction_timestamp())
+				WHERE LEAST($3::FLOAT8, rate_limit_buckets.tokens + EXTRACT(EPOCH FROM transaction_timestamp() - rate_limit_buckets.updated_at)::FLOAT8 * $2::FLOAT8) >= 1
+			RETURNING
+				tokens`
+		availableQuery = `
+			SELECT
+				LEAST($3::FLOAT8, tokens + EXTRACT(EPOCH FROM transaction_timestamp() - updated_at)::FLOAT8 * $2::FLOAT8)
+			FROM
+				rate_limit_buckets
+			WHERE
+				name = $1`
+	)
+
+	var tokens float64
+	err := s.q.QueryRow(ctx, takeQuery, key, limit.Rate, float64(limit.Burst)).Scan(&tokens)
+	switch {
+	case err == nil:
+		return 0, nil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return 0, stacktrace.Propagate(err, "Error in query: %s", takeQuery)
+	}
+
+	// No token is available.
+	err = s.q.QueryRow(ctx, availableQuery, key, limit.Rate, float64(limit.Burst)).Scan(&tokens)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// The bucket was deleted meanwhile, so it is full.
+		return 0, nil
+	case err != nil:
+		return 0, stacktrace.Propagate(err, "Error in query: %s", availableQuery)
+	}
+	wait := time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+	if wait <= 0 {
+		// Refilled meanwhile; the client can retry immediately.
+		wait = time.Nanosecond
+	}
+	return wait, nil
+}
+
+// DeleteIdleBuckets deletes the buckets which were not used for at least
+// idle, which must be long enough for the buckets to be full again.
+func (s *BucketStore) DeleteIdleBuckets(ctx context.Context, idle time.Duration) (int64, error) {
+	const query = `
+		DELETE FROM
+			rate_limit_buckets
+		WHERE
+			updated_at <= transaction_timestamp() - $1::INT8 * INTERVAL '1 millisecond'`
+
+	tag, err := s.q.Exec(ctx, query, idle.Milliseconds())
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return tag.RowsAffected(), nil
+}
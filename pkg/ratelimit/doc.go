@@ -0,0 +1,9 @@
+// Package ratelimit protects the DSS from clients sending more requests than
+// it can serve.
+//
+// The requests of each authenticated client are limited per class of route
+// (read, write or search) by token buckets, kept either in process memory or
+// in a datastore shared by the DSS instances of a pool so that the whole pool
+// enforces the limits together. Independently of the client, requests are
+// shed while the connection pools of the datastores are saturated.
+package ratelimit
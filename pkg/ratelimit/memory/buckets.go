@@ -0,0 +1,83 @@
+// Package memory implements the token buckets of the rate limits in process
+// memory.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/interuss/dss/pkg/ratelimit"
+	"github.com/jonboulle/clockwork"
+	"golang.org/x/time/rate"
+)
+
+// sweepPeriod is the minimum period between deletions of idle buckets.
+const sweepPeriod = time.Minute
+
+type bucket struct {
+	limiter *rate.Limiter
+	// idleAfter is the time after which the bucket is full again, and may
+	// therefore be deleted.
+	idleAfter time.Time
+}
+
+// BucketStore is an implementation of ratelimit.BucketStore keeping its
+// buckets in process memory, so that the limits are enforced by each DSS
+// instance separately.
+type BucketStore struct {
+	clock clockwork.Clock
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewBucketStore returns a BucketStore without any bucket.
+func NewBucketStore() *BucketStore {
+	return newBucketStore(clockwork.NewRealClock())
+}
+
+func newBucketStore(clock clockwork.Clock) *BucketStore {
+	return &BucketStore{
+		clock:     clock,
+		buckets:   map[string]*bucket{},
+		lastSweep: clock.Now(),
+	}
+}
+
+// TakeToken implements ratelimit.BucketStore.
+func (s *BucketStore) TakeToken(ctx context.Context, key string, limit ratelimit.Limit) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok || b.limiter.Limit() != rate.Limit(limit.Rate) || b.limiter.Burst() != limit.Burst {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+		s.buckets[key] = b
+	}
+	reservation := b.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// The token is not taken by rejected requests.
+		reservation.CancelAt(now)
+		return delay, nil
+	}
+	b.idleAfter = now.Add(limit.RefillDuration())
+	return 0, nil
+}
+
+// sweep deletes the buckets which are full again, at most once per
+// sweepPeriod.
+func (s *BucketStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepPeriod {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.After(b.idleAfter) {
+			delete(s.buckets, key)
+		}
+	}
+}
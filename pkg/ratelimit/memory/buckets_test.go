@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/ratelimit"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeToken(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		clock = clockwork.NewFakeClock()
+		store = newBucketStore(clock)
+		limit = ratelimit.Limit{Rate: 2, Burst: 2}
+	)
+
+	for i := 0; i < limit.Burst; i++ {
+		wait, err := store.TakeToken(ctx, "uss1/read", limit)
+		require.NoError(t, err)
+		require.Zero(t, wait)
+	}
+	wait, err := store.TakeToken(ctx, "uss1/read", limit)
+	require.NoError(t, err)
+	require.Equal(t, 500*time.Millisecond, wait)
+
+	// Buckets are independent
+	wait, err = store.TakeToken(ctx, "uss2/read", limit)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	// Rejected requests do not take tokens
+	clock.Advance(500 * time.Millisecond)
+	wait, err = store.TakeToken(ctx, "uss1/read", limit)
+	require.NoError(t, err)
+	require.Zero(t, wait)
+}
+
+func TestSweepsIdleBuckets(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		clock = clockwork.NewFakeClock()
+		store = newBucketStore(clock)
+	)
+
+	_, err := store.TakeToken(ctx, "uss1/read", ratelimit.Limit{Rate: 1, Burst: 1})
+	require.NoError(t, err)
+	_, err = store.TakeToken(ctx, "uss1/search", ratelimit.Limit{Rate: 0.001, Burst: 1})
+	require.NoError(t, err)
+	require.Len(t, store.buckets, 2)
+
+	clock.Advance(sweepPeriod)
+	_, err = store.TakeToken(ctx, "uss2/read", ratelimit.Limit{Rate: 1, Burst: 1})
+	require.NoError(t, err)
+	require.Contains(t, store.buckets, "uss1/search")
+	require.NotContains(t, store.buckets, "uss1/read")
+}
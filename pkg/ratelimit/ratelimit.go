@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/metrics"
+	"github.com/interuss/stacktrace"
+	"go.uber.org/zap"
+)
+
+// Class groups the routes sharing the same rate limit.
+type Class string
+
+const (
+	// Read is the class of the routes retrieving a single entity.
+	Read Class = "read"
+	// Write is the class of the routes creating, updating or deleting
+	// entities.
+	Write Class = "write"
+	// Search is the class of the routes retrieving collections of entities,
+	// which are the most expensive for the datastore.
+	Search Class = "search"
+)
+
+// ClassOf returns the Class of route: GET routes whose path template ends
+// with a parameter are reads, other GET routes and POST routes ending with
+// /query are searches, and all other routes are writes.
+func ClassOf(route *api.Route) Class {
+	switch {
+	case route.Method == http.MethodGet && strings.HasSuffix(route.Path, "}"):
+		return Read
+	case route.Method == http.MethodGet:
+		return Search
+	case route.Method == http.MethodPost && strings.HasSuffix(route.Path, "/query"):
+		return Search
+	default:
+		return Write
+	}
+}
+
+// Limit is the token bucket limiting the requests of a client.
+type Limit struct {
+	// Rate is the number of requests per second a client may sustain.
+	Rate float64
+	// Burst is the number of requests a client may send at once.
+	Burst int
+}
+
+// RefillDuration returns the time after which an empty bucket is full again.
+func (l Limit) RefillDuration() time.Duration {
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// ParseLimits parses the limits of each class from a comma-separated list of
+// <class>=<rate>[:<burst>] pairs; the burst defaults to the rate rounded up.
+func ParseLimits(s string) (map[Class]Limit, error) {
+	limits := map[Class]Limit{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, stacktrace.NewError("Missing limit of class in `%s`", pair)
+		}
+		class := Class(strings.TrimSpace(name))
+		switch class {
+		case Read, Write, Search:
+		default:
+			return nil, stacktrace.NewError("Unknown class %s, must be one of {%s, %s, %s}", class, Read, Write, Search)
+		}
+		rateValue, burstValue, hasBurst := strings.Cut(value, ":")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateValue), 64)
+		if err != nil || rate <= 0 {
+			return nil, stacktrace.NewError("Rate of class %s must be a positive number", class)
+		}
+		burst := int(math.Ceil(rate))
+		if hasBurst {
+			burst, err = strconv.Atoi(strings.TrimSpace(burstValue))
+			if err != nil || burst <= 0 {
+				return nil, stacktrace.NewError("Burst of class %s must be a positive integer", class)
+			}
+		}
+		limits[class] = Limit{Rate: rate, Burst: burst}
+	}
+	return limits, nil
+}
+
+// BucketStore holds the token buckets of the clients.
+type BucketStore interface {
+	// TakeToken takes a token from the bucket key, refilled and capped
+	// according to limit. It returns zero if a token was taken, or the time
+	// until a token is available otherwise.
+	TakeToken(ctx context.Context, key string, limit Limit) (time.Duration, error)
+}
+
+// errorResponse is the body of the responses to rejected requests, in the
+// format of the errors of the DSS APIs.
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// Authorizer is an api.Authorizer limiting the rate of the requests of the
+// clients authorized by another api.Authorizer. It responds to the requests
+// exceeding the limit of their client with 429 Too Many Requests.
+//
+// Routes are identified with api.MatchedRoute, so requests must be recorded
+// with api.WithRouteRecorder, e.g. by SheddingMiddleware.
+type Authorizer struct {
+	// Authorizer authorizes the requests before they are limited.
+	api.Authorizer
+	// Store holds the token buckets of the clients.
+	Store BucketStore
+	// Limits are the limits of each Class; the requests of the classes
+	// without limit are not limited.
+	Limits map[Class]Limit
+	// Logger reports the failures of Store, for which requests are allowed.
+	Logger *zap.Logger
+}
+
+// Authorize implements api.Authorizer.
+func (a *Authorizer) Authorize(w http.ResponseWriter, r *http.Request, authOptions []api.AuthorizationOption) api.AuthorizationResult {
+	result := a.Authorizer.Authorize(w, r, authOptions)
+	if result.Error != nil || result.ClientID == nil {
+		return result
+	}
+	route := api.MatchedRoute(r)
+	if route == nil {
+		return result
+	}
+	class := ClassOf(route)
+	limit, ok := a.Limits[class]
+	if !ok {
+		return result
+	}
+
+	retryAfter, err := a.Store.TakeToken(r.Context(), *result.ClientID+"/"+string(class), limit)
+	if err != nil {
+		logging.WithValuesFromContext(r.Context(), a.Logger).Warn("Failed to check rate limit; allowing request", zap.String("client_id", *result.ClientID), zap.Error(err))
+		return result
+	}
+	if retryAfter > 0 {
+		metrics.RequestRateLimited(string(class))
+		w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+		api.WriteJSON(w, http.StatusTooManyRequests, errorResponse{
+			Message: fmt.Sprintf("Rate limit of %s requests exceeded by client %s", class, *result.ClientID)})
+		result.Handled = true
+	}
+	return result
+}
+
+// retryAfterSeconds formats d as the value of a Retry-After header, in whole
+// seconds rounded up.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestClassOf(t *testing.T) {
+	for _, tc := range []struct {
+		method   string
+		path     string
+		expected Class
+	}{
+		{http.MethodGet, "/rid/v2/dss/identification_service_areas/{id}", Read},
+		{http.MethodGet, "/rid/v2/dss/identification_service_areas", Search},
+		{http.MethodPost, "/dss/v1/operational_intent_references/query", Search},
+		{http.MethodPut, "/dss/v1/operational_intent_references/{entityid}", Write},
+		{http.MethodDelete, "/rid/v2/dss/subscriptions/{id}/{version}", Write},
+		{http.MethodPost, "/dss/v1/reports", Write},
+	} {
+		require.Equal(t, tc.expected, ClassOf(&api.Route{Method: tc.method, Path: tc.path}), "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits(" read=20:40, write=0.5,search=2.5 ,")
+	require.NoError(t, err)
+	require.Equal(t, map[Class]Limit{
+		Read:   {Rate: 20, Burst: 40},
+		Write:  {Rate: 0.5, Burst: 1},
+		Search: {Rate: 2.5, Burst: 3},
+	}, limits)
+	require.Equal(t, 2*time.Second, limits[Read].RefillDuration())
+
+	limits, err = ParseLimits("")
+	require.NoError(t, err)
+	require.Empty(t, limits)
+
+	for _, invalid := range []string{"read", "reads=1", "read=0", "read=x", "read=1:0", "read=1:x"} {
+		_, err := ParseLimits(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+// fixedAuthorizer authorizes every request for its client.
+type fixedAuthorizer struct {
+	clientID string
+}
+
+func (a fixedAuthorizer) Authorize(w http.ResponseWriter, r *http.Request, authOptions []api.AuthorizationOption) api.AuthorizationResult {
+	return api.AuthorizationResult{ClientID: &a.clientID}
+}
+
+// fixedBucketStore responds to every request for a token with its wait and
+// error, recording the requested keys.
+type fixedBucketStore struct {
+	wait time.Duration
+	err  error
+	keys []string
+}
+
+func (s *fixedBucketStore) TakeToken(ctx context.Context, key string, limit Limit) (time.Duration, error) {
+	s.keys = append(s.keys, key)
+	return s.wait, s.err
+}
+
+// serve serves a request to a route authorized by authorizer through
+// SheddingMiddleware, reporting whether the operation was performed.
+func serve(authorizer api.Authorizer, method string, path string) (*httptest.ResponseRecorder, bool) {
+	performed := false
+	router := api.NewRouter(&api.Route{
+		Method: method,
+		Path:   path,
+		Handler: func(params api.PathParams, w http.ResponseWriter, r *http.Request) {
+			if authorizer.Authorize(w, r, nil).Handled {
+				return
+			}
+			performed = true
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	w := httptest.NewRecorder()
+	SheddingMiddleware(0.5, router).ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w, performed
+}
+
+func TestAuthorizerLimitsRate(t *testing.T) {
+	store := &fixedBucketStore{wait: 1500 * time.Millisecond}
+	authorizer := &Authorizer{
+		Authorizer: fixedAuthorizer{clientID: "uss1"},
+		Store:      store,
+		Limits:     map[Class]Limit{Search: {Rate: 1, Burst: 1}},
+		Logger:     zap.NewNop(),
+	}
+
+	w, performed := serve(authorizer, http.MethodPost, "/things/query")
+	require.False(t, performed)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "2", w.Header().Get("Retry-After"))
+	var body errorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Contains(t, body.Message, "uss1")
+	require.Equal(t, []string{"uss1/search"}, store.keys)
+
+	// Classes without limit are not limited
+	w, performed = serve(authorizer, http.MethodPut, "/things/{id}")
+	require.True(t, performed)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Len(t, store.keys, 1)
+}
+
+func TestAuthorizerAllowsRequests(t *testing.T) {
+	for _, store := range []*fixedBucketStore{{}, {err: errors.New("unavailable")}} {
+		authorizer := &Authorizer{
+			Authorizer: fixedAuthorizer{clientID: "uss1"},
+			Store:      store,
+			Limits:     map[Class]Limit{Write: {Rate: 1, Burst: 1}},
+			Logger:     zap.NewNop(),
+		}
+
+		w, performed := serve(authorizer, http.MethodPut, "/things/{id}")
+		require.True(t, performed)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, []string{"uss1/write"}, store.keys)
+	}
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/interuss/dss/pkg/api"
+	"github.com/interuss/dss/pkg/metrics"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sheddingRetryAfter is the Retry-After of the responses to shed requests.
+const sheddingRetryAfter = "1"
+
+var pools = struct {
+	mu    sync.Mutex
+	pools map[string]*pgxpool.Pool
+}{pools: map[string]*pgxpool.Pool{}}
+
+// ObservePool takes the saturation of the connection pool used to access
+// database into account to shed requests. It replaces any pool previously
+// observed for the same database.
+func ObservePool(database string, pool *pgxpool.Pool) {
+	pools.mu.Lock()
+	defer pools.mu.Unlock()
+	pools.pools[database] = pool
+}
+
+// saturatedDatabase returns the name of a database whose connection pool has
+// at least saturation of its connections acquired, if any.
+func saturatedDatabase(saturation float64) (string, bool) {
+	pools.mu.Lock()
+	defer pools.mu.Unlock()
+	for database, pool := range pools.pools {
+		stat := pool.Stat()
+		if stat.MaxConns() > 0 && float64(stat.AcquiredConns()) >= saturation*float64(stat.MaxConns()) {
+			return database, true
+		}
+	}
+	return "", false
+}
+
+// SheddingMiddleware installs an http.Handler responding with 503 Service
+// Unavailable instead of calling handler while at least saturation of the
+// connections of any pool observed with ObservePool are acquired. Requests
+// are never shed if saturation is not positive.
+//
+// Requests passed to handler record their api.Route, as required by
+// Authorizer.
+func SheddingMiddleware(saturation float64, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if saturation > 0 {
+			if database, saturated := saturatedDatabase(saturation); saturated {
+				metrics.RequestShed(database)
+				w.Header().Set("Retry-After", sheddingRetryAfter)
+				api.WriteJSON(w, http.StatusServiceUnavailable, errorResponse{
+					Message: "The DSS is overloaded; please retry later"})
+				return
+			}
+		}
+		handler.ServeHTTP(w, api.WithRouteRecorder(r))
+	})
+}
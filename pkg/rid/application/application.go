@@ -1,6 +1,8 @@
 package application
 
 import (
+	"github.com/interuss/dss/pkg/notifications"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
 	"github.com/interuss/dss/pkg/rid/store"
 	"github.com/jonboulle/clockwork"
 	"go.uber.org/zap"
@@ -17,8 +19,16 @@ type app struct {
 	store.Store
 	clock  clockwork.Clock
 	logger *zap.Logger
+
+	// isaNotifications builds the notifications of ISA changes sent by the DSS;
+	// nil if the DSS does not send notifications.
+	isaNotifications ISANotificationsBuilder
 }
 
+// ISANotificationsBuilder returns the notifications informing the managers of
+// subs of a change to isa, which has been deleted if deleted is true.
+type ISANotificationsBuilder func(isa *ridmodels.IdentificationServiceArea, deleted bool, subs []*ridmodels.Subscription) ([]*notifications.Notification, error)
+
 type App interface {
 	ISAApp
 	SubscriptionApp
@@ -33,3 +43,15 @@ func NewFromTransactor(store store.Store, logger *zap.Logger) App {
 		logger: logger,
 	}
 }
+
+// NewFromTransactorWithNotifications is like NewFromTransactor, but the
+// returned App also enqueues the notifications built by isaNotifications in
+// the transaction of every ISA change.
+func NewFromTransactorWithNotifications(store store.Store, logger *zap.Logger, isaNotifications ISANotificationsBuilder) App {
+	return &app{
+		Store:            store,
+		clock:            DefaultClock,
+		logger:           logger,
+		isaNotifications: isaNotifications,
+	}
+}
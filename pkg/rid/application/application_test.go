@@ -5,9 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/interuss/dss/pkg/audit"
 	"github.com/interuss/dss/pkg/datastore"
 	"github.com/interuss/dss/pkg/datastore/flags"
 	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
 	ridmodels "github.com/interuss/dss/pkg/rid/models"
 	"github.com/interuss/dss/pkg/rid/repos"
 	"github.com/interuss/dss/pkg/rid/store"
@@ -29,6 +31,18 @@ type mockRepo struct {
 	*isaStore
 	*subscriptionStore
 	dssql.Queryable
+	notifications []*notifications.Notification
+	audit         []*audit.Record
+}
+
+func (s *mockRepo) AppendAuditRecord(ctx context.Context, r *audit.Record) error {
+	s.audit = append(s.audit, r)
+	return nil
+}
+
+func (s *mockRepo) EnqueueNotifications(ctx context.Context, ns []*notifications.Notification) error {
+	s.notifications = append(s.notifications, ns...)
+	return nil
 }
 
 func (s *mockRepo) Interact(ctx context.Context) (repos.Repository, error) {
@@ -5,11 +5,13 @@ import (
 	"time"
 
 	"github.com/golang/geo/s2"
+	"github.com/interuss/dss/pkg/audit"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	"github.com/interuss/dss/pkg/geo"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	ridmodels "github.com/interuss/dss/pkg/rid/models"
 	"github.com/interuss/dss/pkg/rid/repos"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/stacktrace"
 )
 
@@ -29,11 +31,14 @@ type ISAApp interface {
 	// UpdateISA
 	UpdateISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error)
 
-	// SearchISAs returns all subscriptions ownded by "owner" in "cells".
-	SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time) ([]*ridmodels.IdentificationServiceArea, error)
+	// SearchISAs returns up to "limit" ISAs in "cells".
+	SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time, limit int) ([]*ridmodels.IdentificationServiceArea, error)
 }
 
 func (a *app) GetISA(ctx context.Context, id dssmodels.ID) (*ridmodels.IdentificationServiceArea, error) {
+	ctx, span := tracing.Start(ctx, "rid.GetISA")
+	defer span.End()
+
 	repo, err := a.Store.Interact(ctx)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Unable to interact with store")
@@ -42,7 +47,10 @@ func (a *app) GetISA(ctx context.Context, id dssmodels.ID) (*ridmodels.Identific
 }
 
 // SearchISAs for ISA within the volume bounds.
-func (a *app) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time) ([]*ridmodels.IdentificationServiceArea, error) {
+func (a *app) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time, limit int) ([]*ridmodels.IdentificationServiceArea, error) {
+	ctx, span := tracing.Start(ctx, "rid.SearchISAs")
+	defer span.End()
+
 	now := a.clock.Now()
 	if earliest == nil || earliest.Before(now) {
 		earliest = &now
@@ -53,11 +61,14 @@ func (a *app) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time
 		return nil, stacktrace.Propagate(err, "Unable to interact with store")
 	}
 
-	return repo.SearchISAs(ctx, cells, earliest, latest)
+	return repo.SearchISAs(ctx, cells, earliest, latest, limit)
 }
 
 // DeleteISA the given ISA
 func (a *app) DeleteISA(ctx context.Context, id dssmodels.ID, owner dssmodels.Owner, version *dssmodels.Version) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "rid.DeleteISA")
+	defer span.End()
+
 	var (
 		ret  *ridmodels.IdentificationServiceArea
 		subs []*ridmodels.Subscription
@@ -82,18 +93,24 @@ func (a *app) DeleteISA(ctx context.Context, id dssmodels.ID, owner dssmodels.Ow
 		if err != nil {
 			return stacktrace.Propagate(err, "Error deleting ISA")
 		}
+		if err := repo.AppendAuditRecord(ctx, audit.NewRecord(audit.EntityISA, old.ID, owner.String(), old.Version.String(), "", old.Cells)); err != nil {
+			return stacktrace.Propagate(err, "Error recording ISA deletion")
+		}
 
 		subs, err = repo.UpdateNotificationIdxsInCells(ctx, old.Cells)
 		if err != nil {
 			return stacktrace.Propagate(err, "Error updating notification indices")
 		}
-		return nil
+		return a.enqueueISANotifications(ctx, repo, old, true, subs)
 	})
 	return ret, subs, err // No need to Propagate this error as this stack layer does not add useful information
 }
 
 // InsertISA implments the AppInterface InsertISA method
 func (a *app) InsertISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "rid.InsertISA")
+	defer span.End()
+
 	// Validate and perhaps correct StartTime and EndTime.
 	if err := isa.AdjustTimeRange(a.clock.Now(), nil); err != nil {
 		return nil, nil, stacktrace.Propagate(err, "Error adjusting time range")
@@ -125,13 +142,19 @@ func (a *app) InsertISA(ctx context.Context, isa *ridmodels.IdentificationServic
 		if err != nil {
 			return stacktrace.Propagate(err, "Error inserting ISA")
 		}
-		return nil
+		if err := repo.AppendAuditRecord(ctx, audit.NewRecord(audit.EntityISA, ret.ID, ret.Owner.String(), "", ret.Version.String(), ret.Cells)); err != nil {
+			return stacktrace.Propagate(err, "Error recording ISA creation")
+		}
+		return a.enqueueISANotifications(ctx, repo, ret, false, subs)
 	})
 	return ret, subs, err // No need to Propagate this error as this stack layer does not add useful information
 }
 
 // UpdateISA implments the AppInterface UpdateISA method
 func (a *app) UpdateISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "rid.UpdateISA")
+	defer span.End()
+
 	// Update the notification index for both cells removed and added.
 	var (
 		ret  *ridmodels.IdentificationServiceArea
@@ -163,6 +186,9 @@ func (a *app) UpdateISA(ctx context.Context, isa *ridmodels.IdentificationServic
 		if err != nil {
 			return stacktrace.Propagate(err, "Error updating ISA")
 		}
+		if err := repo.AppendAuditRecord(ctx, audit.NewRecord(audit.EntityISA, ret.ID, ret.Owner.String(), old.Version.String(), ret.Version.String(), ret.Cells)); err != nil {
+			return stacktrace.Propagate(err, "Error recording ISA update")
+		}
 
 		// TODO steeling, we should change this to a Custom type, to obfuscate
 		// some of these metrics and prevent us from doing the wrong thing.
@@ -175,8 +201,35 @@ func (a *app) UpdateISA(ctx context.Context, isa *ridmodels.IdentificationServic
 		if err != nil {
 			return stacktrace.Propagate(err, "Error updating notification indices")
 		}
-		return nil
+		return a.enqueueISANotifications(ctx, repo, ret, false, subs)
 	})
 
 	return ret, subs, err // No need to Propagate this error as this stack layer does not add useful information
 }
+
+// enqueueISANotifications enqueues in repo the notifications of the change to
+// isa for the Subscriptions in subs not owned by the owner of isa, if a sends
+// notifications.
+func (a *app) enqueueISANotifications(ctx context.Context, repo repos.Repository, isa *ridmodels.IdentificationServiceArea, deleted bool, subs []*ridmodels.Subscription) error {
+	if a.isaNotifications == nil {
+		return nil
+	}
+	var recipients []*ridmodels.Subscription
+	for _, sub := range subs {
+		if sub.Owner != isa.Owner {
+			recipients = append(recipients, sub)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	ns, err := a.isaNotifications(isa, deleted, recipients)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error building ISA notifications")
+	}
+	if err := repo.EnqueueNotifications(ctx, ns); err != nil {
+		return stacktrace.Propagate(err, "Error enqueuing ISA notifications")
+	}
+	return nil
+}
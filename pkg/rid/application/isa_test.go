@@ -8,9 +8,12 @@ import (
 	"github.com/coreos/go-semver/semver"
 	"github.com/golang/geo/s2"
 	"github.com/google/uuid"
+	"github.com/interuss/dss/pkg/audit"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
 	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	ridm "github.com/interuss/dss/pkg/rid/store/memory"
 	"github.com/interuss/stacktrace"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -73,7 +76,7 @@ func (store *isaStore) GetVersion(ctx context.Context) (*semver.Version, error)
 }
 
 // Implements repos.ISA.SearchISA
-func (store *isaStore) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time) ([]*ridmodels.IdentificationServiceArea, error) {
+func (store *isaStore) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time, limit int) ([]*ridmodels.IdentificationServiceArea, error) {
 	var isas []*ridmodels.IdentificationServiceArea
 
 	for _, isa := range store.isas {
@@ -85,7 +88,7 @@ func (store *isaStore) SearchISAs(ctx context.Context, cells s2.CellUnion, earli
 }
 
 // Implements repos.ISA.ListExpiredISAs
-func (store *isaStore) ListExpiredISAs(ctx context.Context, writer string) ([]*ridmodels.IdentificationServiceArea, error) {
+func (store *isaStore) ListExpiredISAs(ctx context.Context, writer *string) ([]*ridmodels.IdentificationServiceArea, error) {
 	return make([]*ridmodels.IdentificationServiceArea, 0), nil
 }
 
@@ -142,7 +145,7 @@ func TestISAUpdateIdxCells(t *testing.T) {
 		require.Equal(t, 1, sub.NotificationIndex)
 	}
 
-	isas, err := app.SearchISAs(ctx, isa.Cells, &startTime, nil)
+	isas, err := app.SearchISAs(ctx, isa.Cells, &startTime, nil, dssmodels.MaxResultLimit)
 	require.NoError(t, err)
 	require.NotNil(t, isas)
 	require.Len(t, isas, 1)
@@ -378,3 +381,89 @@ func TestAppDeleteISAs(t *testing.T) {
 		require.Equal(t, 44, subscriptionsOut[i].NotificationIndex)
 	}
 }
+
+func TestISAChangesEnqueueNotifications(t *testing.T) {
+	ctx := context.Background()
+	ridm.DefaultClock = fakeClock
+	store := ridm.NewStore()
+
+	type notified struct {
+		deleted bool
+		subs    int
+	}
+	var built []notified
+	app := NewFromTransactorWithNotifications(store, zap.L(), func(isa *ridmodels.IdentificationServiceArea, deleted bool, subs []*ridmodels.Subscription) ([]*notifications.Notification, error) {
+		built = append(built, notified{deleted: deleted, subs: len(subs)})
+		var ns []*notifications.Notification
+		for _, sub := range subs {
+			n, err := notifications.New(sub.URL+"/"+isa.ID.String(), "scope", isa.ID)
+			if err != nil {
+				return nil, err
+			}
+			ns = append(ns, n)
+		}
+		return ns, nil
+	})
+
+	cells := s2.CellUnion{17106221850767130624}
+	for _, owner := range []dssmodels.Owner{"owner", "other"} {
+		_, err := app.InsertSubscription(ctx, &ridmodels.Subscription{
+			ID:        dssmodels.ID(uuid.New().String()),
+			Owner:     owner,
+			URL:       "https://" + owner.String(),
+			StartTime: &startTime,
+			EndTime:   &endTime,
+			Cells:     cells,
+		})
+		require.NoError(t, err)
+	}
+
+	isa, _, err := app.InsertISA(ctx, &ridmodels.IdentificationServiceArea{
+		ID:        dssmodels.ID(uuid.New().String()),
+		Owner:     "owner",
+		StartTime: &startTime,
+		EndTime:   &endTime,
+		Cells:     cells,
+	})
+	require.NoError(t, err)
+	_, _, err = app.DeleteISA(ctx, isa.ID, isa.Owner, isa.Version)
+	require.NoError(t, err)
+
+	// Only the Subscription of the other owner is notified, of both changes.
+	require.Equal(t, []notified{{deleted: false, subs: 1}, {deleted: true, subs: 1}}, built)
+	pending, err := store.ClaimNotifications(ctx, fakeClock.Now(), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	for _, n := range pending {
+		require.Equal(t, "https://other/"+isa.ID.String(), n.URL)
+	}
+}
+
+func TestISAChangesAreAudited(t *testing.T) {
+	ctx := context.Background()
+	ridm.DefaultClock = fakeClock
+	store := ridm.NewStore()
+	app := NewFromTransactor(store, zap.L())
+
+	isa, _, err := app.InsertISA(ctx, &ridmodels.IdentificationServiceArea{
+		ID:        dssmodels.ID(uuid.New().String()),
+		Owner:     "owner",
+		StartTime: &startTime,
+		EndTime:   &endTime,
+		Cells:     s2.CellUnion{17106221850767130624},
+	})
+	require.NoError(t, err)
+	_, _, err = app.DeleteISA(ctx, isa.ID, "other", isa.Version)
+	require.Error(t, err)
+	_, _, err = app.DeleteISA(ctx, isa.ID, isa.Owner, isa.Version)
+	require.NoError(t, err)
+
+	records, err := store.QueryAuditRecords(ctx, audit.Filter{EntityID: isa.ID})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, audit.OperationCreate, records[0].Operation)
+	require.Equal(t, isa.Version.String(), records[0].NewVersion)
+	require.Equal(t, audit.OperationDelete, records[1].Operation)
+	require.Equal(t, isa.Version.String(), records[1].OldVersion)
+	require.Equal(t, "owner", records[1].Manager)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 
 	"github.com/golang/geo/s2"
+	"github.com/interuss/dss/pkg/audit"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	ridmodels "github.com/interuss/dss/pkg/rid/models"
 	"github.com/interuss/dss/pkg/rid/repos"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/stacktrace"
 	"go.uber.org/zap"
 )
@@ -34,11 +36,14 @@ type SubscriptionApp interface {
 	// UpdateSubscription
 	UpdateSubscription(ctx context.Context, s *ridmodels.Subscription) (*ridmodels.Subscription, error)
 
-	// SearchSubscriptionsByOwner returns all IdentificationServiceAreas ownded by "owner" in "cells".
-	SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) ([]*ridmodels.Subscription, error)
+	// SearchSubscriptionsByOwner returns up to "limit" Subscriptions ownded by "owner" in "cells".
+	SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner, limit int) ([]*ridmodels.Subscription, error)
 }
 
 func (a *app) GetSubscription(ctx context.Context, id dssmodels.ID) (*ridmodels.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "rid.GetSubscription")
+	defer span.End()
+
 	repo, err := a.Store.Interact(ctx)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Unable to interact with store")
@@ -46,15 +51,21 @@ func (a *app) GetSubscription(ctx context.Context, id dssmodels.ID) (*ridmodels.
 	return repo.GetSubscription(ctx, id)
 }
 
-func (a *app) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) ([]*ridmodels.Subscription, error) {
+func (a *app) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner, limit int) ([]*ridmodels.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "rid.SearchSubscriptionsByOwner")
+	defer span.End()
+
 	repo, err := a.Store.Interact(ctx)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Unable to interact with store")
 	}
-	return repo.SearchSubscriptionsByOwner(ctx, cells, owner)
+	return repo.SearchSubscriptionsByOwner(ctx, cells, owner, limit)
 }
 
 func (a *app) InsertSubscription(ctx context.Context, s *ridmodels.Subscription) (*ridmodels.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "rid.InsertSubscription")
+	defer span.End()
+
 	// Validate and perhaps correct StartTime and EndTime.
 	if err := s.AdjustTimeRange(a.clock.Now(), nil); err != nil {
 		return nil, stacktrace.Propagate(err, "Unable to adjust time range")
@@ -88,6 +99,9 @@ func (a *app) InsertSubscription(ctx context.Context, s *ridmodels.Subscription)
 		if err != nil {
 			return stacktrace.Propagate(err, "Error inserting Subscription into repo")
 		}
+		if err := repo.AppendAuditRecord(ctx, audit.NewRecord(audit.EntityRIDSubscription, sub.ID, sub.Owner.String(), "", sub.Version.String(), sub.Cells)); err != nil {
+			return stacktrace.Propagate(err, "Error recording Subscription creation")
+		}
 
 		return nil
 	})
@@ -96,6 +110,9 @@ func (a *app) InsertSubscription(ctx context.Context, s *ridmodels.Subscription)
 
 // InsertSubscription implements the App InsertSubscription method
 func (a *app) UpdateSubscription(ctx context.Context, s *ridmodels.Subscription) (*ridmodels.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "rid.UpdateSubscription")
+	defer span.End()
+
 	var sub *ridmodels.Subscription
 
 	err := a.Store.Transact(ctx, func(repo repos.Repository) error {
@@ -137,6 +154,9 @@ func (a *app) UpdateSubscription(ctx context.Context, s *ridmodels.Subscription)
 		if err != nil {
 			return stacktrace.Propagate(err, "Error updating Subscription in repo")
 		}
+		if err := repo.AppendAuditRecord(ctx, audit.NewRecord(audit.EntityRIDSubscription, sub.ID, sub.Owner.String(), old.Version.String(), sub.Version.String(), sub.Cells)); err != nil {
+			return stacktrace.Propagate(err, "Error recording Subscription update")
+		}
 		return nil
 	})
 	return sub, err
@@ -144,6 +164,9 @@ func (a *app) UpdateSubscription(ctx context.Context, s *ridmodels.Subscription)
 
 // DeleteSubscription deletes the Subscription identified by "id" and owned by "owner".
 func (a *app) DeleteSubscription(ctx context.Context, id dssmodels.ID, owner dssmodels.Owner, version *dssmodels.Version) (*ridmodels.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "rid.DeleteSubscription")
+	defer span.End()
+
 	var ret *ridmodels.Subscription
 	err := a.Store.Transact(ctx, func(repo repos.Repository) error {
 		var err error
@@ -167,6 +190,9 @@ func (a *app) DeleteSubscription(ctx context.Context, id dssmodels.ID, owner dss
 		if err != nil {
 			return stacktrace.Propagate(err, "Error deleting Subscription from repo")
 		}
+		if err := repo.AppendAuditRecord(ctx, audit.NewRecord(audit.EntityRIDSubscription, old.ID, owner.String(), old.Version.String(), "", old.Cells)); err != nil {
+			return stacktrace.Propagate(err, "Error recording Subscription deletion")
+		}
 		return nil
 	})
 	return ret, err
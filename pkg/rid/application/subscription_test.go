@@ -97,7 +97,7 @@ func (store *subscriptionStore) UpdateSubscription(ctx context.Context, s *ridmo
 	return &returnedCopy, nil
 }
 
-func (store *subscriptionStore) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) ([]*ridmodels.Subscription, error) {
+func (store *subscriptionStore) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner, limit int) ([]*ridmodels.Subscription, error) {
 	var subs []*ridmodels.Subscription
 
 	res, _ := store.SearchSubscriptions(ctx, cells)
@@ -119,7 +119,7 @@ func (store *subscriptionStore) UpdateNotificationIdxsInCells(ctx context.Contex
 
 func (store *subscriptionStore) MaxSubscriptionCountInCellsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) (int, error) {
 	max := 0
-	subs, _ := store.SearchSubscriptionsByOwner(ctx, cells, owner)
+	subs, _ := store.SearchSubscriptionsByOwner(ctx, cells, owner, dssmodels.MaxResultLimit)
 
 	cellMap := make(map[s2.CellID]int)
 	for _, s := range subs {
@@ -158,7 +158,7 @@ func (store *subscriptionStore) SearchSubscriptions(ctx context.Context, cells s
 	return subs, nil
 }
 
-func (store *subscriptionStore) ListExpiredSubscriptions(ctx context.Context, writer string) ([]*ridmodels.Subscription, error) {
+func (store *subscriptionStore) ListExpiredSubscriptions(ctx context.Context, writer *string) ([]*ridmodels.Subscription, error) {
 	return make([]*ridmodels.Subscription, 0), nil
 }
 
@@ -211,7 +211,7 @@ func TestSubscriptionUpdateCells(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, sub)
 
-	subs, err := app.SearchSubscriptionsByOwner(ctx, sub.Cells, owner)
+	subs, err := app.SearchSubscriptionsByOwner(ctx, sub.Cells, owner, dssmodels.MaxResultLimit)
 	require.NoError(t, err)
 	require.NotNil(t, subs)
 	require.Len(t, subs, 1)
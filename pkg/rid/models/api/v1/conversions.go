@@ -118,6 +118,19 @@ func ToVolume3D(vol3 *dssmodels.Volume3D) (*restapi.Volume3D, error) {
 	return result, nil
 }
 
+// ToSubmittedExtents converts the extents submitted for an entity to a RID v1
+// REST model, or returns nil if they are unknown or, as circular outlines
+// submitted through RID v2, cannot be represented in RID v1.
+func ToSubmittedExtents(extents *dssmodels.Volume4D) (*restapi.Volume4D, error) {
+	if extents == nil {
+		return nil, nil
+	}
+	if _, ok := extents.SpatialVolume.Footprint.(*dssmodels.GeoPolygon); !ok {
+		return nil, nil
+	}
+	return ToVolume4D(extents)
+}
+
 // ToGeoPolygon converts GeoPolygon business object to a RID v1 REST model
 func ToGeoPolygon(gp *dssmodels.GeoPolygon) *restapi.GeoPolygon {
 	if gp == nil {
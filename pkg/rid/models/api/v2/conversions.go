@@ -164,6 +164,90 @@ func ToTime(t *time.Time) *restapi.Time {
 	return result
 }
 
+// ToAltitude converts an altitude in meters above the WGS84 ellipsoid to RID
+// v2 REST model
+func ToAltitude(alt *float32) *restapi.Altitude {
+	if alt == nil {
+		return nil
+	}
+
+	return &restapi.Altitude{
+		Reference: "W84",
+		Units:     "M",
+		Value:     float64(*alt),
+	}
+}
+
+// ToVolume4D converts Volume4D business object to RID v2 REST model
+func ToVolume4D(vol4 *dssmodels.Volume4D) (*restapi.Volume4D, error) {
+	vol3, err := ToVolume3D(vol4.SpatialVolume)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	return &restapi.Volume4D{
+		Volume:    *vol3,
+		TimeStart: ToTime(vol4.StartTime),
+		TimeEnd:   ToTime(vol4.EndTime),
+	}, nil
+}
+
+// ToSubmittedExtents converts the extents submitted for an entity to RID v2
+// REST model, or returns nil if they are unknown.
+func ToSubmittedExtents(extents *dssmodels.Volume4D) (*restapi.Volume4D, error) {
+	if extents == nil {
+		return nil, nil
+	}
+	return ToVolume4D(extents)
+}
+
+// ToVolume3D converts Volume3D business object to RID v2 REST model
+func ToVolume3D(vol3 *dssmodels.Volume3D) (*restapi.Volume3D, error) {
+	if vol3 == nil {
+		return nil, stacktrace.NewError("Missing spatial volume")
+	}
+
+	result := &restapi.Volume3D{
+		AltitudeLower: ToAltitude(vol3.AltitudeLo),
+		AltitudeUpper: ToAltitude(vol3.AltitudeHi),
+	}
+
+	switch t := vol3.Footprint.(type) {
+	case *dssmodels.GeoPolygon:
+		result.OutlinePolygon = ToPolygon(t)
+	case *dssmodels.GeoCircle:
+		result.OutlineCircle = ToCircle(t)
+	default:
+		return nil, stacktrace.NewError("Unsupported geometry type: %T", vol3.Footprint)
+	}
+
+	return result, nil
+}
+
+// ToPolygon converts GeoPolygon business object to RID v2 REST model
+func ToPolygon(gp *dssmodels.GeoPolygon) *restapi.Polygon {
+	result := &restapi.Polygon{
+		Vertices: make([]restapi.LatLngPoint, 0, len(gp.Vertices)),
+	}
+
+	for _, pt := range gp.Vertices {
+		result.Vertices = append(result.Vertices, *ToLatLngPoint(pt))
+	}
+
+	return result
+}
+
+// ToCircle converts GeoCircle business object to RID v2 REST model
+func ToCircle(gc *dssmodels.GeoCircle) *restapi.Circle {
+	return &restapi.Circle{
+		Center: ToLatLngPoint(&gc.Center),
+		Radius: &restapi.Radius{
+			Units: "M",
+			Value: gc.RadiusMeter,
+		},
+	}
+}
+
 // ToLatLngPoint converts latlngpoint business object to RID v2 REST model
 func ToLatLngPoint(pt *dssmodels.LatLngPoint) *restapi.LatLngPoint {
 	result := &restapi.LatLngPoint{
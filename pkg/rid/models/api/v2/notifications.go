@@ -0,0 +1,46 @@
+package apiv2
+
+import (
+	"fmt"
+	"strings"
+
+	restapi "github.com/interuss/dss/pkg/api/ridv2"
+	"github.com/interuss/dss/pkg/notifications"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/interuss/stacktrace"
+)
+
+// isaNotification is the body of the notification of an ISA change sent to
+// the USSs with relevant Subscriptions, as defined by the
+// PutIdentificationServiceAreaNotificationParameters of the RID v2 USS API.
+type isaNotification struct {
+	Subscriptions []restapi.SubscriptionState `json:"subscriptions"`
+	// ServiceArea is the new state of the ISA, absent if it was deleted.
+	ServiceArea *restapi.IdentificationServiceArea `json:"service_area,omitempty"`
+}
+
+// MakeISANotifications builds the RID v2 notifications of a change to isa to
+// the USSs managing subs. It implements application.ISANotificationsBuilder.
+//
+// The extents of the ISA are not included as the DSS only retains their
+// covering.
+func MakeISANotifications(isa *ridmodels.IdentificationServiceArea, deleted bool, subs []*ridmodels.Subscription) ([]*notifications.Notification, error) {
+	var serviceArea *restapi.IdentificationServiceArea
+	if !deleted {
+		serviceArea = ToIdentificationServiceArea(isa)
+	}
+
+	var result []*notifications.Notification
+	for _, subscriber := range MakeSubscribersToNotify(subs) {
+		url := fmt.Sprintf("%s/uss/identification_service_areas/%s", strings.TrimSuffix(string(subscriber.Url), "/"), isa.ID)
+		n, err := notifications.New(url, string(restapi.RidServiceProviderScope), isaNotification{
+			Subscriptions: subscriber.Subscriptions,
+			ServiceArea:   serviceArea,
+		})
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error building notification to %s", url)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
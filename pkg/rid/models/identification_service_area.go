@@ -16,6 +16,7 @@ type IdentificationServiceArea struct {
 	URL        string
 	Owner      dssmodels.Owner
 	Cells      s2.CellUnion
+	Footprint  dssmodels.Geometry
 	StartTime  *time.Time
 	EndTime    *time.Time
 	Version    *dssmodels.Version
@@ -44,6 +45,7 @@ func (i *IdentificationServiceArea) SetExtents(extents *dssmodels.Volume4D) erro
 	i.EndTime = extents.EndTime
 	i.AltitudeHi = extents.SpatialVolume.AltitudeHi
 	i.AltitudeLo = extents.SpatialVolume.AltitudeLo
+	i.Footprint = extents.SpatialVolume.Footprint
 	i.Cells, err = extents.SpatialVolume.Footprint.CalculateCovering()
 	if err != nil {
 		return stacktrace.Propagate(err, "Error calculating covering for ISA")
@@ -86,3 +88,33 @@ func (i *IdentificationServiceArea) AdjustTimeRange(now time.Time, old *Identifi
 
 	return nil
 }
+
+// SubmittedExtents returns the extents submitted for i, or nil if its
+// footprint is unknown.
+func (i *IdentificationServiceArea) SubmittedExtents() *dssmodels.Volume4D {
+	if i.Footprint == nil {
+		return nil
+	}
+	return &dssmodels.Volume4D{
+		StartTime: i.StartTime,
+		EndTime:   i.EndTime,
+		SpatialVolume: &dssmodels.Volume3D{
+			AltitudeLo: i.AltitudeLo,
+			AltitudeHi: i.AltitudeHi,
+			Footprint:  i.Footprint,
+		},
+	}
+}
+
+// FilterISAsByFootprint returns the IdentificationServiceAreas among isas
+// which submitted outline intersects footprint, keeping those which outline
+// is unknown.
+func FilterISAsByFootprint(isas []*IdentificationServiceArea, footprint dssmodels.Geometry) []*IdentificationServiceArea {
+	result := make([]*IdentificationServiceArea, 0, len(isas))
+	for _, isa := range isas {
+		if dssmodels.FootprintsIntersect(isa.Footprint, footprint) {
+			result = append(result, isa)
+		}
+	}
+	return result
+}
@@ -27,6 +27,7 @@ type Subscription struct {
 	NotificationIndex int
 	Owner             dssmodels.Owner
 	Cells             s2.CellUnion
+	Footprint         dssmodels.Geometry
 	StartTime         *time.Time
 	EndTime           *time.Time
 	Version           *dssmodels.Version
@@ -59,6 +60,7 @@ func (s *Subscription) SetExtents(extents *dssmodels.Volume4D) error {
 	s.EndTime = extents.EndTime
 	s.AltitudeHi = extents.SpatialVolume.AltitudeHi
 	s.AltitudeLo = extents.SpatialVolume.AltitudeLo
+	s.Footprint = extents.SpatialVolume.Footprint
 	s.Cells, err = extents.SpatialVolume.Footprint.CalculateCovering()
 	if err != nil {
 		return stacktrace.Propagate(err, "Error calculating covering for Subscription")
@@ -66,6 +68,23 @@ func (s *Subscription) SetExtents(extents *dssmodels.Volume4D) error {
 	return nil
 }
 
+// SubmittedExtents returns the extents submitted for s, or nil if its
+// footprint is unknown.
+func (s *Subscription) SubmittedExtents() *dssmodels.Volume4D {
+	if s.Footprint == nil {
+		return nil
+	}
+	return &dssmodels.Volume4D{
+		StartTime: s.StartTime,
+		EndTime:   s.EndTime,
+		SpatialVolume: &dssmodels.Volume3D{
+			AltitudeLo: s.AltitudeLo,
+			AltitudeHi: s.AltitudeHi,
+			Footprint:  s.Footprint,
+		},
+	}
+}
+
 // AdjustTimeRange adjusts the time range to the max allowed ranges on a
 // subscription.
 func (s *Subscription) AdjustTimeRange(now time.Time, old *Subscription) error {
@@ -0,0 +1,15 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/interuss/dss/pkg/audit"
+)
+
+// Audit is an interface to the audit trail of the changes made to remote ID
+// entities.
+type Audit interface {
+	// AppendAuditRecord adds r to the audit trail, recorded at the timestamp of
+	// the ongoing transaction, if any.
+	AppendAuditRecord(ctx context.Context, r *audit.Record) error
+}
@@ -0,0 +1,15 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/interuss/dss/pkg/notifications"
+)
+
+// Notifications is an interface to the outbox of the notifications sent by the
+// DSS to USSs.
+type Notifications interface {
+	// EnqueueNotifications adds ns to the outbox. They are delivered once the
+	// ongoing transaction, if any, is committed.
+	EnqueueNotifications(ctx context.Context, ns []*notifications.Notification) error
+}
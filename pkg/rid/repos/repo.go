@@ -4,4 +4,6 @@ package repos
 type Repository interface {
 	ISA
 	Subscription
+	Notifications
+	Audit
 }
@@ -28,8 +28,8 @@ type Subscription interface {
 	// SearchSubscriptions returns all subscriptions ownded by in "cells".
 	SearchSubscriptions(ctx context.Context, cells s2.CellUnion) ([]*ridmodels.Subscription, error)
 
-	// SearchSubscriptionsByOwner returns all subscriptions ownded by "owner" in "cells".
-	SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) ([]*ridmodels.Subscription, error)
+	// SearchSubscriptionsByOwner returns up to "limit" subscriptions ownded by "owner" in "cells".
+	SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner, limit int) ([]*ridmodels.Subscription, error)
 
 	// UpdateNotificationIdxsInCells incremement the notification for each sub in the given cells.
 	UpdateNotificationIdxsInCells(ctx context.Context, cells s2.CellUnion) ([]*ridmodels.Subscription, error)
@@ -38,6 +38,7 @@ type Subscription interface {
 	// belonging to the given owner, and returns that number.
 	MaxSubscriptionCountInCellsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) (int, error)
 
-	// ListExpiredSubscriptions lists all expired Subscriptions based on writer.
-	ListExpiredSubscriptions(ctx context.Context, writer string) ([]*ridmodels.Subscription, error)
+	// ListExpiredSubscriptions lists all expired Subscriptions based on writer,
+	// or of every writer if writer is nil.
+	ListExpiredSubscriptions(ctx context.Context, writer *string) ([]*ridmodels.Subscription, error)
 }
@@ -43,8 +43,14 @@ func (s *Server) GetIdentificationServiceArea(ctx context.Context, req *restapi.
 		return restapi.GetIdentificationServiceAreaResponseSet{Response404: &restapi.ErrorResponse{
 			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.NotFound, "ISA %s not found", req.Id))}}
 	}
+	extents, err := apiv1.ToSubmittedExtents(isa.SubmittedExtents())
+	if err != nil {
+		return restapi.GetIdentificationServiceAreaResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Could not convert ISA extents"))}}
+	}
 	return restapi.GetIdentificationServiceAreaResponseSet{Response200: &restapi.GetIdentificationServiceAreaResponse{
-		ServiceArea: *apiv1.ToIdentificationServiceArea(isa)}}
+		ServiceArea: *apiv1.ToIdentificationServiceArea(isa),
+		Extents:     extents}}
 }
 
 // CreateIdentificationServiceArea creates an ISA
@@ -315,7 +321,7 @@ func (s *Server) SearchIdentificationServiceAreas(ctx context.Context, req *rest
 
 	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
 	defer cancel()
-	isas, err := s.App.SearchISAs(ctx, cu, earliest, latest)
+	isas, err := s.App.SearchISAs(ctx, cu, earliest, latest, s.resultLimit()+1)
 	if err != nil {
 		err = stacktrace.Propagate(err, "Unable to search ISAs")
 		if stacktrace.GetCode(err) == dsserr.BadRequest {
@@ -325,6 +331,16 @@ func (s *Server) SearchIdentificationServiceAreas(ctx context.Context, req *rest
 		return restapi.SearchIdentificationServiceAreasResponseSet{Response500: &api.InternalServerErrorBody{
 			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
 	}
+	isas = api.TruncateResults(ctx, isas, s.resultLimit())
+
+	if s.ExactGeometry {
+		area, err := dssmodels.GeoPolygonFromArea(string(*req.Area))
+		if err != nil {
+			return restapi.SearchIdentificationServiceAreasResponseSet{Response400: &restapi.ErrorResponse{
+				Message: dsserr.Handle(ctx, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid area"))}}
+		}
+		isas = ridmodels.FilterISAsByFootprint(isas, area)
+	}
 
 	areas := make([]restapi.IdentificationServiceArea, 0, len(isas))
 	for _, isa := range isas {
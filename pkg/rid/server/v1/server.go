@@ -7,6 +7,7 @@ import (
 	"github.com/interuss/dss/pkg/api"
 	restapi "github.com/interuss/dss/pkg/api/ridv1"
 	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
 	"github.com/interuss/stacktrace"
 	"github.com/robfig/cron/v3"
 
@@ -20,6 +21,20 @@ type Server struct {
 	Locality          string
 	AllowHTTPBaseUrls bool
 	Cron              *cron.Cron
+	// ExactGeometry enables the refinement of cell-based searches with the
+	// submitted outlines of the ISAs found.
+	ExactGeometry bool
+	// MaxResultLimit is the maximum number of entities returned by a search,
+	// dssmodels.MaxResultLimit if 0.
+	MaxResultLimit int
+}
+
+// resultLimit returns the maximum number of entities returned by a search.
+func (s *Server) resultLimit() int {
+	if s.MaxResultLimit > 0 {
+		return s.MaxResultLimit
+	}
+	return dssmodels.MaxResultLimit
 }
 
 func setAuthError(ctx context.Context, authErr error, resp401, resp403 **restapi.ErrorResponse, resp500 **api.InternalServerErrorBody) {
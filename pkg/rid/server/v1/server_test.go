@@ -70,7 +70,7 @@ func (ma *mockApp) DeleteSubscription(ctx context.Context, id dssmodels.ID, owne
 	return args.Get(0).(*ridmodels.Subscription), args.Error(1)
 }
 
-func (ma *mockApp) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) ([]*ridmodels.Subscription, error) {
+func (ma *mockApp) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner, limit int) ([]*ridmodels.Subscription, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	args := ma.Called(ctx, cells, owner)
@@ -101,7 +101,7 @@ func (ma *mockApp) UpdateISA(ctx context.Context, isa *ridmodels.IdentificationS
 	return args.Get(0).(*ridmodels.IdentificationServiceArea), args.Get(1).([]*ridmodels.Subscription), args.Error(2)
 }
 
-func (ma *mockApp) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time) ([]*ridmodels.IdentificationServiceArea, error) {
+func (ma *mockApp) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time, limit int) ([]*ridmodels.IdentificationServiceArea, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	args := ma.Called(ctx, cells, earliest, latest)
@@ -186,6 +186,7 @@ func TestCreateSubscription(t *testing.T) {
 				AltitudeHi: (*float32)(testdata.LoopVolume3D.AltitudeHi),
 				AltitudeLo: (*float32)(testdata.LoopVolume3D.AltitudeLo),
 				Cells:      mustPolygonToCellIDs(&testdata.LoopPolygon),
+				Footprint:  apiv1.FromGeoPolygon(&testdata.LoopPolygon),
 			},
 		},
 		{
@@ -281,6 +282,7 @@ func TestCreateSubscriptionResponseIncludesISAs(t *testing.T) {
 		AltitudeHi: (*float32)(testdata.LoopVolume3D.AltitudeHi),
 		AltitudeLo: (*float32)(testdata.LoopVolume3D.AltitudeLo),
 		Cells:      cells,
+		Footprint:  apiv1.FromGeoPolygon(&testdata.LoopPolygon),
 	}
 
 	ma := &mockApp{}
@@ -438,6 +440,7 @@ func TestCreateISA(t *testing.T) {
 				URL:        "https://example.com",
 				Owner:      "foo",
 				Cells:      mustPolygonToCellIDs(&testdata.LoopPolygon),
+				Footprint:  apiv1.FromGeoPolygon(&testdata.LoopPolygon),
 				StartTime:  mustTimestamp(testdata.LoopVolume4D.TimeStart),
 				EndTime:    mustTimestamp(testdata.LoopVolume4D.TimeEnd),
 				AltitudeHi: (*float32)(testdata.LoopVolume3D.AltitudeHi),
@@ -540,6 +543,7 @@ func TestUpdateISA(t *testing.T) {
 				URL:        "https://example.com",
 				Owner:      "foo",
 				Cells:      mustPolygonToCellIDs(&testdata.LoopPolygon),
+				Footprint:  apiv1.FromGeoPolygon(&testdata.LoopPolygon),
 				StartTime:  mustTimestamp(testdata.LoopVolume4D.TimeStart),
 				EndTime:    mustTimestamp(testdata.LoopVolume4D.TimeEnd),
 				AltitudeHi: (*float32)(testdata.LoopVolume3D.AltitudeHi),
@@ -93,7 +93,7 @@ func (s *Server) SearchSubscriptions(ctx context.Context, req *restapi.SearchSub
 
 	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
 	defer cancel()
-	subscriptions, err := s.App.SearchSubscriptionsByOwner(ctx, cu, dssmodels.Owner(*req.Auth.ClientID))
+	subscriptions, err := s.App.SearchSubscriptionsByOwner(ctx, cu, dssmodels.Owner(*req.Auth.ClientID), s.resultLimit()+1)
 	if err != nil {
 		err = stacktrace.Propagate(err, "Could not search Subscriptions")
 		if stacktrace.GetCode(err) == dsserr.BadRequest {
@@ -103,6 +103,7 @@ func (s *Server) SearchSubscriptions(ctx context.Context, req *restapi.SearchSub
 		return restapi.SearchSubscriptionsResponseSet{Response500: &api.InternalServerErrorBody{
 			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
 	}
+	subscriptions = api.TruncateResults(ctx, subscriptions, s.resultLimit())
 
 	sp := make([]restapi.Subscription, 0, len(subscriptions))
 	for _, sub := range subscriptions {
@@ -140,8 +141,14 @@ func (s *Server) GetSubscription(ctx context.Context, req *restapi.GetSubscripti
 		return restapi.GetSubscriptionResponseSet{Response404: &restapi.ErrorResponse{
 			Message: dsserr.Handle(ctx, stacktrace.NewErrorWithCode(dsserr.NotFound, "Subscription %s not found", req.Id))}}
 	}
+	extents, err := apiv2.ToSubmittedExtents(subscription.SubmittedExtents())
+	if err != nil {
+		return restapi.GetSubscriptionResponseSet{Response500: &api.InternalServerErrorBody{
+			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Could not convert Subscription extents"))}}
+	}
 	return restapi.GetSubscriptionResponseSet{Response200: &restapi.GetSubscriptionResponse{
-		Subscription: *apiv2.ToSubscription(subscription)}}
+		Subscription: *apiv2.ToSubscription(subscription),
+		Extents:      extents}}
 }
 
 // CreateSubscription creates a single subscription.
@@ -217,15 +224,22 @@ func (s *Server) CreateSubscription(ctx context.Context, req *restapi.CreateSubs
 	}
 
 	// Find ISAs that were in this subscription's area.
-	isas, err := s.App.SearchISAs(ctx, sub.Cells, nil, nil)
+	isas, err := s.App.SearchISAs(ctx, sub.Cells, nil, nil, dssmodels.MaxResultLimit+1)
+	if err == nil {
+		err = dssmodels.CheckCompleteResults(isas, "ISAs")
+	}
 	if err != nil {
 		err = stacktrace.Propagate(err, "Could not search ISAs")
-		if stacktrace.GetCode(err) == dsserr.BadRequest {
-			return restapi.CreateSubscriptionResponseSet{Response400: &restapi.ErrorResponse{
-				Message: dsserr.Handle(ctx, err)}}
+		errResp := &restapi.ErrorResponse{Message: dsserr.Handle(ctx, err)}
+		switch stacktrace.GetCode(err) {
+		case dsserr.BadRequest:
+			return restapi.CreateSubscriptionResponseSet{Response400: errResp}
+		case dsserr.Exhausted:
+			return restapi.CreateSubscriptionResponseSet{Response429: errResp}
+		default:
+			return restapi.CreateSubscriptionResponseSet{Response500: &api.InternalServerErrorBody{
+				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
 		}
-		return restapi.CreateSubscriptionResponseSet{Response500: &api.InternalServerErrorBody{
-			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
 	}
 
 	// Convert the ISAs to REST.
@@ -314,15 +328,22 @@ func (s *Server) UpdateSubscription(ctx context.Context, req *restapi.UpdateSubs
 	}
 
 	// Find ISAs that were in this subscription's area.
-	isas, err := s.App.SearchISAs(ctx, sub.Cells, nil, nil)
+	isas, err := s.App.SearchISAs(ctx, sub.Cells, nil, nil, dssmodels.MaxResultLimit+1)
+	if err == nil {
+		err = dssmodels.CheckCompleteResults(isas, "ISAs")
+	}
 	if err != nil {
 		err = stacktrace.Propagate(err, "Could not search ISAs")
-		if stacktrace.GetCode(err) == dsserr.BadRequest {
-			return restapi.UpdateSubscriptionResponseSet{Response400: &restapi.ErrorResponse{
-				Message: dsserr.Handle(ctx, err)}}
+		errResp := &restapi.ErrorResponse{Message: dsserr.Handle(ctx, err)}
+		switch stacktrace.GetCode(err) {
+		case dsserr.BadRequest:
+			return restapi.UpdateSubscriptionResponseSet{Response400: errResp}
+		case dsserr.Exhausted:
+			return restapi.UpdateSubscriptionResponseSet{Response429: errResp}
+		default:
+			return restapi.UpdateSubscriptionResponseSet{Response500: &api.InternalServerErrorBody{
+				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
 		}
-		return restapi.UpdateSubscriptionResponseSet{Response500: &api.InternalServerErrorBody{
-			ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
 	}
 
 	// Convert the ISAs to REST.
@@ -0,0 +1,19 @@
+package cockroach
+
+import (
+	"context"
+
+	"github.com/interuss/dss/pkg/audit"
+	auditc "github.com/interuss/dss/pkg/audit/cockroach"
+	dssql "github.com/interuss/dss/pkg/sql"
+)
+
+// AppendAuditRecord implements repos.Audit.AppendAuditRecord.
+func (r *repo) AppendAuditRecord(ctx context.Context, rec *audit.Record) error {
+	return auditc.NewLog(r.Queryable).Append(ctx, rec)
+}
+
+// QueryAuditRecords implements audit.Log.
+func (s *Store) QueryAuditRecords(ctx context.Context, filter audit.Filter) ([]*audit.Record, error) {
+	return auditc.NewLog(dssql.WithTracing(s.db.Pool)).QueryAuditRecords(ctx, filter)
+}
@@ -3,16 +3,19 @@ package cockroach
 import (
 	"context"
 
+	"github.com/interuss/dss/pkg/metrics"
 	"github.com/interuss/dss/pkg/rid/repos"
 	"github.com/interuss/stacktrace"
 )
 
 type GarbageCollector struct {
 	repos  repos.Repository
-	writer string
+	writer *string
 }
 
-func NewGarbageCollector(repos repos.Repository, writer string) *GarbageCollector {
+// NewGarbageCollector returns a GarbageCollector deleting the expired records
+// of writer, or of every writer if writer is nil.
+func NewGarbageCollector(repos repos.Repository, writer *string) *GarbageCollector {
 	return &GarbageCollector{
 		repos:  repos,
 		writer: writer,
@@ -43,14 +46,13 @@ func (gc *GarbageCollector) DeleteExpiredISAs(ctx context.Context) error {
 
 	for _, isa := range expiredISAs {
 		isaOut, err := gc.repos.DeleteISA(ctx, isa)
-		if isaOut != nil {
-			return stacktrace.Propagate(err,
-				"Deleted ISA")
-		}
 		if err != nil {
 			return stacktrace.Propagate(err,
 				"Failed to delete ISAs")
 		}
+		if isaOut != nil {
+			metrics.GarbageCollected("rid", "identification_service_area", 1)
+		}
 	}
 
 	return nil
@@ -65,14 +67,13 @@ func (gc *GarbageCollector) DeleteExpiredSubscriptions(ctx context.Context) erro
 
 	for _, sub := range expiredSubscriptions {
 		subOut, err := gc.repos.DeleteSubscription(ctx, sub)
-		if subOut != nil {
-			return stacktrace.Propagate(err,
-				"Deleted Subscription")
-		}
 		if err != nil {
 			return stacktrace.Propagate(err,
 				"Failed to delete Subscription")
 		}
+		if subOut != nil {
+			metrics.GarbageCollected("rid", "subscription", 1)
+		}
 	}
 	return nil
 }
@@ -2,6 +2,7 @@ package cockroach
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/interuss/dss/pkg/rid/repos"
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
 )
@@ -46,7 +48,7 @@ func TestDeleteExpiredISAs(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, ret)
 
-	gc := NewGarbageCollector(repo, writer)
+	gc := NewGarbageCollector(repo, &writer)
 	err = gc.DeleteRIDExpiredRecords(ctx)
 	require.NoError(t, err)
 
@@ -90,7 +92,7 @@ func TestDeleteExpiredSubscriptions(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, ret)
 
-	gc := NewGarbageCollector(repo, writer)
+	gc := NewGarbageCollector(repo, &writer)
 	err = gc.DeleteRIDExpiredRecords(ctx)
 	require.NoError(t, err)
 
@@ -98,3 +100,55 @@ func TestDeleteExpiredSubscriptions(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, ret)
 }
+
+// expiredRepo is a repos.Repository listing a fixed set of expired records,
+// and failing to delete the ones in failing.
+type expiredRepo struct {
+	repos.Repository
+	isas          []*ridmodels.IdentificationServiceArea
+	subscriptions []*ridmodels.Subscription
+	failing       map[dssmodels.ID]bool
+	deleted       []dssmodels.ID
+}
+
+func (r *expiredRepo) ListExpiredISAs(ctx context.Context, writer *string) ([]*ridmodels.IdentificationServiceArea, error) {
+	return r.isas, nil
+}
+
+func (r *expiredRepo) DeleteISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) (*ridmodels.IdentificationServiceArea, error) {
+	if r.failing[isa.ID] {
+		return nil, errors.New("deletion failed")
+	}
+	r.deleted = append(r.deleted, isa.ID)
+	return isa, nil
+}
+
+func (r *expiredRepo) ListExpiredSubscriptions(ctx context.Context, writer *string) ([]*ridmodels.Subscription, error) {
+	return r.subscriptions, nil
+}
+
+func (r *expiredRepo) DeleteSubscription(ctx context.Context, sub *ridmodels.Subscription) (*ridmodels.Subscription, error) {
+	if r.failing[sub.ID] {
+		return nil, errors.New("deletion failed")
+	}
+	r.deleted = append(r.deleted, sub.ID)
+	return sub, nil
+}
+
+func TestDeleteAllExpiredRecords(t *testing.T) {
+	ctx := context.Background()
+	repo := &expiredRepo{
+		isas:          []*ridmodels.IdentificationServiceArea{{ID: "isa1"}, {ID: "isa2"}},
+		subscriptions: []*ridmodels.Subscription{{ID: "sub1"}, {ID: "sub2"}},
+	}
+
+	// Every expired record is deleted, not only the first one.
+	require.NoError(t, NewGarbageCollector(repo, nil).DeleteRIDExpiredRecords(ctx))
+	require.Equal(t, []dssmodels.ID{"isa1", "isa2", "sub1", "sub2"}, repo.deleted)
+
+	// A failed deletion stops the collection and is reported.
+	repo.deleted = nil
+	repo.failing = map[dssmodels.ID]bool{"isa2": true}
+	require.Error(t, NewGarbageCollector(repo, nil).DeleteRIDExpiredRecords(ctx))
+	require.Equal(t, []dssmodels.ID{"isa1"}, repo.deleted)
+}
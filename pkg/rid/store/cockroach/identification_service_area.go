@@ -17,8 +17,8 @@ import (
 )
 
 const (
-	isaFields       = "id, owner, url, cells, starts_at, ends_at, writer, updated_at"
-	updateISAFields = "id, url, cells, starts_at, ends_at, writer, updated_at"
+	isaFields       = "id, owner, url, cells, starts_at, ends_at, writer, footprint, altitude_lower, altitude_upper, updated_at"
+	updateISAFields = "id, url, cells, starts_at, ends_at, writer, footprint, altitude_lower, altitude_upper, updated_at"
 )
 
 func (r *repo) fetchISAs(ctx context.Context, query string, args ...interface{}) ([]*ridmodels.IdentificationServiceArea, error) {
@@ -35,7 +35,10 @@ func (r *repo) fetchISAs(ctx context.Context, query string, args ...interface{})
 	for rows.Next() {
 		i := new(ridmodels.IdentificationServiceArea)
 
-		var updateTime time.Time
+		var (
+			updateTime time.Time
+			footprint  []byte
+		)
 
 		err := rows.Scan(
 			&i.ID,
@@ -45,12 +48,19 @@ func (r *repo) fetchISAs(ctx context.Context, query string, args ...interface{})
 			&i.StartTime,
 			&i.EndTime,
 			&writer,
+			&footprint,
+			&i.AltitudeLo,
+			&i.AltitudeHi,
 			&updateTime,
 		)
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "Error scanning ISA row")
 		}
 		i.Writer = writer.String
+		i.Footprint, err = dssmodels.DecodeFootprint(footprint)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error decoding footprint of ISA %s", i.ID)
+		}
 		i.SetCells(cids)
 		i.Version = dssmodels.VersionFromTime(updateTime)
 		payload = append(payload, i)
@@ -106,7 +116,7 @@ func (r *repo) InsertISA(ctx context.Context, isa *ridmodels.IdentificationServi
 				identification_service_areas
 				(%s)
 			VALUES
-				($1, $2, $3, $4, $5, $6, $7, transaction_timestamp())
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, transaction_timestamp())
 			RETURNING
 				%s`, isaFields, isaFields)
 	)
@@ -124,7 +134,11 @@ func (r *repo) InsertISA(ctx context.Context, isa *ridmodels.IdentificationServi
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
-	return r.fetchISA(ctx, insertAreasQuery, id, isa.Owner, isa.URL, cids, isa.StartTime, isa.EndTime, isa.Writer)
+	footprint, err := dssmodels.EncodeFootprint(isa.Footprint)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to encode footprint")
+	}
+	return r.fetchISA(ctx, insertAreasQuery, id, isa.Owner, isa.URL, cids, isa.StartTime, isa.EndTime, isa.Writer, footprint, isa.AltitudeLo, isa.AltitudeHi)
 
 }
 
@@ -140,7 +154,7 @@ func (r *repo) UpdateISA(ctx context.Context, isa *ridmodels.IdentificationServi
 		updateAreasQuery = fmt.Sprintf(`
 			UPDATE
 				identification_service_areas
-			SET	(%s) = ($1, $2, $3, $4, $5, $7, transaction_timestamp())
+			SET	(%s) = ($1, $2, $3, $4, $5, $7, $8, $9, $10, transaction_timestamp())
 			WHERE id = $1 AND updated_at = $6
 			RETURNING
 				%s`, updateISAFields, isaFields)
@@ -154,7 +168,11 @@ func (r *repo) UpdateISA(ctx context.Context, isa *ridmodels.IdentificationServi
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
-	return r.fetchISA(ctx, updateAreasQuery, id, isa.URL, cids, isa.StartTime, isa.EndTime, isa.Version.ToTimestamp(), isa.Writer)
+	footprint, err := dssmodels.EncodeFootprint(isa.Footprint)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to encode footprint")
+	}
+	return r.fetchISA(ctx, updateAreasQuery, id, isa.URL, cids, isa.StartTime, isa.EndTime, isa.Version.ToTimestamp(), isa.Writer, footprint, isa.AltitudeLo, isa.AltitudeHi)
 }
 
 // DeleteISA deletes the IdentificationServiceArea identified by "id" and owned by "owner".
@@ -180,8 +198,8 @@ func (r *repo) DeleteISA(ctx context.Context, isa *ridmodels.IdentificationServi
 
 // SearchISAs searches IdentificationServiceArea
 // instances that intersect with "cells" and, if set, the temporal volume
-// defined by "earliest" and "latest".
-func (r *repo) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time) ([]*ridmodels.IdentificationServiceArea, error) {
+// defined by "earliest" and "latest", up to "limit" of them.
+func (r *repo) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time, limit int) ([]*ridmodels.IdentificationServiceArea, error) {
 	var (
 		// TODO: make earliest and latest required (NOT NULL) and remove coalesce.
 		// Make them real values (not pointers), on the model layer.
@@ -207,18 +225,15 @@ func (r *repo) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *tim
 		return nil, stacktrace.NewError("Earliest start time is missing")
 	}
 
-	return r.fetchISAs(ctx, isasInCellsQuery, earliest, latest, dssql.CellUnionToCellIds(cells), dssmodels.MaxResultLimit)
+	return r.fetchISAs(ctx, isasInCellsQuery, earliest, latest, dssql.CellUnionToCellIds(cells), limit)
 }
 
 // ListExpiredISAs lists all expired ISAs based on writer.
 // Records expire if current time is <expiredDurationInMin> minutes more than records' endTime.
-// The function queries both empty writer and null writer when passing empty string as a writer.
-func (r *repo) ListExpiredISAs(ctx context.Context, writer string) ([]*ridmodels.IdentificationServiceArea, error) {
-	writerQuery := "'" + writer + "'"
-	if len(writer) == 0 {
-		writerQuery = "'' OR writer = NULL"
-	}
-
+// The function queries both empty writer and null writer when passing empty string as a writer,
+// and every writer when passing nil.
+func (r *repo) ListExpiredISAs(ctx context.Context, writer *string) ([]*ridmodels.IdentificationServiceArea, error) {
+	condition, args := writerCondition(2, writer)
 	var (
 		isasInCellsQuery = fmt.Sprintf(`
 	SELECT
@@ -228,9 +243,9 @@ func (r *repo) ListExpiredISAs(ctx context.Context, writer string) ([]*ridmodels
 	WHERE
 		ends_at + INTERVAL '%d' MINUTE <= CURRENT_TIMESTAMP
 	AND
-		(writer = %s)
-	LIMIT $1`, isaFields, expiredDurationInMin, writerQuery)
+		%s
+	LIMIT $1`, isaFields, expiredDurationInMin, condition)
 	)
 
-	return r.fetchISAs(ctx, isasInCellsQuery, dssmodels.MaxResultLimit)
+	return r.fetchISAs(ctx, isasInCellsQuery, append([]interface{}{dssmodels.MaxResultLimit}, args...)...)
 }
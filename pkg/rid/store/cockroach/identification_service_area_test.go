@@ -133,7 +133,7 @@ func TestStoreSearchISAs(t *testing.T) {
 		t.Run(r.name, func(t *testing.T) {
 			earliest, latest := r.timestampMutator(*saOut.StartTime, *saOut.EndTime)
 
-			serviceAreas, err := repo.SearchISAs(ctx, r.cells, earliest, latest)
+			serviceAreas, err := repo.SearchISAs(ctx, r.cells, earliest, latest, dssmodels.MaxResultLimit)
 			require.NoError(t, err)
 			require.Len(t, serviceAreas, r.expectedLen)
 		})
@@ -182,7 +182,7 @@ func TestStoreExpiredISA(t *testing.T) {
 
 	// We should still be able to find the ISA by searching and by ID.
 	now := fakeClock.Now()
-	serviceAreas, err := repo.SearchISAs(ctx, serviceArea.Cells, &now, nil)
+	serviceAreas, err := repo.SearchISAs(ctx, serviceArea.Cells, &now, nil, dssmodels.MaxResultLimit)
 	require.NoError(t, err)
 	require.Len(t, serviceAreas, 1)
 
@@ -194,7 +194,7 @@ func TestStoreExpiredISA(t *testing.T) {
 	fakeClock.Advance(2 * time.Minute)
 	now = fakeClock.Now()
 
-	serviceAreas, err = repo.SearchISAs(ctx, serviceArea.Cells, &now, nil)
+	serviceAreas, err = repo.SearchISAs(ctx, serviceArea.Cells, &now, nil, dssmodels.MaxResultLimit)
 	require.NoError(t, err)
 	require.Len(t, serviceAreas, 0)
 
@@ -279,7 +279,7 @@ func TestListExpiredISAs(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, saOut2)
 
-	serviceAreas, err := repo.ListExpiredISAs(ctx, writer)
+	serviceAreas, err := repo.ListExpiredISAs(ctx, &writer)
 	require.NoError(t, err)
 	require.Len(t, serviceAreas, 1)
 }
@@ -293,6 +293,7 @@ func TestListExpiredISAsWithEmptyWriter(t *testing.T) {
 	require.NoError(t, err)
 
 	fakeClock := clockwork.NewFakeClockAt(time.Now())
+	emptyWriter := ""
 
 	// Insert ISA with endtime 1 day from now
 	isa1 := *serviceArea
@@ -317,7 +318,7 @@ func TestListExpiredISAsWithEmptyWriter(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, saOut2)
 
-	serviceAreas, err := repo.ListExpiredISAs(ctx, "")
+	serviceAreas, err := repo.ListExpiredISAs(ctx, &emptyWriter)
 	require.NoError(t, err)
 	require.Len(t, serviceAreas, 1)
 }
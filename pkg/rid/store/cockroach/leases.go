@@ -0,0 +1,23 @@
+package cockroach
+
+import (
+	"context"
+	"time"
+
+	leaderc "github.com/interuss/dss/pkg/leader/cockroach"
+	dssql "github.com/interuss/dss/pkg/sql"
+)
+
+func (s *Store) leases() *leaderc.LeaseStore {
+	return leaderc.NewLeaseStore(dssql.WithTracing(s.db.Pool))
+}
+
+// AcquireLease implements leader.LeaseStore.
+func (s *Store) AcquireLease(ctx context.Context, name string, holder string, duration time.Duration) (bool, error) {
+	return s.leases().AcquireLease(ctx, name, holder, duration)
+}
+
+// ReleaseLease implements leader.LeaseStore.
+func (s *Store) ReleaseLease(ctx context.Context, name string, holder string) error {
+	return s.leases().ReleaseLease(ctx, name, holder)
+}
@@ -0,0 +1,25 @@
+package cockroach
+
+import (
+	"context"
+	"time"
+
+	"github.com/interuss/dss/pkg/ratelimit"
+	ratelimitc "github.com/interuss/dss/pkg/ratelimit/cockroach"
+	dssql "github.com/interuss/dss/pkg/sql"
+)
+
+func (s *Store) buckets() *ratelimitc.BucketStore {
+	return ratelimitc.NewBucketStore(dssql.WithTracing(s.db.Pool))
+}
+
+// TakeToken implements ratelimit.BucketStore.
+func (s *Store) TakeToken(ctx context.Context, key string, limit ratelimit.Limit) (time.Duration, error) {
+	return s.buckets().TakeToken(ctx, key, limit)
+}
+
+// DeleteIdleBuckets deletes the rate limit buckets which were not used for at
+// least idle.
+func (s *Store) DeleteIdleBuckets(ctx context.Context, idle time.Duration) (int64, error) {
+	return s.buckets().DeleteIdleBuckets(ctx, idle)
+}
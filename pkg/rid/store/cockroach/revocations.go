@@ -0,0 +1,35 @@
+package cockroach
+
+import (
+	"context"
+	"time"
+
+	"github.com/interuss/dss/pkg/auth"
+	authc "github.com/interuss/dss/pkg/auth/cockroach"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	dssql "github.com/interuss/dss/pkg/sql"
+)
+
+func (s *Store) revocations() *authc.RevocationStore {
+	return authc.NewRevocationStore(dssql.WithTracing(s.db.Pool))
+}
+
+// IsTokenRevoked implements auth.RevocationStore.
+func (s *Store) IsTokenRevoked(ctx context.Context, issuer, jti, subject string, issuedAt time.Time) (bool, error) {
+	return s.revocations().IsTokenRevoked(ctx, issuer, jti, subject, issuedAt)
+}
+
+// InsertRevocation implements auth.RevocationStore.
+func (s *Store) InsertRevocation(ctx context.Context, r *auth.Revocation) (*auth.Revocation, error) {
+	return s.revocations().InsertRevocation(ctx, r)
+}
+
+// ListRevocations implements auth.RevocationStore.
+func (s *Store) ListRevocations(ctx context.Context) ([]*auth.Revocation, error) {
+	return s.revocations().ListRevocations(ctx)
+}
+
+// DeleteRevocation implements auth.RevocationStore.
+func (s *Store) DeleteRevocation(ctx context.Context, id dssmodels.ID) (*auth.Revocation, error) {
+	return s.revocations().DeleteRevocation(ctx, id)
+}
@@ -2,19 +2,22 @@ package cockroach
 
 import (
 	"context"
-	"github.com/cockroachdb/cockroach-go/v2/crdb"
+	"fmt"
 	"github.com/interuss/dss/pkg/datastore/flags"
 	dssql "github.com/interuss/dss/pkg/sql"
 	"time"
 
-	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbpgxv5"
 	"github.com/coreos/go-semver/semver"
 	"github.com/interuss/dss/pkg/datastore"
 	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/metrics"
 	"github.com/interuss/dss/pkg/rid/repos"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/stacktrace"
 	"github.com/jackc/pgx/v5"
 	"github.com/jonboulle/clockwork"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -102,7 +105,7 @@ func (s *Store) CheckCurrentMajorSchemaVersion(ctx context.Context) error {
 func (s *Store) Interact(ctx context.Context) (repos.Repository, error) {
 	logger := logging.WithValuesFromContext(ctx, s.logger)
 	return &repo{
-		Queryable: s.db.Pool,
+		Queryable: dssql.WithTracing(s.db.Pool),
 		clock:     s.clock,
 		logger:    logger,
 	}, nil
@@ -119,17 +122,23 @@ func (s *Store) Transact(ctx context.Context, f func(repo repos.Repository) erro
 	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
 	defer cancel()
 
-	ctx = crdb.WithMaxRetries(ctx, flags.ConnectParameters().MaxRetries)
-
-	return crdbpgx.ExecuteTx(ctx, s.db.Pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+	ctx, span := tracing.Start(ctx, "rid.Transact")
+	attempts := 0
+	err := s.db.ExecuteTx(ctx, flags.ConnectParameters().MaxRetries, func(tx pgx.Tx) error {
+		if attempts++; attempts > 1 {
+			metrics.TransactionRetried("rid")
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempts)))
+		}
 		// Is this recover still necessary?
 		defer recoverRollbackRepanic(ctx, tx)
 		return f(&repo{
-			Queryable: tx,
+			Queryable: dssql.WithTracing(tx),
 			clock:     s.clock,
 			logger:    logger,
 		})
 	})
+	tracing.End(span, err)
+	return err
 }
 
 // Close closes the underlying DB connection.
@@ -170,3 +179,17 @@ func (s *Store) GetVersion(ctx context.Context) (*semver.Version, error) {
 	}
 	return s.version, nil
 }
+
+// writerCondition returns the SQL condition selecting the records of writer,
+// or of every writer if writer is nil, along with its arguments starting at
+// placeholder index.
+func writerCondition(index int, writer *string) (string, []interface{}) {
+	switch {
+	case writer == nil:
+		return "TRUE", nil
+	case *writer == "":
+		return "(writer = '' OR writer IS NULL)", nil
+	default:
+		return fmt.Sprintf("writer = $%d", index), []interface{}{*writer}
+	}
+}
@@ -16,8 +16,8 @@ import (
 )
 
 const (
-	subscriptionFields       = "id, owner, url, notification_index, cells, starts_at, ends_at, writer, updated_at"
-	updateSubscriptionFields = "id, url, notification_index, cells, starts_at, ends_at, writer, updated_at"
+	subscriptionFields       = "id, owner, url, notification_index, cells, starts_at, ends_at, writer, footprint, altitude_lower, altitude_upper, updated_at"
+	updateSubscriptionFields = "id, url, notification_index, cells, starts_at, ends_at, writer, footprint, altitude_lower, altitude_upper, updated_at"
 )
 
 // process a query that should return one or many subscriptions.
@@ -35,7 +35,10 @@ func (r *repo) process(ctx context.Context, query string, args ...interface{}) (
 	for rows.Next() {
 		s := new(ridmodels.Subscription)
 
-		var updateTime time.Time
+		var (
+			updateTime time.Time
+			footprint  []byte
+		)
 
 		err := rows.Scan(
 			&s.ID,
@@ -46,12 +49,19 @@ func (r *repo) process(ctx context.Context, query string, args ...interface{}) (
 			&s.StartTime,
 			&s.EndTime,
 			&writer,
+			&footprint,
+			&s.AltitudeLo,
+			&s.AltitudeHi,
 			&updateTime,
 		)
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "Error scanning Subscription row")
 		}
 		s.Writer = writer.String
+		s.Footprint, err = dssmodels.DecodeFootprint(footprint)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error decoding footprint of Subscription %s", s.ID)
+		}
 
 		s.SetCells(cids)
 		s.Version = dssmodels.VersionFromTime(updateTime)
@@ -87,7 +97,7 @@ func (r *repo) MaxSubscriptionCountInCellsByOwner(ctx context.Context, cells s2.
 	// strict we could keep this count in memory, (or in some other storage).
 	var query = `
     SELECT
-      IFNULL(MAX(subscriptions_per_cell_id), 0)
+      COALESCE(MAX(subscriptions_per_cell_id), 0)
     FROM (
       SELECT
         COUNT(*) AS subscriptions_per_cell_id
@@ -96,11 +106,11 @@ func (r *repo) MaxSubscriptionCountInCellsByOwner(ctx context.Context, cells s2.
       	FROM subscriptions
       	WHERE owner = $1
       		AND ends_at >= $2
-      )
+      ) AS subscription_cells
       WHERE
         cell_id = ANY($3)
       GROUP BY cell_id
-    )`
+    ) AS counts`
 
 	row := r.QueryRow(ctx, query, owner, r.clock.Now(), dssql.CellUnionToCellIds(cells))
 	var ret int
@@ -129,7 +139,7 @@ func (r *repo) UpdateSubscription(ctx context.Context, s *ridmodels.Subscription
 		updateQuery = fmt.Sprintf(`
 		UPDATE
 		  subscriptions
-		SET (%s) = ($1, $2, $3, $4, $5, $6, $7, transaction_timestamp())
+		SET (%s) = ($1, $2, $3, $4, $5, $6, $7, $9, $10, $11, transaction_timestamp())
 		WHERE id = $1 AND updated_at = $8
 		RETURNING
 			%s`, updateSubscriptionFields, subscriptionFields)
@@ -145,6 +155,10 @@ func (r *repo) UpdateSubscription(ctx context.Context, s *ridmodels.Subscription
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
+	footprint, err := dssmodels.EncodeFootprint(s.Footprint)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to encode footprint")
+	}
 	return r.processOne(ctx, updateQuery,
 		id,
 		s.URL,
@@ -153,7 +167,10 @@ func (r *repo) UpdateSubscription(ctx context.Context, s *ridmodels.Subscription
 		s.StartTime,
 		s.EndTime,
 		s.Writer,
-		s.Version.ToTimestamp())
+		s.Version.ToTimestamp(),
+		footprint,
+		s.AltitudeLo,
+		s.AltitudeHi)
 }
 
 // InsertSubscription inserts subscription into the store and returns
@@ -165,7 +182,7 @@ func (r *repo) InsertSubscription(ctx context.Context, s *ridmodels.Subscription
 		  subscriptions
 		  (%s)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, transaction_timestamp())
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, transaction_timestamp())
 		RETURNING
 			%s`, subscriptionFields, subscriptionFields)
 	)
@@ -180,6 +197,10 @@ func (r *repo) InsertSubscription(ctx context.Context, s *ridmodels.Subscription
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
+	footprint, err := dssmodels.EncodeFootprint(s.Footprint)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to encode footprint")
+	}
 	return r.processOne(ctx, insertQuery,
 		id,
 		s.Owner,
@@ -188,7 +209,10 @@ func (r *repo) InsertSubscription(ctx context.Context, s *ridmodels.Subscription
 		cids,
 		s.StartTime,
 		s.EndTime,
-		s.Writer)
+		s.Writer,
+		footprint,
+		s.AltitudeLo,
+		s.AltitudeHi)
 }
 
 // DeleteSubscription deletes the subscription identified by ID.
@@ -247,8 +271,9 @@ func (r *repo) SearchSubscriptions(ctx context.Context, cells s2.CellUnion) ([]*
 	return r.process(ctx, query, dssql.CellUnionToCellIds(cells), r.clock.Now(), dssmodels.MaxResultLimit)
 }
 
-// SearchSubscriptionsByOwner returns all subscriptions in "cells".
-func (r *repo) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) ([]*ridmodels.Subscription, error) {
+// SearchSubscriptionsByOwner returns up to "limit" subscriptions of "owner" in
+// "cells".
+func (r *repo) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner, limit int) ([]*ridmodels.Subscription, error) {
 	var (
 		query = fmt.Sprintf(`
 			SELECT
@@ -268,18 +293,15 @@ func (r *repo) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnio
 		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "no location provided")
 	}
 
-	return r.process(ctx, query, dssql.CellUnionToCellIds(cells), owner, r.clock.Now(), dssmodels.MaxResultLimit)
+	return r.process(ctx, query, dssql.CellUnionToCellIds(cells), owner, r.clock.Now(), limit)
 }
 
 // ListExpiredSubscriptions lists all expired Subscriptions based on writer.
 // Records expire if current time is <expiredDurationInMin> minutes more than records' endTime.
-// The function queries both empty writer and null writer when passing empty string as a writer.
-func (r *repo) ListExpiredSubscriptions(ctx context.Context, writer string) ([]*ridmodels.Subscription, error) {
-	writerQuery := "'" + writer + "'"
-	if len(writer) == 0 {
-		writerQuery = "'' OR writer = NULL"
-	}
-
+// The function queries both empty writer and null writer when passing empty string as a writer,
+// and every writer when passing nil.
+func (r *repo) ListExpiredSubscriptions(ctx context.Context, writer *string) ([]*ridmodels.Subscription, error) {
+	condition, args := writerCondition(1, writer)
 	var (
 		query = fmt.Sprintf(`
 	SELECT
@@ -289,8 +311,8 @@ func (r *repo) ListExpiredSubscriptions(ctx context.Context, writer string) ([]*
 	WHERE
 		ends_at + INTERVAL '%d' MINUTE <= CURRENT_TIMESTAMP
 	AND
-		(writer = %s)`, subscriptionFields, expiredDurationInMin, writerQuery)
+		%s`, subscriptionFields, expiredDurationInMin, condition)
 	)
 
-	return r.process(ctx, query)
+	return r.process(ctx, query, args...)
 }
@@ -215,7 +215,7 @@ func TestStoreSearchSubscription(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, found, 3)
 	for _, owner := range owners {
-		found, err := repo.SearchSubscriptionsByOwner(ctx, cells, owner)
+		found, err := repo.SearchSubscriptionsByOwner(ctx, cells, owner, dssmodels.MaxResultLimit)
 		require.NoError(t, err)
 		require.NotNil(t, found)
 		// We insert one subscription per owner. Hence, no matter how many cells are touched by the subscription,
@@ -246,7 +246,7 @@ func TestStoreExpiredSubscription(t *testing.T) {
 	fakeClock.Advance(23 * time.Hour)
 
 	// We should still be able to find the subscription by searching and by ID.
-	subs, err := repo.SearchSubscriptionsByOwner(ctx, sub.Cells, "original owner")
+	subs, err := repo.SearchSubscriptionsByOwner(ctx, sub.Cells, "original owner", dssmodels.MaxResultLimit)
 	require.NoError(t, err)
 	require.Len(t, subs, 1)
 
@@ -257,7 +257,7 @@ func TestStoreExpiredSubscription(t *testing.T) {
 	// But now the subscription has expired.
 	fakeClock.Advance(2 * time.Hour)
 
-	subs, err = repo.SearchSubscriptionsByOwner(ctx, sub.Cells, "original owner")
+	subs, err = repo.SearchSubscriptionsByOwner(ctx, sub.Cells, "original owner", dssmodels.MaxResultLimit)
 	require.NoError(t, err)
 	require.Len(t, subs, 0)
 
@@ -333,7 +333,7 @@ func TestListExpiredSubscriptions(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, subOut2)
 
-	subscriptions, err := repo.ListExpiredSubscriptions(ctx, writer)
+	subscriptions, err := repo.ListExpiredSubscriptions(ctx, &writer)
 	require.NoError(t, err)
 	require.Len(t, subscriptions, 1)
 }
@@ -347,6 +347,7 @@ func TestListExpiredSubscriptionsWithEmptyWriter(t *testing.T) {
 	require.NoError(t, err)
 
 	fakeClock := clockwork.NewFakeClockAt(time.Now())
+	emptyWriter := ""
 
 	// Insert Subscription with endtime 1 day from now
 	subscripiton1 := *subscriptionsPool[0].input
@@ -371,7 +372,7 @@ func TestListExpiredSubscriptionsWithEmptyWriter(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, subOut2)
 
-	subscriptions, err := repo.ListExpiredSubscriptions(ctx, "")
+	subscriptions, err := repo.ListExpiredSubscriptions(ctx, &emptyWriter)
 	require.NoError(t, err)
 	require.Len(t, subscriptions, 1)
 }
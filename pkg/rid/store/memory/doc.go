@@ -0,0 +1,4 @@
+// Package memory provides an implementation of a dss.Store keeping all of its
+// data in process memory. It is intended for local development and tests and
+// does not persist anything across restarts.
+package memory
@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/golang/geo/s2"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/geo"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/interuss/stacktrace"
+)
+
+// copyISA returns a copy of the fields of isa persisted by the SQL-backed
+// stores.
+func copyISA(isa *ridmodels.IdentificationServiceArea) *ridmodels.IdentificationServiceArea {
+	return &ridmodels.IdentificationServiceArea{
+		ID:         isa.ID,
+		Owner:      isa.Owner,
+		URL:        isa.URL,
+		Cells:      copyCells(isa.Cells),
+		Footprint:  copyFootprint(isa.Footprint),
+		StartTime:  copyTime(isa.StartTime),
+		EndTime:    copyTime(isa.EndTime),
+		AltitudeLo: copyFloat(isa.AltitudeLo),
+		AltitudeHi: copyFloat(isa.AltitudeHi),
+		Writer:     isa.Writer,
+		Version:    isa.Version,
+	}
+}
+
+// sortedISAs returns copies of isas ordered by ID, limited to limit entries.
+func sortedISAs(isas []*ridmodels.IdentificationServiceArea, limit int) []*ridmodels.IdentificationServiceArea {
+	sort.Slice(isas, func(i, j int) bool { return isas[i].ID < isas[j].ID })
+	if len(isas) > limit {
+		isas = isas[:limit]
+	}
+	result := make([]*ridmodels.IdentificationServiceArea, len(isas))
+	for i, isa := range isas {
+		result[i] = copyISA(isa)
+	}
+	return result
+}
+
+// GetISA returns the isa identified by "id".
+// Returns nil, nil if not found
+func (r *repo) GetISA(ctx context.Context, id dssmodels.ID, forUpdate bool) (*ridmodels.IdentificationServiceArea, error) {
+	var result *ridmodels.IdentificationServiceArea
+	err := r.view(func(t *tables, _ time.Time) error {
+		if isa, ok := t.isas[id]; ok {
+			result = copyISA(isa)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// InsertISA inserts the IdentificationServiceArea identified by "id" and owned
+// by "owner", affecting "cells" in the time interval ["starts", "ends"].
+func (r *repo) InsertISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) (*ridmodels.IdentificationServiceArea, error) {
+	for _, cell := range isa.Cells {
+		if err := geo.ValidateCell(cell); err != nil {
+			return nil, stacktrace.Propagate(err, "Error validating cell")
+		}
+	}
+
+	var result *ridmodels.IdentificationServiceArea
+	err := r.view(func(t *tables, now time.Time) error {
+		if _, ok := t.isas[isa.ID]; ok {
+			return stacktrace.NewError("IdentificationServiceArea %s already exists", isa.ID)
+		}
+		stored := copyISA(isa)
+		stored.Version = dssmodels.VersionFromTime(now)
+		t.isas[isa.ID] = stored
+		result = copyISA(stored)
+		return nil
+	})
+	return result, err
+}
+
+// UpdateISA updates the IdentificationServiceArea identified by "id" and owned
+// by "owner", affecting "cells" in the time interval ["starts", "ends"].
+// Returns nil, nil if ID, version not found
+func (r *repo) UpdateISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) (*ridmodels.IdentificationServiceArea, error) {
+	for _, cell := range isa.Cells {
+		if err := geo.ValidateCell(cell); err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to validate cells")
+		}
+	}
+
+	var result *ridmodels.IdentificationServiceArea
+	err := r.view(func(t *tables, now time.Time) error {
+		old, ok := t.isas[isa.ID]
+		if !ok || !old.Version.ToTimestamp().Equal(*isa.Version.ToTimestamp()) {
+			return nil
+		}
+		stored := copyISA(isa)
+		stored.Owner = old.Owner
+		stored.Version = dssmodels.VersionFromTime(now)
+		t.isas[isa.ID] = stored
+		result = copyISA(stored)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteISA deletes the IdentificationServiceArea identified by "id" and owned by "owner".
+// Returns nil, nil if ID, version not found
+func (r *repo) DeleteISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) (*ridmodels.IdentificationServiceArea, error) {
+	var result *ridmodels.IdentificationServiceArea
+	err := r.view(func(t *tables, _ time.Time) error {
+		old, ok := t.isas[isa.ID]
+		if !ok || !old.Version.ToTimestamp().Equal(*isa.Version.ToTimestamp()) {
+			return nil
+		}
+		delete(t.isas, isa.ID)
+		result = copyISA(old)
+		return nil
+	})
+	return result, err
+}
+
+// SearchISAs searches IdentificationServiceArea
+// instances that intersect with "cells" and, if set, the temporal volume
+// defined by "earliest" and "latest".
+func (r *repo) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time, limit int) ([]*ridmodels.IdentificationServiceArea, error) {
+	if len(cells) == 0 {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing cell IDs for query")
+	}
+
+	if earliest == nil {
+		return nil, stacktrace.NewError("Earliest start time is missing")
+	}
+
+	var result []*ridmodels.IdentificationServiceArea
+	err := r.view(func(t *tables, _ time.Time) error {
+		var isas []*ridmodels.IdentificationServiceArea
+		for _, isa := range t.isas {
+			if isa.EndTime == nil || isa.EndTime.Before(*earliest) {
+				continue
+			}
+			if isa.StartTime != nil && latest != nil && isa.StartTime.After(*latest) {
+				continue
+			}
+			if !overlaps(isa.Cells, cells) {
+				continue
+			}
+			isas = append(isas, isa)
+		}
+		result = sortedISAs(isas, limit)
+		return nil
+	})
+	return result, err
+}
+
+// ListExpiredISAs lists all expired ISAs based on writer.
+// Records expire if current time is <expiredDurationInMin> minutes more than records' endTime.
+// An empty writer matches records without a writer, and a nil writer matches
+// every record.
+func (r *repo) ListExpiredISAs(ctx context.Context, writer *string) ([]*ridmodels.IdentificationServiceArea, error) {
+	var result []*ridmodels.IdentificationServiceArea
+	err := r.view(func(t *tables, now time.Time) error {
+		var isas []*ridmodels.IdentificationServiceArea
+		for _, isa := range t.isas {
+			if (writer != nil && isa.Writer != *writer) || isa.EndTime == nil {
+				continue
+			}
+			if isa.EndTime.Add(expiredDurationInMin * time.Minute).After(now) {
+				continue
+			}
+			isas = append(isas, isa)
+		}
+		result = sortedISAs(isas, dssmodels.MaxResultLimit)
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/golang/geo/s2"
+	auditm "github.com/interuss/dss/pkg/audit/memory"
+	authm "github.com/interuss/dss/pkg/auth/memory"
+	leaderm "github.com/interuss/dss/pkg/leader/memory"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	notificationsm "github.com/interuss/dss/pkg/notifications/memory"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/interuss/dss/pkg/rid/repos"
+	"github.com/jonboulle/clockwork"
+)
+
+const (
+	//  Records expire if current time is <expiredDurationInMin> minutes more than records' endTime.
+	expiredDurationInMin = 30
+)
+
+var (
+	// DefaultClock is what is used as the Store's clock.
+	DefaultClock = clockwork.NewRealClock()
+
+	// schemaVersion is the version reported by GetVersion. It mirrors the
+	// latest remote ID schema so that callers checking the version treat the
+	// in-memory store like an up-to-date database.
+	schemaVersion = semver.New("4.6.0")
+)
+
+// tables holds the content of the store.
+type tables struct {
+	isas          map[dssmodels.ID]*ridmodels.IdentificationServiceArea
+	subscriptions map[dssmodels.ID]*ridmodels.Subscription
+	notifications notificationsm.Queue
+	audit         auditm.Trail
+}
+
+func newTables() *tables {
+	return &tables{
+		isas:          map[dssmodels.ID]*ridmodels.IdentificationServiceArea{},
+		subscriptions: map[dssmodels.ID]*ridmodels.Subscription{},
+	}
+}
+
+// clone returns a deep copy of t.
+func (t *tables) clone() *tables {
+	c := newTables()
+	for id, isa := range t.isas {
+		c.isas[id] = copyISA(isa)
+	}
+	for id, sub := range t.subscriptions {
+		c.subscriptions[id] = copySubscription(sub)
+	}
+	c.notifications = t.notifications.Clone()
+	c.audit = t.audit.Clone()
+	return c
+}
+
+type repo struct {
+	store *Store
+	clock clockwork.Clock
+
+	// tx holds the tables of the ongoing transaction, nil outside of a
+	// transaction.
+	tx *tables
+	// now is the timestamp of the ongoing transaction.
+	now time.Time
+}
+
+// Store is an implementation of store.Store keeping its data in memory.
+//
+// Transactions are serialized: a transaction holds the store lock for its
+// whole duration, operates on a copy of the data and only publishes its
+// changes when it succeeds. This gives the same isolation guarantees as the
+// SELECT ... FOR UPDATE statements used by the SQL-backed stores.
+type Store struct {
+	mu    sync.Mutex
+	data  *tables
+	clock clockwork.Clock
+
+	// RevocationStore holds the access token revocations, which are not
+	// part of remote ID transactions.
+	*authm.RevocationStore
+	// LeaseStore holds the leases of the leader election of periodic jobs.
+	*leaderm.LeaseStore
+}
+
+// NewStore returns an empty Store instance.
+func NewStore() *Store {
+	return &Store{
+		data:  newTables(),
+		clock: DefaultClock,
+
+		RevocationStore: authm.NewRevocationStore(),
+		LeaseStore:      leaderm.NewLeaseStore(),
+	}
+}
+
+// Interact implements store.Interactor interface.
+func (s *Store) Interact(ctx context.Context) (repos.Repository, error) {
+	return &repo{
+		store: s,
+		clock: s.clock,
+	}, nil
+}
+
+// Transact supplies a new repo, that will perform all of the accesses in
+// isolation from any other access to the store. Changes are discarded if f
+// returns an error or panics.
+func (s *Store) Transact(ctx context.Context, f func(repo repos.Repository) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := &repo{
+		store: s,
+		clock: s.clock,
+		tx:    s.data.clone(),
+		now:   timestamp(s.clock),
+	}
+	if err := f(r); err != nil {
+		return err
+	}
+	s.data = r.tx
+	return nil
+}
+
+// Close implements io.Closer. The data is dropped.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = newTables()
+	return nil
+}
+
+// GetVersion returns the schema version the store is equivalent to.
+func (s *Store) GetVersion(ctx context.Context) (*semver.Version, error) {
+	return schemaVersion, nil
+}
+
+// view runs f against the tables visible to r, locking the store when r is
+// not part of a transaction. f receives the time to use as the statement
+// timestamp.
+func (r *repo) view(f func(t *tables, now time.Time) error) error {
+	if r.tx != nil {
+		return f(r.tx, r.now)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return f(r.store.data, timestamp(r.clock))
+}
+
+// timestamp returns the current time of clock with the precision of a
+// database timestamp.
+func timestamp(clock clockwork.Clock) time.Time {
+	return clock.Now().UTC().Truncate(time.Microsecond)
+}
+
+// overlaps returns true if a and b share at least one cell, like the && array
+// operator does.
+func overlaps(a, b s2.CellUnion) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	set := make(map[s2.CellID]struct{}, len(a))
+	for _, c := range a {
+		set[c] = struct{}{}
+	}
+	for _, c := range b {
+		if _, ok := set[c]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func copyCells(cells s2.CellUnion) s2.CellUnion {
+	if cells == nil {
+		return nil
+	}
+	return append(s2.CellUnion{}, cells...)
+}
+
+// copyFootprint returns a copy of footprint as the SQL-backed stores persist
+// it, i.e. nil for footprints that have no compact form.
+func copyFootprint(footprint dssmodels.Geometry) dssmodels.Geometry {
+	data, err := dssmodels.EncodeFootprint(footprint)
+	if err != nil {
+		return nil
+	}
+	result, err := dssmodels.DecodeFootprint(data)
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+func copyFloat(f *float32) *float32 {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	return &c
+}
@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/interuss/dss/pkg/rid/repos"
+	"github.com/interuss/dss/pkg/rid/store"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// Ensure the struct conforms to the interface
+	_ store.Store = &Store{}
+
+	cells = s2.CellUnion{
+		s2.CellID(17106221850767130624),
+		s2.CellID(17106221885126868992),
+	}
+)
+
+func setUpStore() (*Store, clockwork.FakeClock) {
+	clock := clockwork.NewFakeClock()
+	s := NewStore()
+	s.clock = clock
+	return s, clock
+}
+
+func newISA(clock clockwork.Clock) *ridmodels.IdentificationServiceArea {
+	start := clock.Now().Add(-time.Minute)
+	end := clock.Now().Add(time.Hour)
+	return &ridmodels.IdentificationServiceArea{
+		ID:        dssmodels.ID(uuid.New().String()),
+		Owner:     dssmodels.Owner("me"),
+		URL:       "https://no/place/like/home",
+		Cells:     cells,
+		StartTime: &start,
+		EndTime:   &end,
+		Writer:    "writer",
+	}
+}
+
+func TestTransactRollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setUpStore()
+	isa := newISA(clock)
+
+	errAbort := errors.New("abort")
+	err := s.Transact(ctx, func(repo repos.Repository) error {
+		_, err := repo.InsertISA(ctx, isa)
+		require.NoError(t, err)
+		return errAbort
+	})
+	require.Equal(t, errAbort, err)
+
+	repo, err := s.Interact(ctx)
+	require.NoError(t, err)
+	got, err := repo.GetISA(ctx, isa.ID, false)
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	require.NoError(t, s.Transact(ctx, func(repo repos.Repository) error {
+		_, err := repo.InsertISA(ctx, isa)
+		return err
+	}))
+	got, err = repo.GetISA(ctx, isa.ID, false)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+}
+
+func TestISAVersionMismatch(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setUpStore()
+	repo, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	inserted, err := repo.InsertISA(ctx, newISA(clock))
+	require.NoError(t, err)
+	_, err = repo.InsertISA(ctx, inserted)
+	require.Error(t, err)
+
+	clock.Advance(time.Second)
+	stale := *inserted
+	stale.Version = dssmodels.VersionFromTime(clock.Now())
+	updated, err := repo.UpdateISA(ctx, &stale)
+	require.NoError(t, err)
+	require.Nil(t, updated)
+
+	updated, err = repo.UpdateISA(ctx, inserted)
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	require.False(t, updated.Version.Matches(inserted.Version))
+
+	deleted, err := repo.DeleteISA(ctx, inserted)
+	require.NoError(t, err)
+	require.Nil(t, deleted)
+	deleted, err = repo.DeleteISA(ctx, updated)
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+}
+
+func TestSearchAndExpireISAs(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setUpStore()
+	repo, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	isa, err := repo.InsertISA(ctx, newISA(clock))
+	require.NoError(t, err)
+
+	now := clock.Now()
+	found, err := repo.SearchISAs(ctx, cells[:1], &now, nil, dssmodels.MaxResultLimit)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, isa.ID, found[0].ID)
+
+	found, err = repo.SearchISAs(ctx, s2.CellUnion{s2.CellID(17106221919486607360)}, &now, nil, dssmodels.MaxResultLimit)
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	_, err = repo.SearchISAs(ctx, nil, &now, nil, dssmodels.MaxResultLimit)
+	require.Error(t, err)
+
+	writer, emptyWriter := "writer", ""
+	expired, err := repo.ListExpiredISAs(ctx, &writer)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+
+	clock.Advance(time.Hour + expiredDurationInMin*time.Minute)
+	expired, err = repo.ListExpiredISAs(ctx, &writer)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	expired, err = repo.ListExpiredISAs(ctx, &emptyWriter)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+	expired, err = repo.ListExpiredISAs(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+}
+
+func TestSubscriptionNotificationIndices(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setUpStore()
+	repo, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	end := clock.Now().Add(time.Hour)
+	sub, err := repo.InsertSubscription(ctx, &ridmodels.Subscription{
+		ID:         dssmodels.ID(uuid.New().String()),
+		Owner:      dssmodels.Owner("me"),
+		URL:        "https://no/place/like/home",
+		Cells:      cells,
+		EndTime:    &end,
+		AltitudeHi: new(float32),
+		Footprint:  &dssmodels.GeoCircle{Center: dssmodels.LatLngPoint{Lat: 37.4, Lng: -122.1}, RadiusMeter: 300},
+	})
+	require.NoError(t, err)
+	require.Equal(t, float32(0), *sub.AltitudeHi)
+	require.Nil(t, sub.AltitudeLo)
+	require.Equal(t, &dssmodels.GeoCircle{Center: dssmodels.LatLngPoint{Lat: 37.4, Lng: -122.1}, RadiusMeter: 300}, sub.Footprint)
+
+	updated, err := repo.UpdateNotificationIdxsInCells(ctx, cells[1:])
+	require.NoError(t, err)
+	require.Len(t, updated, 1)
+	require.Equal(t, 1, updated[0].NotificationIndex)
+
+	count, err := repo.MaxSubscriptionCountInCellsByOwner(ctx, cells, "me")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	clock.Advance(2 * time.Hour)
+	found, err := repo.SearchSubscriptions(ctx, cells)
+	require.NoError(t, err)
+	require.Empty(t, found)
+}
@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/golang/geo/s2"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/geo"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/interuss/stacktrace"
+)
+
+// copySubscription returns a copy of the fields of s persisted by the
+// SQL-backed stores.
+func copySubscription(s *ridmodels.Subscription) *ridmodels.Subscription {
+	return &ridmodels.Subscription{
+		ID:                s.ID,
+		Owner:             s.Owner,
+		URL:               s.URL,
+		NotificationIndex: s.NotificationIndex,
+		Cells:             copyCells(s.Cells),
+		Footprint:         copyFootprint(s.Footprint),
+		StartTime:         copyTime(s.StartTime),
+		EndTime:           copyTime(s.EndTime),
+		AltitudeLo:        copyFloat(s.AltitudeLo),
+		AltitudeHi:        copyFloat(s.AltitudeHi),
+		Writer:            s.Writer,
+		Version:           s.Version,
+	}
+}
+
+// sortedSubscriptions returns copies of subs ordered by ID, limited to limit
+// entries if limit is positive.
+func sortedSubscriptions(subs []*ridmodels.Subscription, limit int) []*ridmodels.Subscription {
+	sort.Slice(subs, func(i, j int) bool { return subs[i].ID < subs[j].ID })
+	if limit > 0 && len(subs) > limit {
+		subs = subs[:limit]
+	}
+	result := make([]*ridmodels.Subscription, len(subs))
+	for i, s := range subs {
+		result[i] = copySubscription(s)
+	}
+	return result
+}
+
+// isActive returns true if s has not ended at now.
+func isActive(s *ridmodels.Subscription, now time.Time) bool {
+	return s.EndTime != nil && !s.EndTime.Before(now)
+}
+
+func validateCells(cells s2.CellUnion) error {
+	for _, cell := range cells {
+		if err := geo.ValidateCell(cell); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaxSubscriptionCountInCellsByOwner counts how many subscriptions the
+// owner has in each one of these cells, and returns the number of subscriptions
+// in the cell with the highest number of subscriptions.
+func (r *repo) MaxSubscriptionCountInCellsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) (int, error) {
+	wanted := make(map[s2.CellID]struct{}, len(cells))
+	for _, cell := range cells {
+		wanted[cell] = struct{}{}
+	}
+
+	max := 0
+	err := r.view(func(t *tables, now time.Time) error {
+		counts := map[s2.CellID]int{}
+		for _, s := range t.subscriptions {
+			if s.Owner != owner || !isActive(s, now) {
+				continue
+			}
+			for _, cell := range s.Cells {
+				if _, ok := wanted[cell]; !ok {
+					continue
+				}
+				counts[cell]++
+				if counts[cell] > max {
+					max = counts[cell]
+				}
+			}
+		}
+		return nil
+	})
+	return max, err
+}
+
+// GetSubscription returns the subscription identified by "id".
+// Returns nil, nil if not found
+func (r *repo) GetSubscription(ctx context.Context, id dssmodels.ID) (*ridmodels.Subscription, error) {
+	var result *ridmodels.Subscription
+	err := r.view(func(t *tables, _ time.Time) error {
+		if s, ok := t.subscriptions[id]; ok {
+			result = copySubscription(s)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// UpdateSubscription updates the Subscription.
+// Returns nil, nil if ID, version not found
+func (r *repo) UpdateSubscription(ctx context.Context, s *ridmodels.Subscription) (*ridmodels.Subscription, error) {
+	if err := validateCells(s.Cells); err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to validate cells")
+	}
+
+	var result *ridmodels.Subscription
+	err := r.view(func(t *tables, now time.Time) error {
+		old, ok := t.subscriptions[s.ID]
+		if !ok || !old.Version.ToTimestamp().Equal(*s.Version.ToTimestamp()) {
+			return nil
+		}
+		stored := copySubscription(s)
+		stored.Owner = old.Owner
+		stored.Version = dssmodels.VersionFromTime(now)
+		t.subscriptions[s.ID] = stored
+		result = copySubscription(stored)
+		return nil
+	})
+	return result, err
+}
+
+// InsertSubscription inserts subscription into the store and returns
+// the resulting subscription including its ID.
+func (r *repo) InsertSubscription(ctx context.Context, s *ridmodels.Subscription) (*ridmodels.Subscription, error) {
+	if err := validateCells(s.Cells); err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to validate cells")
+	}
+
+	var result *ridmodels.Subscription
+	err := r.view(func(t *tables, now time.Time) error {
+		if _, ok := t.subscriptions[s.ID]; ok {
+			return stacktrace.NewError("Subscription %s already exists", s.ID)
+		}
+		stored := copySubscription(s)
+		stored.Version = dssmodels.VersionFromTime(now)
+		t.subscriptions[s.ID] = stored
+		result = copySubscription(stored)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteSubscription deletes the subscription identified by ID.
+// Returns nil, nil if ID, version not found
+func (r *repo) DeleteSubscription(ctx context.Context, s *ridmodels.Subscription) (*ridmodels.Subscription, error) {
+	var result *ridmodels.Subscription
+	err := r.view(func(t *tables, _ time.Time) error {
+		old, ok := t.subscriptions[s.ID]
+		if !ok || !old.Version.ToTimestamp().Equal(*s.Version.ToTimestamp()) {
+			return nil
+		}
+		delete(t.subscriptions, s.ID)
+		result = copySubscription(old)
+		return nil
+	})
+	return result, err
+}
+
+// UpdateNotificationIdxsInCells incremement the notification for each sub in the given cells.
+func (r *repo) UpdateNotificationIdxsInCells(ctx context.Context, cells s2.CellUnion) ([]*ridmodels.Subscription, error) {
+	var result []*ridmodels.Subscription
+	err := r.view(func(t *tables, now time.Time) error {
+		var subs []*ridmodels.Subscription
+		for _, s := range t.subscriptions {
+			if !isActive(s, now) || !overlaps(s.Cells, cells) {
+				continue
+			}
+			s.NotificationIndex++
+			subs = append(subs, s)
+		}
+		result = sortedSubscriptions(subs, 0)
+		return nil
+	})
+	return result, err
+}
+
+// SearchSubscriptions returns all subscriptions in "cells".
+func (r *repo) SearchSubscriptions(ctx context.Context, cells s2.CellUnion) ([]*ridmodels.Subscription, error) {
+	if len(cells) == 0 {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "no location provided")
+	}
+	return r.searchSubscriptions(cells, func(*ridmodels.Subscription) bool { return true }, dssmodels.MaxResultLimit)
+}
+
+// SearchSubscriptionsByOwner returns up to "limit" subscriptions of "owner" in
+// "cells".
+func (r *repo) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner, limit int) ([]*ridmodels.Subscription, error) {
+	if len(cells) == 0 {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "no location provided")
+	}
+	return r.searchSubscriptions(cells, func(s *ridmodels.Subscription) bool { return s.Owner == owner }, limit)
+}
+
+func (r *repo) searchSubscriptions(cells s2.CellUnion, keep func(*ridmodels.Subscription) bool, limit int) ([]*ridmodels.Subscription, error) {
+	var result []*ridmodels.Subscription
+	err := r.view(func(t *tables, now time.Time) error {
+		var subs []*ridmodels.Subscription
+		for _, s := range t.subscriptions {
+			if isActive(s, now) && overlaps(s.Cells, cells) && keep(s) {
+				subs = append(subs, s)
+			}
+		}
+		result = sortedSubscriptions(subs, limit)
+		return nil
+	})
+	return result, err
+}
+
+// ListExpiredSubscriptions lists all expired Subscriptions based on writer.
+// Records expire if current time is <expiredDurationInMin> minutes more than records' endTime.
+// An empty writer matches records without a writer, and a nil writer matches
+// every record.
+func (r *repo) ListExpiredSubscriptions(ctx context.Context, writer *string) ([]*ridmodels.Subscription, error) {
+	var result []*ridmodels.Subscription
+	err := r.view(func(t *tables, now time.Time) error {
+		var subs []*ridmodels.Subscription
+		for _, s := range t.subscriptions {
+			if (writer != nil && s.Writer != *writer) || s.EndTime == nil {
+				continue
+			}
+			if s.EndTime.Add(expiredDurationInMin * time.Minute).After(now) {
+				continue
+			}
+			subs = append(subs, s)
+		}
+		result = sortedSubscriptions(subs, 0)
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,22 @@
+package scd
+
+import (
+	"context"
+
+	"github.com/golang/geo/s2"
+	"github.com/interuss/dss/pkg/audit"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/stacktrace"
+)
+
+// auditChange appends to r the audit record of the change made by manager to
+// the entity of type entity identified by id, from oldVersion to newVersion.
+// oldVersion is empty for a creation and newVersion for a deletion.
+func auditChange(ctx context.Context, r repos.Repository, entity audit.EntityType, id dssmodels.ID, manager dssmodels.Manager, oldVersion, newVersion string, cells s2.CellUnion) error {
+	rec := audit.NewRecord(entity, id, manager.String(), oldVersion, newVersion, cells)
+	if err := r.AppendAuditRecord(ctx, rec); err != nil {
+		return stacktrace.Propagate(err, "Error recording %s of %s %s", rec.Operation, entity, id)
+	}
+	return nil
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/golang/geo/s2"
 	"github.com/interuss/dss/pkg/api"
 	restapi "github.com/interuss/dss/pkg/api/scdv1"
+	"github.com/interuss/dss/pkg/audit"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/stacktrace"
 	"github.com/jackc/pgx/v5"
 )
@@ -18,6 +20,9 @@ import (
 // the specified version.
 func (a *Server) DeleteConstraintReference(ctx context.Context, req *restapi.DeleteConstraintReferenceRequest,
 ) restapi.DeleteConstraintReferenceResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.DeleteConstraintReference")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.DeleteConstraintReferenceResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -71,10 +76,13 @@ func (a *Server) DeleteConstraintReference(ctx context.Context, req *restapi.Del
 				Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
 					return old.Cells, nil
 				}),
-			}})
+			}}, dssmodels.MaxResultLimit+1)
 		if err != nil {
 			return stacktrace.Propagate(err, "Unable to search Subscriptions in repo")
 		}
+		if err := dssmodels.CheckCompleteResults(allsubs, "Subscriptions"); err != nil {
+			return stacktrace.Propagate(err, "Unable to find all Subscriptions to notify")
+		}
 
 		// Limit Subscription notifications to only those interested in Constraints
 		subs := repos.Subscriptions{}
@@ -89,6 +97,9 @@ func (a *Server) DeleteConstraintReference(ctx context.Context, req *restapi.Del
 		if err != nil {
 			return stacktrace.Propagate(err, "Unable to delete Constraint from repo")
 		}
+		if err := auditChange(ctx, r, audit.EntityConstraint, old.ID, old.Manager, old.OVN.String(), "", old.Cells); err != nil {
+			return err
+		}
 
 		// Increment notification indices for relevant Subscriptions
 		err = subs.IncrementNotificationIndices(ctx, r)
@@ -102,7 +113,7 @@ func (a *Server) DeleteConstraintReference(ctx context.Context, req *restapi.Del
 			Subscribers:         makeSubscribersToNotify(subs),
 		}
 
-		return nil
+		return a.enqueueConstraintNotifications(ctx, r, req.Entityid, old.Manager, nil, subs)
 	}
 
 	err = a.Store.Transact(ctx, action)
@@ -118,6 +129,8 @@ func (a *Server) DeleteConstraintReference(ctx context.Context, req *restapi.Del
 			return restapi.DeleteConstraintReferenceResponseSet{Response404: errResp}
 		case dsserr.VersionMismatch:
 			return restapi.DeleteConstraintReferenceResponseSet{Response409: errResp}
+		case dsserr.Exhausted:
+			return restapi.DeleteConstraintReferenceResponseSet{Response429: errResp}
 		default:
 			return restapi.DeleteConstraintReferenceResponseSet{Response500: &api.InternalServerErrorBody{
 				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
@@ -130,6 +143,9 @@ func (a *Server) DeleteConstraintReference(ctx context.Context, req *restapi.Del
 // GetConstraintReference returns a single constraint ref for the given ID.
 func (a *Server) GetConstraintReference(ctx context.Context, req *restapi.GetConstraintReferenceRequest,
 ) restapi.GetConstraintReferenceResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.GetConstraintReference")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.GetConstraintReferenceResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -164,6 +180,7 @@ func (a *Server) GetConstraintReference(ctx context.Context, req *restapi.GetCon
 		// Return response to client
 		response = &restapi.GetConstraintReferenceResponse{
 			ConstraintReference: *constraint.ToRest(),
+			Extents:             scdmodels.VolumesToRest(constraint.Volumes),
 		}
 
 		return nil
@@ -184,6 +201,9 @@ func (a *Server) GetConstraintReference(ctx context.Context, req *restapi.GetCon
 
 func (a *Server) CreateConstraintReference(ctx context.Context, req *restapi.CreateConstraintReferenceRequest,
 ) restapi.CreateConstraintReferenceResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.CreateConstraintReference")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.CreateConstraintReferenceResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -210,6 +230,8 @@ func (a *Server) CreateConstraintReference(ctx context.Context, req *restapi.Cre
 			return restapi.CreateConstraintReferenceResponseSet{Response409: errResp}
 		case dsserr.BadRequest:
 			return restapi.CreateConstraintReferenceResponseSet{Response400: errResp}
+		case dsserr.Exhausted:
+			return restapi.CreateConstraintReferenceResponseSet{Response429: errResp}
 		default:
 			return restapi.CreateConstraintReferenceResponseSet{Response500: &api.InternalServerErrorBody{
 				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
@@ -221,6 +243,9 @@ func (a *Server) CreateConstraintReference(ctx context.Context, req *restapi.Cre
 
 func (a *Server) UpdateConstraintReference(ctx context.Context, req *restapi.UpdateConstraintReferenceRequest,
 ) restapi.UpdateConstraintReferenceResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.UpdateConstraintReference")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.UpdateConstraintReferenceResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -247,6 +272,8 @@ func (a *Server) UpdateConstraintReference(ctx context.Context, req *restapi.Upd
 			return restapi.UpdateConstraintReferenceResponseSet{Response409: errResp}
 		case dsserr.BadRequest:
 			return restapi.UpdateConstraintReferenceResponseSet{Response400: errResp}
+		case dsserr.Exhausted:
+			return restapi.UpdateConstraintReferenceResponseSet{Response429: errResp}
 		default:
 			return restapi.UpdateConstraintReferenceResponseSet{Response500: &api.InternalServerErrorBody{
 				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
@@ -260,6 +287,9 @@ func (a *Server) UpdateConstraintReference(ctx context.Context, req *restapi.Upd
 // If the ovn argument is empty (""), it will attempt to create a new Constraint.
 func (a *Server) PutConstraintReference(ctx context.Context, manager string, entityid restapi.EntityID, ovn restapi.EntityOVN, params *restapi.PutConstraintReferenceParameters,
 ) (*restapi.ChangeConstraintReferenceResponse, error) {
+	ctx, span := tracing.Start(ctx, "scd.PutConstraintReference")
+	defer span.End()
+
 	id, err := dssmodels.IDFromString(string(entityid))
 
 	if err != nil {
@@ -303,6 +333,10 @@ func (a *Server) PutConstraintReference(ctx context.Context, manager string, ent
 	if err != nil {
 		return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid area")
 	}
+	volumes, err := scdmodels.VolumesFromVolume4Ds(extents)
+	if err != nil {
+		return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid area")
+	}
 
 	var response *restapi.ChangeConstraintReferenceResponse
 	action := func(ctx context.Context, r repos.Repository) (err error) {
@@ -366,16 +400,27 @@ func (a *Server) PutConstraintReference(ctx context.Context, manager string, ent
 
 			USSBaseURL: string(params.UssBaseUrl),
 			Cells:      cells,
+			Volumes:    volumes,
 		})
 		if err != nil {
 			return err
 		}
+		var oldOVN string
+		if old != nil {
+			oldOVN = old.OVN.String()
+		}
+		if err := auditChange(ctx, r, audit.EntityConstraint, constraint.ID, dssmodels.Manager(manager), oldOVN, constraint.OVN.String(), constraint.Cells); err != nil {
+			return err
+		}
 
 		// Find Subscriptions that may need to be notified
-		allsubs, err := r.SearchSubscriptions(ctx, notifyVol4)
+		allsubs, err := r.SearchSubscriptions(ctx, notifyVol4, dssmodels.MaxResultLimit+1)
 		if err != nil {
 			return err
 		}
+		if err := dssmodels.CheckCompleteResults(allsubs, "Subscriptions"); err != nil {
+			return stacktrace.Propagate(err, "Unable to find all Subscriptions to notify")
+		}
 
 		// Limit Subscription notifications to only those interested in Constraints
 		subs := repos.Subscriptions{}
@@ -397,7 +442,11 @@ func (a *Server) PutConstraintReference(ctx context.Context, manager string, ent
 			Subscribers:         makeSubscribersToNotify(subs),
 		}
 
-		return nil
+		notified := &notifiedConstraint{
+			Reference: response.ConstraintReference,
+			Details:   constraintDetails{Volumes: params.Extents},
+		}
+		return a.enqueueConstraintNotifications(ctx, r, entityid, dssmodels.Manager(manager), notified, subs)
 	}
 
 	err = a.Store.Transact(ctx, action)
@@ -412,6 +461,9 @@ func (a *Server) PutConstraintReference(ctx context.Context, manager string, ent
 // bounds.
 func (a *Server) QueryConstraintReferences(ctx context.Context, req *restapi.QueryConstraintReferencesRequest,
 ) restapi.QueryConstraintReferencesResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.QueryConstraintReferences")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.QueryConstraintReferencesResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -440,10 +492,17 @@ func (a *Server) QueryConstraintReferences(ctx context.Context, req *restapi.Que
 	var response *restapi.QueryConstraintReferencesResponse
 	action := func(ctx context.Context, r repos.Repository) (err error) {
 		// Perform search query on Store
-		constraints, err := r.SearchConstraints(ctx, vol4)
+		constraints, err := r.SearchConstraints(ctx, vol4, a.resultLimit()+1)
 		if err != nil {
 			return err
 		}
+		constraints = api.TruncateResults(ctx, constraints, a.resultLimit())
+		if a.ExactGeometry {
+			constraints, err = refineEntities(constraints, vol4)
+			if err != nil {
+				return stacktrace.Propagate(err, "Unable to refine Constraints found")
+			}
+		}
 
 		// Create response for client
 		response = &restapi.QueryConstraintReferencesResponse{
@@ -8,6 +8,11 @@ import (
 	restapi "github.com/interuss/dss/pkg/api/scdv1"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	"github.com/interuss/dss/pkg/logging"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	scdstore "github.com/interuss/dss/pkg/scd/store"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/stacktrace"
 	"go.uber.org/zap"
 )
@@ -43,9 +48,51 @@ func (h *JSONLoggingReceivedReportHandler) Handle(ctx context.Context, req *rest
 	return rVal, nil
 }
 
+// StoredReceivedReportHandler is a ReceivedReportHandler that persists the
+// received reports in a Store, from which they can be read back through the
+// auxiliary API or db-manager.
+type StoredReceivedReportHandler struct {
+	Store scdstore.Store
+}
+
+// Handle stores the received report, attributed to the requesting manager.
+func (h *StoredReceivedReportHandler) Handle(ctx context.Context, req *restapi.MakeDssReportRequest) (*restapi.ErrorReport, error) {
+	if req.Auth.ClientID == nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Missing manager")
+	}
+	reportID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to generate report ID")
+	}
+	rVal := req.Body
+	reportIDStr := reportID.String()
+	rVal.ReportId = &reportIDStr
+	jsonReport, err := json.Marshal(rVal)
+	if err != nil {
+		return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Failed to serialize DSS Report")
+	}
+
+	err = h.Store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+		_, err := r.InsertDSSReport(ctx, &scdmodels.DSSReport{
+			ID:       dssmodels.ID(reportIDStr),
+			Reporter: dssmodels.Manager(*req.Auth.ClientID),
+			Report:   jsonReport,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to store DSS Report")
+	}
+	logging.WithValuesFromContext(ctx, logging.Logger).Info("Stored DSS Report", zap.String("reportID", reportIDStr), zap.String("reporter", *req.Auth.ClientID))
+	return rVal, nil
+}
+
 // MakeDssReport creates an error report about a DSS.
 func (a *Server) MakeDssReport(ctx context.Context, req *restapi.MakeDssReportRequest,
 ) restapi.MakeDssReportResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.MakeDssReport")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.MakeDssReportResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -0,0 +1,24 @@
+package scd
+
+import (
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+)
+
+// refineEntities returns the entities among entities that still intersect vol4
+// when their submitted outlines, rather than their cells, are compared with the
+// footprint of vol4.
+func refineEntities[E scdmodels.VolumeEntity](entities []E, vol4 *dssmodels.Volume4D) ([]E, error) {
+	cells, err := vol4.CalculateSpatialCovering()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to calculate spatial covering")
+	}
+	result := make([]E, 0, len(entities))
+	for _, entity := range entities {
+		if scdmodels.IntersectsAnyExactly(entity.EntityVolumes(), vol4, cells) {
+			result = append(result, entity)
+		}
+	}
+	return result, nil
+}
@@ -23,6 +23,19 @@ type Constraint struct {
 	AltitudeLower   *float32
 	AltitudeUpper   *float32
 	Cells           s2.CellUnion
+	// Volumes are the individual volumes of the extents which StartTime,
+	// EndTime, AltitudeLower, AltitudeUpper and Cells are the union of.
+	Volumes []Volume
+}
+
+// EntityID implements VolumeEntity.
+func (c *Constraint) EntityID() dssmodels.ID {
+	return c.ID
+}
+
+// EntityVolumes implements VolumeEntity.
+func (c *Constraint) EntityVolumes() []Volume {
+	return c.Volumes
 }
 
 // ToRest converts the Constraint to its SCD v1 REST model API format
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+)
+
+// DSSReport models a report about the DSS submitted by a USS.
+type DSSReport struct {
+	ID dssmodels.ID
+	// Reporter is the manager of the USS which submitted the report.
+	Reporter dssmodels.Manager
+	// Report is the JSON report as submitted, including its ID.
+	Report     json.RawMessage
+	ReceivedAt time.Time
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/geo/s2"
 	"github.com/google/uuid"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	"github.com/stretchr/testify/require"
@@ -107,3 +108,51 @@ func TestNewOVNFromUUIDv7Suffix(t *testing.T) {
 		}
 	})
 }
+
+func TestVolumeIntersects(t *testing.T) {
+	var (
+		start         = time.Date(2024, time.August, 14, 15, 0, 0, 0, time.UTC)
+		end           = start.Add(time.Hour)
+		low, high     = float32(50), float32(100)
+		cell          = s2.CellID(int64(8768904281496485888))
+		otherCell     = s2.CellID(int64(8768904178417270784))
+		afterEnd      = end.Add(time.Minute)
+		aboveHigh     = high + 1
+		volume        = Volume{StartTime: &start, EndTime: &end, AltitudeLower: &low, AltitudeUpper: &high, Cells: s2.CellUnion{cell}}
+		matchingCells = s2.CellUnion{otherCell, cell}
+	)
+
+	require.True(t, volume.Intersects(&dssmodels.Volume4D{}, matchingCells))
+	require.False(t, volume.Intersects(&dssmodels.Volume4D{}, s2.CellUnion{otherCell}))
+	require.False(t, volume.Intersects(&dssmodels.Volume4D{StartTime: &afterEnd}, matchingCells))
+	require.False(t, volume.Intersects(&dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{AltitudeLo: &aboveHigh}}, matchingCells))
+	require.True(t, volume.Intersects(&dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{AltitudeLo: &high}}, matchingCells))
+
+	require.True(t, IntersectsAny(nil, &dssmodels.Volume4D{}, s2.CellUnion{otherCell}))
+	require.False(t, IntersectsAny([]Volume{volume}, &dssmodels.Volume4D{}, s2.CellUnion{otherCell}))
+}
+
+func TestVolumeIntersectsExactly(t *testing.T) {
+	var (
+		cell     = s2.CellID(int64(8768904281496485888))
+		triangle = func(lng float64) *dssmodels.GeoPolygon {
+			return &dssmodels.GeoPolygon{Vertices: []*dssmodels.LatLngPoint{
+				{Lat: 37.427, Lng: lng}, {Lat: 37.4271, Lng: lng}, {Lat: 37.427, Lng: lng + 0.0001},
+			}}
+		}
+		volume    = Volume{Cells: s2.CellUnion{cell}, Footprint: triangle(-122.17)}
+		unknown   = Volume{Cells: s2.CellUnion{cell}}
+		overlap   = &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: triangle(-122.16995)}}
+		disjoint  = &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: triangle(-122.1695)}}
+		cellsOnly = s2.CellUnion{cell}
+	)
+
+	require.True(t, volume.IntersectsExactly(overlap, cellsOnly))
+	require.False(t, volume.IntersectsExactly(disjoint, cellsOnly))
+	require.True(t, volume.Intersects(disjoint, cellsOnly))
+	require.True(t, unknown.IntersectsExactly(disjoint, cellsOnly))
+	require.True(t, volume.IntersectsExactly(&dssmodels.Volume4D{}, cellsOnly))
+
+	require.False(t, IntersectsAnyExactly([]Volume{volume}, disjoint, cellsOnly))
+	require.True(t, IntersectsAnyExactly([]Volume{volume, unknown}, disjoint, cellsOnly))
+}
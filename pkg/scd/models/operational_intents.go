@@ -82,6 +82,19 @@ type OperationalIntent struct {
 	AltitudeLower   *float32
 	AltitudeUpper   *float32
 	Cells           s2.CellUnion
+	// Volumes are the individual volumes of the extents which StartTime,
+	// EndTime, AltitudeLower, AltitudeUpper and Cells are the union of.
+	Volumes []Volume
+}
+
+// EntityID implements VolumeEntity.
+func (o *OperationalIntent) EntityID() dssmodels.ID {
+	return o.ID
+}
+
+// EntityVolumes implements VolumeEntity.
+func (o *OperationalIntent) EntityVolumes() []Volume {
+	return o.Volumes
 }
 
 func (s OperationalIntentState) String() string {
@@ -36,6 +36,8 @@ type Subscription struct {
 	NotifyForConstraints        bool
 	ImplicitSubscription        bool
 	Cells                       s2.CellUnion
+	// Footprint is the outline submitted for the Subscription, nil if unknown.
+	Footprint dssmodels.Geometry
 }
 
 // ToRest converts the Subscription to its SCD v1 REST model API format
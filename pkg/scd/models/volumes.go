@@ -0,0 +1,164 @@
+package models
+
+import (
+	"time"
+
+	"github.com/golang/geo/s2"
+	restapi "github.com/interuss/dss/pkg/api/scdv1"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/stacktrace"
+)
+
+// Volume is one of the 4D volumes making up the extents of an
+// OperationalIntent or a Constraint, as stored by the DSS.
+type Volume struct {
+	StartTime     *time.Time
+	EndTime       *time.Time
+	AltitudeLower *float32
+	AltitudeUpper *float32
+	Cells         s2.CellUnion
+	// Footprint is the outline submitted for this volume, nil if unknown.
+	Footprint dssmodels.Geometry
+}
+
+// VolumeEntity is an entity, OperationalIntent or Constraint, located by
+// individual Volumes.
+type VolumeEntity interface {
+	// EntityID returns the ID of the entity.
+	EntityID() dssmodels.ID
+	// EntityVolumes returns the Volumes of the extents of the entity.
+	EntityVolumes() []Volume
+}
+
+// VolumesFromVolume4Ds returns the Volumes covering each of extents.
+func VolumesFromVolume4Ds(extents []*dssmodels.Volume4D) ([]Volume, error) {
+	result := make([]Volume, len(extents))
+	for i, extent := range extents {
+		cells, err := extent.CalculateSpatialCovering()
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to calculate spatial covering of extent %d", i)
+		}
+		result[i] = Volume{
+			StartTime: extent.StartTime,
+			EndTime:   extent.EndTime,
+			Cells:     cells,
+		}
+		if extent.SpatialVolume != nil {
+			result[i].AltitudeLower = extent.SpatialVolume.AltitudeLo
+			result[i].AltitudeUpper = extent.SpatialVolume.AltitudeHi
+			result[i].Footprint = extent.SpatialVolume.Footprint
+		}
+	}
+	return result, nil
+}
+
+// Intersects returns true if v intersects v4d, which spatial covering is
+// cells. A missing bound of either volume is considered unbounded.
+func (v *Volume) Intersects(v4d *dssmodels.Volume4D, cells s2.CellUnion) bool {
+	if v4d.StartTime != nil && v.EndTime != nil && v.EndTime.Before(*v4d.StartTime) {
+		return false
+	}
+	if v4d.EndTime != nil && v.StartTime != nil && v.StartTime.After(*v4d.EndTime) {
+		return false
+	}
+	if v4d.SpatialVolume != nil {
+		if v4d.SpatialVolume.AltitudeLo != nil && v.AltitudeUpper != nil && *v.AltitudeUpper < *v4d.SpatialVolume.AltitudeLo {
+			return false
+		}
+		if v4d.SpatialVolume.AltitudeHi != nil && v.AltitudeLower != nil && *v.AltitudeLower > *v4d.SpatialVolume.AltitudeHi {
+			return false
+		}
+	}
+	return sharesCell(v.Cells, cells)
+}
+
+// sharesCell returns true if a and b share at least one cell ID, like the &&
+// array operator does.
+func sharesCell(a, b s2.CellUnion) bool {
+	set := make(map[s2.CellID]struct{}, len(a))
+	for _, c := range a {
+		set[c] = struct{}{}
+	}
+	for _, c := range b {
+		if _, ok := set[c]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectsExactly returns true if v intersects v4d as per Intersects and,
+// when both footprints are known, their outlines share at least one point.
+func (v *Volume) IntersectsExactly(v4d *dssmodels.Volume4D, cells s2.CellUnion) bool {
+	if !v.Intersects(v4d, cells) {
+		return false
+	}
+	if v4d.SpatialVolume == nil {
+		return true
+	}
+	return dssmodels.FootprintsIntersect(v.Footprint, v4d.SpatialVolume.Footprint)
+}
+
+// ToVolume4D returns the Volume4D v was created from, or the Volume4D
+// covering v if its footprint is unknown.
+func (v *Volume) ToVolume4D() *dssmodels.Volume4D {
+	footprint := v.Footprint
+	if footprint == nil {
+		cells := v.Cells
+		footprint = dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
+			return cells, nil
+		})
+	}
+	return &dssmodels.Volume4D{
+		StartTime: v.StartTime,
+		EndTime:   v.EndTime,
+		SpatialVolume: &dssmodels.Volume3D{
+			AltitudeLo: v.AltitudeLower,
+			AltitudeHi: v.AltitudeUpper,
+			Footprint:  footprint,
+		},
+	}
+}
+
+// IntersectsAny returns true if volumes is empty, since the entity they belong
+// to predates the storage of individual volumes and is only known by the union
+// of its volumes, or if any of volumes intersects v4d.
+func IntersectsAny(volumes []Volume, v4d *dssmodels.Volume4D, cells s2.CellUnion) bool {
+	if len(volumes) == 0 {
+		return true
+	}
+	for i := range volumes {
+		if volumes[i].Intersects(v4d, cells) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectsAnyExactly is like IntersectsAny, but compares the outlines of
+// the volumes with the footprint of v4d when both are known.
+func IntersectsAnyExactly(volumes []Volume, v4d *dssmodels.Volume4D, cells s2.CellUnion) bool {
+	if len(volumes) == 0 {
+		return true
+	}
+	for i := range volumes {
+		if volumes[i].IntersectsExactly(v4d, cells) {
+			return true
+		}
+	}
+	return false
+}
+
+// VolumesToRest converts the volumes submitted for an OperationalIntent or a
+// Constraint to their SCD v1 REST model, or returns nil if there are none. The
+// outline of the volumes which footprint is unknown is omitted.
+func VolumesToRest(volumes []Volume) *[]restapi.Volume4D {
+	if len(volumes) == 0 {
+		return nil
+	}
+	result := make([]restapi.Volume4D, 0, len(volumes))
+	for i := range volumes {
+		result = append(result, *volumes[i].ToVolume4D().ToSCDRest())
+	}
+	return &result
+}
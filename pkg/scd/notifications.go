@@ -0,0 +1,140 @@
+package scd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	restapi "github.com/interuss/dss/pkg/api/scdv1"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/stacktrace"
+)
+
+// The types below mirror the bodies of the notifications defined by the ASTM
+// F3548-21 USS-USS API, which are not part of the DSS API.
+
+type operationalIntentNotification struct {
+	OperationalIntentID restapi.EntityID            `json:"operational_intent_id"`
+	OperationalIntent   *notifiedOperationalIntent  `json:"operational_intent,omitempty"`
+	Subscriptions       []restapi.SubscriptionState `json:"subscriptions"`
+}
+
+type notifiedOperationalIntent struct {
+	Reference restapi.OperationalIntentReference `json:"reference"`
+	Details   operationalIntentDetails           `json:"details"`
+}
+
+// operationalIntentDetails only holds the information known to the DSS: the
+// priority is the one submitted with the operational intent reference, if any.
+type operationalIntentDetails struct {
+	Volumes           []restapi.Volume4D `json:"volumes"`
+	OffNominalVolumes []restapi.Volume4D `json:"off_nominal_volumes"`
+	Priority          int32              `json:"priority"`
+}
+
+type constraintNotification struct {
+	ConstraintID  restapi.EntityID            `json:"constraint_id"`
+	Constraint    *notifiedConstraint         `json:"constraint,omitempty"`
+	Subscriptions []restapi.SubscriptionState `json:"subscriptions"`
+}
+
+type notifiedConstraint struct {
+	Reference restapi.ConstraintReference `json:"reference"`
+	Details   constraintDetails           `json:"details"`
+}
+
+type constraintDetails struct {
+	Volumes []restapi.Volume4D `json:"volumes"`
+}
+
+// newNotifiedOperationalIntent returns the operational intent notified to subscribers
+// after reference has been upserted with extents and priority, which may be nil.
+func newNotifiedOperationalIntent(reference restapi.OperationalIntentReference, state scdmodels.OperationalIntentState, extents []restapi.Volume4D, priority *int32) *notifiedOperationalIntent {
+	details := operationalIntentDetails{
+		Volumes:           []restapi.Volume4D{},
+		OffNominalVolumes: []restapi.Volume4D{},
+	}
+	if priority != nil {
+		details.Priority = *priority
+	}
+	switch state {
+	case scdmodels.OperationalIntentStateNonconforming, scdmodels.OperationalIntentStateContingent:
+		details.OffNominalVolumes = extents
+	default:
+		details.Volumes = extents
+	}
+	return &notifiedOperationalIntent{Reference: reference, Details: details}
+}
+
+// enqueueOperationalIntentNotifications enqueues in r the notifications of a
+// change to the operational intent identified by id, made by manager, for the
+// other managers' Subscriptions in subs. intent is nil if the operational
+// intent was deleted. Nothing is enqueued unless a notifies subscribers.
+func (a *Server) enqueueOperationalIntentNotifications(ctx context.Context, r repos.Repository, id restapi.EntityID, manager dssmodels.Manager, intent *notifiedOperationalIntent, subs repos.Subscriptions) error {
+	if !a.NotifySubscribers {
+		return nil
+	}
+
+	var ns []*notifications.Notification
+	for _, subscriber := range makeSubscribersToNotify(othersSubscriptions(subs, manager)) {
+		url := fmt.Sprintf("%s/uss/v1/operational_intents", strings.TrimSuffix(string(subscriber.UssBaseUrl), "/"))
+		n, err := notifications.New(url, string(restapi.UtmStrategicCoordinationScope), operationalIntentNotification{
+			OperationalIntentID: id,
+			OperationalIntent:   intent,
+			Subscriptions:       subscriber.Subscriptions,
+		})
+		if err != nil {
+			return stacktrace.Propagate(err, "Error building notification to %s", url)
+		}
+		ns = append(ns, n)
+	}
+
+	if err := r.EnqueueNotifications(ctx, ns); err != nil {
+		return stacktrace.Propagate(err, "Error enqueuing operational intent notifications")
+	}
+	return nil
+}
+
+// enqueueConstraintNotifications enqueues in r the notifications of a change to
+// the constraint identified by id, made by manager, for the other managers'
+// Subscriptions in subs. c is nil if the constraint was deleted. Nothing is
+// enqueued unless a notifies subscribers.
+func (a *Server) enqueueConstraintNotifications(ctx context.Context, r repos.Repository, id restapi.EntityID, manager dssmodels.Manager, c *notifiedConstraint, subs repos.Subscriptions) error {
+	if !a.NotifySubscribers {
+		return nil
+	}
+
+	var ns []*notifications.Notification
+	for _, subscriber := range makeSubscribersToNotify(othersSubscriptions(subs, manager)) {
+		url := fmt.Sprintf("%s/uss/v1/constraints", strings.TrimSuffix(string(subscriber.UssBaseUrl), "/"))
+		n, err := notifications.New(url, string(restapi.UtmConstraintProcessingScope), constraintNotification{
+			ConstraintID:  id,
+			Constraint:    c,
+			Subscriptions: subscriber.Subscriptions,
+		})
+		if err != nil {
+			return stacktrace.Propagate(err, "Error building notification to %s", url)
+		}
+		ns = append(ns, n)
+	}
+
+	if err := r.EnqueueNotifications(ctx, ns); err != nil {
+		return stacktrace.Propagate(err, "Error enqueuing constraint notifications")
+	}
+	return nil
+}
+
+// othersSubscriptions returns the Subscriptions of subs not managed by manager,
+// who is already aware of its own changes.
+func othersSubscriptions(subs repos.Subscriptions, manager dssmodels.Manager) []*scdmodels.Subscription {
+	var result []*scdmodels.Subscription
+	for _, sub := range subs {
+		if sub.Manager != manager {
+			result = append(result, sub)
+		}
+	}
+	return result
+}
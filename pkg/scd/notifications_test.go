@@ -0,0 +1,36 @@
+package scd
+
+import (
+	"encoding/json"
+	"testing"
+
+	restapi "github.com/interuss/dss/pkg/api/scdv1"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifiedOperationalIntentDetails(t *testing.T) {
+	var (
+		reference = restapi.OperationalIntentReference{Id: "00000000-0000-4000-8000-000000000000"}
+		extents   = []restapi.Volume4D{{}}
+		priority  = int32(10)
+	)
+
+	var tests = []struct {
+		name     string
+		state    scdmodels.OperationalIntentState
+		priority *int32
+		details  string
+	}{
+		{"nominal without priority", scdmodels.OperationalIntentStateAccepted, nil, `{"volumes": [{"volume": {}}], "off_nominal_volumes": [], "priority": 0}`},
+		{"nominal with priority", scdmodels.OperationalIntentStateActivated, &priority, `{"volumes": [{"volume": {}}], "off_nominal_volumes": [], "priority": 10}`},
+		{"off-nominal with priority", scdmodels.OperationalIntentStateContingent, &priority, `{"volumes": [], "off_nominal_volumes": [{"volume": {}}], "priority": 10}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			details, err := json.Marshal(newNotifiedOperationalIntent(reference, test.state, extents, test.priority).Details)
+			require.NoError(t, err)
+			require.JSONEq(t, test.details, string(details))
+		})
+	}
+}
@@ -8,11 +8,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/interuss/dss/pkg/api"
 	restapi "github.com/interuss/dss/pkg/api/scdv1"
+	"github.com/interuss/dss/pkg/audit"
 	"github.com/interuss/dss/pkg/auth"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/stacktrace"
 )
 
@@ -51,6 +53,9 @@ func subscriptionIsImplicitAndOnlyAttachedToOIR(ctx context.Context, r repos.Rep
 // the specified version.
 func (a *Server) DeleteOperationalIntentReference(ctx context.Context, req *restapi.DeleteOperationalIntentReferenceRequest,
 ) restapi.DeleteOperationalIntentReferenceResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.DeleteOperationalIntentReference")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.DeleteOperationalIntentReferenceResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -144,6 +149,9 @@ func (a *Server) DeleteOperationalIntentReference(ctx context.Context, req *rest
 		if err := r.DeleteOperationalIntent(ctx, id); err != nil {
 			return stacktrace.Propagate(err, "Unable to delete OperationalIntent from repo")
 		}
+		if err := auditChange(ctx, r, audit.EntityOperationalIntent, old.ID, old.Manager, old.OVN.String(), "", old.Cells); err != nil {
+			return err
+		}
 
 		// removeImplicitSubscription is only true if the OIR had a subscription defined
 		if removeImplicitSubscription {
@@ -152,6 +160,9 @@ func (a *Server) DeleteOperationalIntentReference(ctx context.Context, req *rest
 			if err != nil {
 				return stacktrace.Propagate(err, "Unable to delete associated implicit Subscription")
 			}
+			if err := auditChange(ctx, r, audit.EntitySCDSubscription, previousSubscription.ID, old.Manager, previousSubscription.Version.String(), "", previousSubscription.Cells); err != nil {
+				return err
+			}
 		}
 
 		// Return response to client
@@ -160,7 +171,7 @@ func (a *Server) DeleteOperationalIntentReference(ctx context.Context, req *rest
 			Subscribers:                makeSubscribersToNotify(subsToNotify),
 		}
 
-		return nil
+		return a.enqueueOperationalIntentNotifications(ctx, r, req.Entityid, old.Manager, nil, subsToNotify)
 	}
 
 	err = a.Store.Transact(ctx, action)
@@ -174,6 +185,8 @@ func (a *Server) DeleteOperationalIntentReference(ctx context.Context, req *rest
 			return restapi.DeleteOperationalIntentReferenceResponseSet{Response404: errResp}
 		case dsserr.VersionMismatch:
 			return restapi.DeleteOperationalIntentReferenceResponseSet{Response409: errResp}
+		case dsserr.Exhausted:
+			return restapi.DeleteOperationalIntentReferenceResponseSet{Response429: errResp}
 		default:
 			return restapi.DeleteOperationalIntentReferenceResponseSet{Response500: &api.InternalServerErrorBody{
 				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
@@ -186,6 +199,9 @@ func (a *Server) DeleteOperationalIntentReference(ctx context.Context, req *rest
 // GetOperationalIntentReference returns a single operation intent ref for the given ID.
 func (a *Server) GetOperationalIntentReference(ctx context.Context, req *restapi.GetOperationalIntentReferenceRequest,
 ) restapi.GetOperationalIntentReferenceResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.GetOperationalIntentReference")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.GetOperationalIntentReferenceResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -219,6 +235,7 @@ func (a *Server) GetOperationalIntentReference(ctx context.Context, req *restapi
 
 		response = &restapi.GetOperationalIntentReferenceResponse{
 			OperationalIntentReference: *op.ToRest(),
+			Extents:                    scdmodels.VolumesToRest(op.Volumes),
 		}
 
 		return nil
@@ -241,6 +258,9 @@ func (a *Server) GetOperationalIntentReference(ctx context.Context, req *restapi
 // bounds.
 func (a *Server) QueryOperationalIntentReferences(ctx context.Context, req *restapi.QueryOperationalIntentReferencesRequest,
 ) restapi.QueryOperationalIntentReferencesResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.QueryOperationalIntentReferences")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.QueryOperationalIntentReferencesResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -275,10 +295,17 @@ func (a *Server) QueryOperationalIntentReferences(ctx context.Context, req *rest
 	var response *restapi.QueryOperationalIntentReferenceResponse
 	action := func(ctx context.Context, r repos.Repository) (err error) {
 		// Perform search query on Store
-		ops, err := r.SearchOperationalIntents(ctx, vol4)
+		ops, err := r.SearchOperationalIntents(ctx, vol4, a.resultLimit()+1)
 		if err != nil {
 			return stacktrace.Propagate(err, "Unable to query for OperationalIntents in repo")
 		}
+		ops = api.TruncateResults(ctx, ops, a.resultLimit())
+		if a.ExactGeometry {
+			ops, err = refineEntities(ops, vol4)
+			if err != nil {
+				return stacktrace.Propagate(err, "Unable to refine OperationalIntents found")
+			}
+		}
 
 		// Create response for client
 		response = &restapi.QueryOperationalIntentReferenceResponse{
@@ -311,6 +338,9 @@ func (a *Server) QueryOperationalIntentReferences(ctx context.Context, req *rest
 
 func (a *Server) CreateOperationalIntentReference(ctx context.Context, req *restapi.CreateOperationalIntentReferenceRequest,
 ) restapi.CreateOperationalIntentReferenceResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.CreateOperationalIntentReference")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.CreateOperationalIntentReferenceResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -336,6 +366,8 @@ func (a *Server) CreateOperationalIntentReference(ctx context.Context, req *rest
 				Message: dsserr.Handle(ctx, err)}}
 		case dsserr.MissingOVNs:
 			return restapi.CreateOperationalIntentReferenceResponseSet{Response409: respConflict}
+		case dsserr.Exhausted:
+			return restapi.CreateOperationalIntentReferenceResponseSet{Response429: errResp}
 		default:
 			return restapi.CreateOperationalIntentReferenceResponseSet{Response500: &api.InternalServerErrorBody{
 				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
@@ -347,6 +379,9 @@ func (a *Server) CreateOperationalIntentReference(ctx context.Context, req *rest
 
 func (a *Server) UpdateOperationalIntentReference(ctx context.Context, req *restapi.UpdateOperationalIntentReferenceRequest,
 ) restapi.UpdateOperationalIntentReferenceResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.UpdateOperationalIntentReference")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.UpdateOperationalIntentReferenceResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -372,6 +407,8 @@ func (a *Server) UpdateOperationalIntentReference(ctx context.Context, req *rest
 				Message: dsserr.Handle(ctx, err)}}
 		case dsserr.MissingOVNs:
 			return restapi.UpdateOperationalIntentReferenceResponseSet{Response409: respConflict}
+		case dsserr.Exhausted:
+			return restapi.UpdateOperationalIntentReferenceResponseSet{Response429: errResp}
 		default:
 			return restapi.UpdateOperationalIntentReferenceResponseSet{Response500: &api.InternalServerErrorBody{
 				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
@@ -389,6 +426,7 @@ type validOIRParams struct {
 	extents              []*dssmodels.Volume4D
 	uExtent              *dssmodels.Volume4D
 	cells                s2.CellUnion
+	volumes              []scdmodels.Volume
 	subscriptionID       dssmodels.ID
 	ussBaseURL           string
 	implicitSubscription struct {
@@ -420,6 +458,7 @@ func (vp *validOIRParams) toOIR(manager dssmodels.Manager, attachedSub *scdmodel
 		AltitudeLower: vp.uExtent.SpatialVolume.AltitudeLo,
 		AltitudeUpper: vp.uExtent.SpatialVolume.AltitudeHi,
 		Cells:         vp.cells,
+		Volumes:       vp.volumes,
 
 		USSBaseURL:     vp.ussBaseURL,
 		SubscriptionID: subID,
@@ -530,6 +569,10 @@ func validateAndReturnUpsertParams(
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Invalid area")
 	}
+	valid.volumes, err = scdmodels.VolumesFromVolume4Ds(valid.extents)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Invalid area")
+	}
 
 	if valid.uExtent.EndTime.Before(*valid.uExtent.StartTime) {
 		return nil, stacktrace.NewError("End time is past the start time")
@@ -626,7 +669,14 @@ func createAndStoreNewImplicitSubscription(ctx context.Context, r repos.Reposito
 		ImplicitSubscription:        true,
 	}
 
-	return r.UpsertSubscription(ctx, &subToUpsert)
+	sub, err := r.UpsertSubscription(ctx, &subToUpsert)
+	if err != nil {
+		return nil, err
+	}
+	if err := auditChange(ctx, r, audit.EntitySCDSubscription, sub.ID, manager, "", sub.Version.String(), sub.Cells); err != nil {
+		return nil, err
+	}
+	return sub, nil
 }
 
 // computeNotificationVolume computes the volume that needs to be queried for subscriptions
@@ -670,10 +720,13 @@ func getRelevantSubscriptionsAndIncrementIndices(
 ) (repos.Subscriptions, error) {
 
 	// Find Subscriptions that may need to be notified
-	allsubs, err := r.SearchSubscriptions(ctx, notifyVolume)
+	allsubs, err := r.SearchSubscriptions(ctx, notifyVolume, dssmodels.MaxResultLimit+1)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to search for impacted subscriptions.")
 	}
+	if err := dssmodels.CheckCompleteResults(allsubs, "Subscriptions"); err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to find all impacted subscriptions")
+	}
 
 	// Limit Subscription notifications to only those interested in OperationalIntents
 	subs := repos.Subscriptions{}
@@ -691,21 +744,55 @@ func getRelevantSubscriptionsAndIncrementIndices(
 	return subs, nil
 }
 
+// searchPerVolume returns the entities of kind found by search to intersect
+// any of volumes, rather than their union. If exact is true, the outlines of the
+// entities found must intersect the outline of the volume.
+func searchPerVolume[E scdmodels.VolumeEntity](ctx context.Context, volumes []scdmodels.Volume, exact bool, kind string,
+	search func(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]E, error)) ([]E, error) {
+	var result []E
+	found := map[dssmodels.ID]bool{}
+	for i := range volumes {
+		vol4 := volumes[i].ToVolume4D()
+		entities, err := search(ctx, vol4, dssmodels.MaxResultLimit+1)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Unable to search %s intersecting volume %d", kind, i)
+		}
+		if err := dssmodels.CheckCompleteResults(entities, kind); err != nil {
+			return nil, stacktrace.Propagate(err, "Unable to find all %s intersecting volume %d", kind, i)
+		}
+		if exact {
+			entities, err = refineEntities(entities, vol4)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Unable to refine %s intersecting volume %d", kind, i)
+			}
+		}
+		for _, entity := range entities {
+			if !found[entity.EntityID()] {
+				found[entity.EntityID()] = true
+				result = append(result, entity)
+			}
+		}
+	}
+	return result, nil
+}
+
 // validateKeyAndProvideConflictResponse ensures that the provided key contains all the necessary OVNs relevant for the area covered by the OperationalIntent.
 // - If all required keys are provided, (nil, nil) will be returned.
 // - If keys are missing, the conflict response to be sent back as well as an error with the dsserr.MissingOVNs code will be returned.
 // - In case of any other error, (nil, error) will be returned.
+// If exactGeometry is true, only the entities which outlines intersect the OperationalIntent are relevant.
 func validateKeyAndProvideConflictResponse(
 	ctx context.Context,
 	r repos.Repository,
 	requestingManager dssmodels.Manager,
 	params *validOIRParams,
 	attachedSubscription *scdmodels.Subscription,
+	exactGeometry bool,
 ) (*restapi.AirspaceConflictResponse, error) {
 
 	// Identify OperationalIntents missing from the key
 	var missingOps []*scdmodels.OperationalIntent
-	relevantOps, err := r.SearchOperationalIntents(ctx, params.uExtent)
+	relevantOps, err := searchPerVolume(ctx, params.volumes, exactGeometry, "OperationalIntents", r.SearchOperationalIntents)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Unable to SearchOperations")
 	}
@@ -720,7 +807,7 @@ func validateKeyAndProvideConflictResponse(
 	// Identify Constraints missing from the key
 	var missingConstraints []*scdmodels.Constraint
 	if attachedSubscription != nil && attachedSubscription.NotifyForConstraints {
-		constraints, err := r.SearchConstraints(ctx, params.uExtent)
+		constraints, err := searchPerVolume(ctx, params.volumes, exactGeometry, "Constraints", r.SearchConstraints)
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "Unable to SearchConstraints")
 		}
@@ -774,8 +861,9 @@ func validateKeyAndProvideConflictResponse(
 // ensureSubscriptionCoversOIR ensures that the subscription covers the requested geo-temporal extent, extending it if both possible and required,
 // or failing otherwise.
 // After this method returns successfully, the subscription will cover the requested geo-temporal extent.
-func ensureSubscriptionCoversOIR(ctx context.Context, r repos.Repository, sub *scdmodels.Subscription, params *validOIRParams) (*scdmodels.Subscription, error) {
+func ensureSubscriptionCoversOIR(ctx context.Context, r repos.Repository, sub *scdmodels.Subscription, params *validOIRParams, quota Quota) (*scdmodels.Subscription, error) {
 
+	original := *sub
 	updateSub := false
 	if sub.StartTime != nil && sub.StartTime.After(*params.uExtent.StartTime) {
 		if sub.ImplicitSubscription {
@@ -793,9 +881,28 @@ func ensureSubscriptionCoversOIR(ctx context.Context, r repos.Repository, sub *s
 			return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Subscription ends before the OperationalIntent ends")
 		}
 	}
+	if sub.ImplicitSubscription {
+		// Subscriptions are searched by altitude: implicit subscriptions are
+		// extended vertically so that their manager keeps being notified of
+		// changes around the OperationalIntent.
+		lo, hi := params.uExtent.SpatialVolume.AltitudeLo, params.uExtent.SpatialVolume.AltitudeHi
+		if sub.AltitudeLo != nil && (lo == nil || *lo < *sub.AltitudeLo) {
+			sub.AltitudeLo = lo
+			updateSub = true
+		}
+		if sub.AltitudeHi != nil && (hi == nil || *hi > *sub.AltitudeHi) {
+			sub.AltitudeHi = hi
+			updateSub = true
+		}
+	}
 	if !sub.Cells.Contains(params.cells) {
 		if sub.ImplicitSubscription {
 			sub.Cells = s2.CellUnionFromUnion(sub.Cells, params.cells)
+			// The extended Subscription no longer matches any submitted outline.
+			sub.Footprint = nil
+			if err := quota.checkSubscription(ctx, r, sub.Manager, sub.ID, sub.Cells); err != nil {
+				return nil, stacktrace.Propagate(err, "Subscription cannot be extended to cover the OperationalIntent")
+			}
 			updateSub = true
 		} else {
 			return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Subscription does not cover entire spatial area of the OperationalIntent")
@@ -806,6 +913,9 @@ func ensureSubscriptionCoversOIR(ctx context.Context, r repos.Repository, sub *s
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "Failed to update existing Subscription")
 		}
+		if err := auditChange(ctx, r, audit.EntitySCDSubscription, upsertedSub.ID, sub.Manager, original.Version.String(), upsertedSub.Version.String(), upsertedSub.Cells); err != nil {
+			return nil, err
+		}
 		return upsertedSub, nil
 	}
 
@@ -816,6 +926,9 @@ func ensureSubscriptionCoversOIR(ctx context.Context, r repos.Repository, sub *s
 // If the ovn argument is empty (""), it will attempt to create a new Operational Intent.
 func (a *Server) upsertOperationalIntentReference(ctx context.Context, now time.Time, authorizedManager *api.AuthorizationResult, entityid restapi.EntityID, ovn restapi.EntityOVN, params *restapi.PutOperationalIntentReferenceParameters,
 ) (*restapi.ChangeOperationalIntentReferenceResponse, *restapi.AirspaceConflictResponse, error) {
+	ctx, span := tracing.Start(ctx, "scd.upsertOperationalIntentReference")
+	defer span.End()
+
 	// Note: validateAndReturnUpsertParams and checkUpsertPermissionsAndReturnManager could be moved out of this method and only the valid params passed,
 	// but this requires some changes in the caller that go beyond the immediate scope of #1088 and can be done later.
 	validParams, err := validateAndReturnUpsertParams(now, entityid, ovn, params, a.AllowHTTPBaseUrls)
@@ -847,6 +960,11 @@ func (a *Server) upsertOperationalIntentReference(ctx context.Context, now time.
 			return stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), "Request validation failed")
 		}
 
+		quota := a.Quotas.For(manager)
+		if err := quota.checkOperationalIntent(ctx, r, manager, validParams.id, validParams.cells); err != nil {
+			return stacktrace.Propagate(err, "OperationalIntent exceeds the quota of manager")
+		}
+
 		var (
 			version     = scdmodels.VersionNumber(1)
 			pastOVNs    = make([]scdmodels.OVN, 0)
@@ -885,6 +1003,13 @@ func (a *Server) upsertOperationalIntentReference(ctx context.Context, now time.
 				// Parameters for a new implicit subscription have been passed: we will create
 				// a new implicit subscription even if another subscription was attached to this OIR before,
 				// regardless of whether it was an implicit subscription or not.
+				replacedSubID := dssmodels.ID("")
+				if removePreviousImplicitSubscription {
+					replacedSubID = previousSub.ID
+				}
+				if err := quota.checkSubscription(ctx, r, manager, replacedSubID, validParams.cells); err != nil {
+					return stacktrace.Propagate(err, "Implicit subscription exceeds the quota of manager")
+				}
 				if attachedSub, err = createAndStoreNewImplicitSubscription(ctx, r, manager, validParams); err != nil {
 					return stacktrace.Propagate(err, "Failed to create implicit subscription")
 				}
@@ -924,14 +1049,14 @@ func (a *Server) upsertOperationalIntentReference(ctx context.Context, now time.
 			}
 
 			// We need to ensure the subscription covers the OIR's geo-temporal extent
-			attachedSub, err = ensureSubscriptionCoversOIR(ctx, r, attachedSub, validParams)
+			attachedSub, err = ensureSubscriptionCoversOIR(ctx, r, attachedSub, validParams, quota)
 			if err != nil {
 				return stacktrace.Propagate(err, "Failed to ensure subscription covers OIR")
 			}
 		}
 
 		if validParams.state.RequiresKey() {
-			responseConflict, err = validateKeyAndProvideConflictResponse(ctx, r, manager, validParams, attachedSub)
+			responseConflict, err = validateKeyAndProvideConflictResponse(ctx, r, manager, validParams, attachedSub, a.ExactGeometry)
 			if err != nil {
 				return stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), "Failed to validate key")
 			}
@@ -945,6 +1070,13 @@ func (a *Server) upsertOperationalIntentReference(ctx context.Context, now time.
 		if err != nil {
 			return stacktrace.Propagate(err, "Failed to upsert OperationalIntent in repo")
 		}
+		var oldOVN string
+		if old != nil {
+			oldOVN = old.OVN.String()
+		}
+		if err := auditChange(ctx, r, audit.EntityOperationalIntent, op.ID, manager, oldOVN, op.OVN.String(), op.Cells); err != nil {
+			return err
+		}
 
 		// Check if the previously attached subscription should be removed
 		if removePreviousImplicitSubscription {
@@ -952,6 +1084,9 @@ func (a *Server) upsertOperationalIntentReference(ctx context.Context, now time.
 			if err != nil {
 				return stacktrace.Propagate(err, "Unable to delete previous implicit Subscription")
 			}
+			if err := auditChange(ctx, r, audit.EntitySCDSubscription, previousSub.ID, manager, previousSub.Version.String(), "", previousSub.Cells); err != nil {
+				return err
+			}
 		}
 
 		notifyVolume, err := computeNotificationVolume(old, validParams.uExtent)
@@ -971,7 +1106,8 @@ func (a *Server) upsertOperationalIntentReference(ctx context.Context, now time.
 			Subscribers:                makeSubscribersToNotify(subsToNotify),
 		}
 
-		return nil
+		intent := newNotifiedOperationalIntent(responseOK.OperationalIntentReference, op.State, params.Extents, params.Priority)
+		return a.enqueueOperationalIntentNotifications(ctx, r, entityid, manager, intent, subsToNotify)
 	}
 
 	err = a.Store.Transact(ctx, action)
@@ -0,0 +1,120 @@
+package scd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/golang/geo/s2"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/geo"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/stacktrace"
+)
+
+// Quota bounds the SCD entities a single manager may hold in the DSS. A zero
+// field sets no limit.
+type Quota struct {
+	// MaxActiveOperationalIntents is the maximum number of operational intents
+	// of the manager which have not ended yet.
+	MaxActiveOperationalIntents int `json:"max_active_operational_intents"`
+	// MaxSubscriptionsPerCell is the maximum number of active subscriptions,
+	// implicit ones included, of the manager in any single S2 cell.
+	MaxSubscriptionsPerCell int `json:"max_subscriptions_per_cell"`
+	// MaxOperationalIntentsAreaKm2 is the maximum area covered by the active
+	// operational intents of the manager altogether.
+	MaxOperationalIntentsAreaKm2 float64 `json:"max_operational_intents_area_km2"`
+}
+
+// Quotas holds the Quota applying to each manager.
+type Quotas struct {
+	// Default applies to the managers absent from Managers.
+	Default Quota
+	// Managers overrides the Default quota of specific managers. An override
+	// replaces the whole Default quota.
+	Managers map[dssmodels.Manager]Quota
+}
+
+// For returns the Quota applying to manager. A nil Quotas sets no limit.
+func (q *Quotas) For(manager dssmodels.Manager) Quota {
+	if q == nil {
+		return Quota{}
+	}
+	if quota, ok := q.Managers[manager]; ok {
+		return quota
+	}
+	return q.Default
+}
+
+// LoadQuotasFile reads the per-manager quota overrides from the JSON file at
+// path, for example:
+//
+//	{
+//	  "managers": {
+//	    "uss1": {"max_active_operational_intents": 1000},
+//	    "uss2": {"max_subscriptions_per_cell": 5, "max_operational_intents_area_km2": 500}
+//	  }
+//	}
+func LoadQuotasFile(path string) (map[dssmodels.Manager]Quota, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error reading quotas file")
+	}
+
+	var file struct {
+		Managers map[dssmodels.Manager]Quota `json:"managers"`
+	}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, stacktrace.Propagate(err, "Error parsing quotas file %s", path)
+	}
+	for manager, quota := range file.Managers {
+		if quota.MaxActiveOperationalIntents < 0 || quota.MaxSubscriptionsPerCell < 0 || quota.MaxOperationalIntentsAreaKm2 < 0 {
+			return nil, stacktrace.NewError("Negative quota for manager %s in %s", manager, path)
+		}
+	}
+	return file.Managers, nil
+}
+
+// checkOperationalIntent returns an error with the dsserr.Exhausted code if
+// manager would exceed q by holding the active operational intent identified by
+// id and covering cells.
+func (q Quota) checkOperationalIntent(ctx context.Context, r repos.Repository, manager dssmodels.Manager, id dssmodels.ID, cells s2.CellUnion) error {
+	if q.MaxActiveOperationalIntents > 0 {
+		count, err := r.CountActiveOperationalIntentsByManager(ctx, manager, id)
+		if err != nil {
+			return stacktrace.Propagate(err, "Unable to count active OperationalIntents of manager")
+		}
+		if count >= q.MaxActiveOperationalIntents {
+			return stacktrace.NewErrorWithCode(dsserr.Exhausted, "Too many active OperationalIntents for manager (limit is %d)", q.MaxActiveOperationalIntents)
+		}
+	}
+	if q.MaxOperationalIntentsAreaKm2 > 0 {
+		covered, err := r.ActiveOperationalIntentCellsByManager(ctx, manager, id)
+		if err != nil {
+			return stacktrace.Propagate(err, "Unable to get cells of active OperationalIntents of manager")
+		}
+		if area := geo.CellUnionAreaKm2(s2.CellUnionFromUnion(covered, cells)); area > q.MaxOperationalIntentsAreaKm2 {
+			return stacktrace.NewErrorWithCode(dsserr.Exhausted, "Active OperationalIntents of manager would cover %.1f km², over the limit of %.1f km²", area, q.MaxOperationalIntentsAreaKm2)
+		}
+	}
+	return nil
+}
+
+// checkSubscription returns an error with the dsserr.Exhausted code if manager
+// would exceed q by holding a subscription covering cells. excluded identifies
+// the subscription being replaced, if any.
+func (q Quota) checkSubscription(ctx context.Context, r repos.Repository, manager dssmodels.Manager, excluded dssmodels.ID, cells s2.CellUnion) error {
+	if q.MaxSubscriptionsPerCell <= 0 || len(cells) == 0 {
+		return nil
+	}
+
+	count, err := r.MaxSubscriptionCountInCellsByManager(ctx, cells, manager, excluded)
+	if err != nil {
+		return stacktrace.Propagate(err, "Unable to count Subscriptions of manager in cells")
+	}
+	if count >= q.MaxSubscriptionsPerCell {
+		return stacktrace.NewErrorWithCode(dsserr.Exhausted, "Too many Subscriptions for manager in the same area (limit is %d)", q.MaxSubscriptionsPerCell)
+	}
+	return nil
+}
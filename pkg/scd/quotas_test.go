@@ -0,0 +1,101 @@
+package scd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/geo"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/store/memory"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	quotaCell      = s2.CellID(int64(8768904281496485888))
+	quotaOtherCell = s2.CellID(int64(8768904178417270784))
+)
+
+func TestQuotasFor(t *testing.T) {
+	var nilQuotas *Quotas
+	require.Equal(t, Quota{}, nilQuotas.For("uss1"))
+
+	quotas := &Quotas{
+		Default:  Quota{MaxActiveOperationalIntents: 10},
+		Managers: map[dssmodels.Manager]Quota{"uss1": {MaxSubscriptionsPerCell: 2}},
+	}
+	require.Equal(t, Quota{MaxActiveOperationalIntents: 10}, quotas.For("uss2"))
+	require.Equal(t, Quota{MaxSubscriptionsPerCell: 2}, quotas.For("uss1"))
+}
+
+func TestLoadQuotasFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quotas.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"managers": {"uss1": {"max_active_operational_intents": 3, "max_operational_intents_area_km2": 12.5}}}`), 0600))
+	managers, err := LoadQuotasFile(path)
+	require.NoError(t, err)
+	require.Equal(t, map[dssmodels.Manager]Quota{
+		"uss1": {MaxActiveOperationalIntents: 3, MaxOperationalIntentsAreaKm2: 12.5},
+	}, managers)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"managers": {"uss1": {"max_subscriptions_per_cell": -1}}}`), 0600))
+	_, err = LoadQuotasFile(path)
+	require.Error(t, err)
+}
+
+func TestQuotaChecks(t *testing.T) {
+	ctx := context.Background()
+	r, err := memory.NewStore().Interact(ctx)
+	require.NoError(t, err)
+
+	end := time.Now().Add(time.Hour)
+	existing := dssmodels.ID("00000185-e36d-40be-8d38-beca6ca30000")
+	_, err = r.UpsertOperationalIntent(ctx, &scdmodels.OperationalIntent{
+		ID:      existing,
+		Manager: "uss1",
+		EndTime: &end,
+		Cells:   s2.CellUnion{quotaCell},
+	})
+	require.NoError(t, err)
+	_, err = r.UpsertSubscription(ctx, &scdmodels.Subscription{
+		ID:      existing,
+		Manager: "uss1",
+		EndTime: &end,
+		Cells:   s2.CellUnion{quotaCell},
+	})
+	require.NoError(t, err)
+
+	newID := dssmodels.ID("78ea3fe8-71c2-4f5c-9b44-9c02f5563c6f")
+	requireExhausted := func(err error) {
+		require.Error(t, err)
+		require.Equal(t, dsserr.Exhausted, stacktrace.GetCode(err))
+	}
+
+	// Active operational intents
+	quota := Quota{MaxActiveOperationalIntents: 1}
+	requireExhausted(quota.checkOperationalIntent(ctx, r, "uss1", newID, s2.CellUnion{quotaOtherCell}))
+	require.NoError(t, quota.checkOperationalIntent(ctx, r, "uss1", existing, s2.CellUnion{quotaOtherCell}))
+	require.NoError(t, quota.checkOperationalIntent(ctx, r, "uss2", newID, s2.CellUnion{quotaOtherCell}))
+
+	// Covered area, where overlapping cells are only counted once
+	area := geo.CellUnionAreaKm2(s2.CellUnion{quotaCell})
+	quota = Quota{MaxOperationalIntentsAreaKm2: 1.5 * area}
+	require.NoError(t, quota.checkOperationalIntent(ctx, r, "uss1", newID, s2.CellUnion{quotaCell}))
+	requireExhausted(quota.checkOperationalIntent(ctx, r, "uss1", newID, s2.CellUnion{quotaOtherCell}))
+
+	// Subscriptions per cell
+	quota = Quota{MaxSubscriptionsPerCell: 1}
+	requireExhausted(quota.checkSubscription(ctx, r, "uss1", newID, s2.CellUnion{quotaCell}))
+	require.NoError(t, quota.checkSubscription(ctx, r, "uss1", existing, s2.CellUnion{quotaCell}))
+	require.NoError(t, quota.checkSubscription(ctx, r, "uss1", newID, s2.CellUnion{quotaOtherCell}))
+	require.NoError(t, quota.checkSubscription(ctx, r, "uss2", newID, s2.CellUnion{quotaCell}))
+
+	// No limit
+	require.NoError(t, Quota{}.checkOperationalIntent(ctx, r, "uss1", newID, s2.CellUnion{quotaOtherCell}))
+	require.NoError(t, Quota{}.checkSubscription(ctx, r, "uss1", newID, s2.CellUnion{quotaCell}))
+}
@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/golang/geo/s2"
+	"github.com/interuss/dss/pkg/audit"
 	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 )
 
@@ -23,22 +25,32 @@ type OperationalIntent interface {
 	// UpsertOperationalIntent inserts or updates an operation into the store.
 	UpsertOperationalIntent(ctx context.Context, operation *scdmodels.OperationalIntent) (*scdmodels.OperationalIntent, error)
 
-	// SearchOperationalIntents returns all operations intersecting "v4d".
-	SearchOperationalIntents(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.OperationalIntent, error)
+	// SearchOperationalIntents returns up to "limit" operations intersecting "v4d".
+	SearchOperationalIntents(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.OperationalIntent, error)
 
 	// GetDependentOperationalIntents returns IDs of all operations dependent on
 	// subscription identified by "subscriptionID".
 	GetDependentOperationalIntents(ctx context.Context, subscriptionID dssmodels.ID) ([]dssmodels.ID, error)
 
-	// ListExpiredOperationalIntents lists all operational intents older than the threshold.
+	// ListExpiredOperationalIntents lists up to "limit" operational intents older than the threshold.
 	// Their age is determined by their end time, or by their update time if they do not have an end time.
-	ListExpiredOperationalIntents(ctx context.Context, threshold time.Time) ([]*scdmodels.OperationalIntent, error)
+	ListExpiredOperationalIntents(ctx context.Context, threshold time.Time, limit int) ([]*scdmodels.OperationalIntent, error)
+
+	// CountActiveOperationalIntentsByManager counts the operational intents
+	// managed by "manager" which have not ended yet, except the one identified
+	// by "excluded".
+	CountActiveOperationalIntentsByManager(ctx context.Context, manager dssmodels.Manager, excluded dssmodels.ID) (int, error)
+
+	// ActiveOperationalIntentCellsByManager returns the union of the cells
+	// covered by the operational intents managed by "manager" which have not
+	// ended yet, except the one identified by "excluded".
+	ActiveOperationalIntentCellsByManager(ctx context.Context, manager dssmodels.Manager, excluded dssmodels.ID) (s2.CellUnion, error)
 }
 
 // Subscription abstracts subscription-specific interactions with the backing repository.
 type Subscription interface {
-	// SearchSubscriptions returns all Subscriptions in "v4d".
-	SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.Subscription, error)
+	// SearchSubscriptions returns up to "limit" Subscriptions in "v4d".
+	SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.Subscription, error)
 
 	// GetSubscription returns the Subscription referenced by id, or nil and no
 	// error if the Subscription doesn't exist
@@ -61,9 +73,16 @@ type Subscription interface {
 	// LockSubscriptionsOnCells locks the subscriptions of interest on specific cells.
 	LockSubscriptionsOnCells(ctx context.Context, cells s2.CellUnion) error
 
-	// ListExpiredSubscriptions lists all subscriptions older than the threshold.
+	// ListExpiredSubscriptions lists up to "limit" subscriptions older than the threshold.
 	// Their age is determined by their end time, or by their update time if they do not have an end time.
-	ListExpiredSubscriptions(ctx context.Context, threshold time.Time) ([]*scdmodels.Subscription, error)
+	// Subscriptions still referenced by an operational intent are not listed,
+	// as deleting them would delete the operational intent too.
+	ListExpiredSubscriptions(ctx context.Context, threshold time.Time, limit int) ([]*scdmodels.Subscription, error)
+
+	// MaxSubscriptionCountInCellsByManager finds, out of a set of cells, the
+	// cell with the most active subscriptions of "manager", other than the
+	// subscription identified by "excluded", and returns that count.
+	MaxSubscriptionCountInCellsByManager(ctx context.Context, cells s2.CellUnion, manager dssmodels.Manager, excluded dssmodels.ID) (int, error)
 }
 
 type UssAvailability interface {
@@ -72,10 +91,27 @@ type UssAvailability interface {
 	UpsertUssAvailability(ctx context.Context, ussa *scdmodels.UssAvailabilityStatus) (*scdmodels.UssAvailabilityStatus, error)
 }
 
+// repos.DSSReport abstracts interactions with the reports about the DSS
+// submitted by USSs.
+type DSSReport interface {
+	// InsertDSSReport stores report, received at the timestamp of the ongoing
+	// transaction, if any.
+	InsertDSSReport(ctx context.Context, report *scdmodels.DSSReport) (*scdmodels.DSSReport, error)
+
+	// GetDSSReport returns the report identified by "id", or nil if it does
+	// not exist.
+	GetDSSReport(ctx context.Context, id dssmodels.ID) (*scdmodels.DSSReport, error)
+
+	// ListDSSReports returns up to "limit" reports, most recent first,
+	// submitted by "reporter" (any reporter if empty) in the time interval
+	// ["earliest", "latest"] (unbounded for nil bounds).
+	ListDSSReports(ctx context.Context, reporter dssmodels.Manager, earliest *time.Time, latest *time.Time, limit int) ([]*scdmodels.DSSReport, error)
+}
+
 // repos.Constraint abstracts constraint-specific interactions with the backing store.
 type Constraint interface {
-	// SearchConstraints returns all Constraints in "v4d".
-	SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.Constraint, error)
+	// SearchConstraints returns up to "limit" Constraints in "v4d".
+	SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.Constraint, error)
 
 	// GetConstraint returns the Constraint referenced by id, or
 	// (nil, sql.ErrNoRows) if the Constraint doesn't exist
@@ -88,6 +124,26 @@ type Constraint interface {
 	// deleted subscription.  Returns nil and an error if the Constraint does
 	// not exist.
 	DeleteConstraint(ctx context.Context, id dssmodels.ID) error
+
+	// ListExpiredConstraints lists up to "limit" constraints older than the threshold.
+	// Their age is determined by their end time, or by their update time if they do not have an end time.
+	ListExpiredConstraints(ctx context.Context, threshold time.Time, limit int) ([]*scdmodels.Constraint, error)
+}
+
+// Notifications abstracts interactions with the outbox of the notifications
+// sent by the DSS to USSs.
+type Notifications interface {
+	// EnqueueNotifications adds ns to the outbox. They are delivered once the
+	// ongoing transaction, if any, is committed.
+	EnqueueNotifications(ctx context.Context, ns []*notifications.Notification) error
+}
+
+// Audit abstracts interactions with the audit trail of the changes made to
+// SCD entities.
+type Audit interface {
+	// AppendAuditRecord adds r to the audit trail, recorded at the timestamp of
+	// the ongoing transaction, if any.
+	AppendAuditRecord(ctx context.Context, r *audit.Record) error
 }
 
 // Repository aggregates all SCD-specific repo interfaces.
@@ -96,6 +152,9 @@ type Repository interface {
 	Subscription
 	Constraint
 	UssAvailability
+	DSSReport
+	Notifications
+	Audit
 }
 
 // IncrementNotificationIndices is a utility function that extracts the IDs from
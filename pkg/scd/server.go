@@ -7,6 +7,7 @@ import (
 	"github.com/interuss/dss/pkg/api"
 	restapi "github.com/interuss/dss/pkg/api/scdv1"
 	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 	scdstore "github.com/interuss/dss/pkg/scd/store"
 	"github.com/interuss/stacktrace"
@@ -39,6 +40,26 @@ type Server struct {
 	DSSReportHandler  ReceivedReportHandler
 	Timeout           time.Duration
 	AllowHTTPBaseUrls bool
+	// NotifySubscribers enables the delivery by the DSS of the notifications
+	// of operational intent and constraint changes to subscribed USSs.
+	NotifySubscribers bool
+	// Quotas bounds the operational intents and subscriptions of each
+	// manager. A nil Quotas sets no limit.
+	Quotas *Quotas
+	// ExactGeometry enables the refinement of cell-based searches with the
+	// submitted outlines of the entities found.
+	ExactGeometry bool
+	// MaxResultLimit is the maximum number of entities returned by a search,
+	// dssmodels.MaxResultLimit if 0.
+	MaxResultLimit int
+}
+
+// resultLimit returns the maximum number of entities returned by a search.
+func (a *Server) resultLimit() int {
+	if a.MaxResultLimit > 0 {
+		return a.MaxResultLimit
+	}
+	return dssmodels.MaxResultLimit
 }
 
 func setAuthError(ctx context.Context, authErr error, resp401, resp403 **restapi.ErrorResponse, resp500 **api.InternalServerErrorBody) {
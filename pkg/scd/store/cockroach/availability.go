@@ -42,13 +42,14 @@ func init() {
 func (u *repo) UpsertUssAvailability(ctx context.Context, s *scdmodels.UssAvailabilityStatus) (*scdmodels.UssAvailabilityStatus, error) {
 	var (
 		upsertQuery = fmt.Sprintf(`
-		UPSERT INTO
+		INSERT INTO
 		scd_uss_availability
 		  (%s)
 		VALUES
 			($1, $2, transaction_timestamp())
+		%s
 		RETURNING
-			%s`, availabilityFieldsWithoutPrefix, availabilityFieldsWithPrefix)
+			%s`, availabilityFieldsWithoutPrefix, dsssql.OnConflictUpdate("id", availabilityFieldsWithIndices[:]), availabilityFieldsWithPrefix)
 	)
 
 	s, err := u.fetchAvailability(ctx, u.q, upsertQuery,
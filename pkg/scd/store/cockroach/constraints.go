@@ -88,6 +88,18 @@ func (c *repo) fetchConstraints(ctx context.Context, q dsssql.Queryable, query s
 	if err := rows.Err(); err != nil {
 		return nil, stacktrace.Propagate(err, "Error in rows query result")
 	}
+
+	ids := make([]dssmodels.ID, len(payload))
+	for i, constraint := range payload {
+		ids[i] = constraint.ID
+	}
+	volumes, err := constraintVolumes.fetch(ctx, q, ids)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Constraint volumes")
+	}
+	for _, constraint := range payload {
+		constraint.Volumes = volumes[constraint.ID]
+	}
 	return payload, nil
 }
 
@@ -127,13 +139,14 @@ func (c *repo) GetConstraint(ctx context.Context, id dssmodels.ID) (*scdmodels.C
 func (c *repo) UpsertConstraint(ctx context.Context, s *scdmodels.Constraint) (*scdmodels.Constraint, error) {
 	var (
 		upsertQuery = fmt.Sprintf(`
-		UPSERT INTO
+		INSERT INTO
 		  scd_constraints
 		  (%s)
 		VALUES
 			($1, $2, $3, $4, $5, $6, $7, $8, $9, transaction_timestamp())
+		%s
 		RETURNING
-			%s`, constraintFieldsWithoutPrefix, constraintFieldsWithPrefix)
+			%s`, constraintFieldsWithoutPrefix, dsssql.OnConflictUpdate("id", constraintFieldsWithIndices[:]), constraintFieldsWithPrefix)
 	)
 
 	cids, err := dsssql.CellUnionToCellIdsWithValidation(s.Cells)
@@ -146,6 +159,7 @@ func (c *repo) UpsertConstraint(ctx context.Context, s *scdmodels.Constraint) (*
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
+	volumes := s.Volumes
 	s, err = c.fetchConstraint(ctx, c.q, upsertQuery,
 		id,
 		s.Manager,
@@ -160,6 +174,11 @@ func (c *repo) UpsertConstraint(ctx context.Context, s *scdmodels.Constraint) (*
 		return nil, stacktrace.Propagate(err, "Error fetching Constraint")
 	}
 
+	if err := constraintVolumes.replace(ctx, c.q, s.ID, volumes); err != nil {
+		return nil, stacktrace.Propagate(err, "Error storing Constraint volumes")
+	}
+	s.Volumes = volumes
+
 	return s, nil
 }
 
@@ -190,7 +209,7 @@ func (c *repo) DeleteConstraint(ctx context.Context, id dssmodels.ID) error {
 }
 
 // Implements scd.repos.Constraint.SearchConstraints
-func (c *repo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.Constraint, error) {
+func (c *repo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.Constraint, error) {
 	var (
 		query = fmt.Sprintf(`
 			SELECT
@@ -203,7 +222,13 @@ func (c *repo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D) (
 				COALESCE(starts_at <= $3, true)
 			AND
 				COALESCE(ends_at >= $2, true)
-			LIMIT $4`, constraintFieldsWithoutPrefix)
+			AND
+				COALESCE(altitude_upper >= $5, true)
+			AND
+				COALESCE(altitude_lower <= $6, true)
+			AND
+				%s
+			LIMIT $4`, constraintFieldsWithoutPrefix, constraintVolumes.intersectsCondition("scd_constraints", 1, 5, 6, 2, 3))
 	)
 
 	// TODO: Lazily calculate & cache spatial covering so that it is only ever
@@ -218,10 +243,37 @@ func (c *repo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D) (
 	}
 
 	constraints, err := c.fetchConstraints(
-		ctx, c.q, query, dsssql.CellUnionToCellIds(cells), v4d.StartTime, v4d.EndTime, dssmodels.MaxResultLimit)
+		ctx, c.q, query, dsssql.CellUnionToCellIds(cells), v4d.StartTime, v4d.EndTime, limit,
+		v4d.SpatialVolume.AltitudeLo, v4d.SpatialVolume.AltitudeHi)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Constraints")
 	}
 
 	return constraints, nil
 }
+
+// ListExpiredConstraints lists up to "limit" constraints older than the threshold.
+// Their age is determined by their end time, or by their last update time if they do not have an end time.
+func (c *repo) ListExpiredConstraints(ctx context.Context, threshold time.Time, limit int) ([]*scdmodels.Constraint, error) {
+	expiredConstraintsQuery := fmt.Sprintf(`
+        SELECT
+            %s
+        FROM
+            scd_constraints
+        WHERE
+            scd_constraints.ends_at IS NOT NULL AND scd_constraints.ends_at <= $1
+            OR
+            scd_constraints.ends_at IS NULL AND scd_constraints.updated_at <= $1 -- use last update time as reference if there is no end time
+        LIMIT $2`, constraintFieldsWithPrefix)
+
+	result, err := c.fetchConstraints(
+		ctx, c.q, expiredConstraintsQuery,
+		threshold,
+		limit,
+	)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Constraints")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+)
+
+const dssReportFields = "id, reporter, report, received_at"
+
+// InsertDSSReport implements repos.DSSReport.InsertDSSReport.
+func (r *repo) InsertDSSReport(ctx context.Context, report *scdmodels.DSSReport) (*scdmodels.DSSReport, error) {
+	var query = fmt.Sprintf(`
+		INSERT INTO
+			scd_dss_reports
+			(%s)
+		VALUES
+			($1, $2, $3, transaction_timestamp())
+		RETURNING
+			%s`, dssReportFields, dssReportFields)
+
+	id, err := report.ID.PgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	reports, err := r.fetchDSSReports(ctx, query, id, report.Reporter, report.Report)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if len(reports) != 1 {
+		return nil, stacktrace.NewError("Query returned %d DSS reports when only 1 was expected", len(reports))
+	}
+	return reports[0], nil
+}
+
+// GetDSSReport implements repos.DSSReport.GetDSSReport.
+func (r *repo) GetDSSReport(ctx context.Context, id dssmodels.ID) (*scdmodels.DSSReport, error) {
+	var query = fmt.Sprintf(`
+		SELECT %s FROM
+			scd_dss_reports
+		WHERE
+			id = $1`, dssReportFields)
+
+	uid, err := id.PgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	reports, err := r.fetchDSSReports(ctx, query, uid)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if len(reports) == 0 {
+		return nil, nil
+	}
+	return reports[0], nil
+}
+
+// ListDSSReports implements repos.DSSReport.ListDSSReports.
+func (r *repo) ListDSSReports(ctx context.Context, reporter dssmodels.Manager, earliest *time.Time, latest *time.Time, limit int) ([]*scdmodels.DSSReport, error) {
+	var (
+		conditions = []string{"TRUE"}
+		args       []interface{}
+	)
+	if reporter != "" {
+		args = append(args, reporter)
+		conditions = append(conditions, fmt.Sprintf("reporter = $%d", len(args)))
+	}
+	if earliest != nil {
+		args = append(args, *earliest)
+		conditions = append(conditions, fmt.Sprintf("received_at >= $%d", len(args)))
+	}
+	if latest != nil {
+		args = append(args, *latest)
+		conditions = append(conditions, fmt.Sprintf("received_at <= $%d", len(args)))
+	}
+	args = append(args, limit)
+
+	var query = fmt.Sprintf(`
+		SELECT %s FROM
+			scd_dss_reports
+		WHERE
+			%s
+		ORDER BY
+			received_at DESC
+		LIMIT $%d`, dssReportFields, strings.Join(conditions, " AND "), len(args))
+
+	return r.fetchDSSReports(ctx, query, args...)
+}
+
+func (r *repo) fetchDSSReports(ctx context.Context, query string, args ...interface{}) ([]*scdmodels.DSSReport, error) {
+	rows, err := r.q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	var payload []*scdmodels.DSSReport
+	for rows.Next() {
+		report := new(scdmodels.DSSReport)
+		err := rows.Scan(
+			&report.ID,
+			&report.Reporter,
+			&report.Report,
+			&report.ReceivedAt,
+		)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning DSS report row")
+		}
+		payload = append(payload, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return payload, nil
+}
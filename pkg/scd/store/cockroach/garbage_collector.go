@@ -0,0 +1,126 @@
+package cockroach
+
+import (
+	"context"
+	"time"
+
+	"github.com/interuss/dss/pkg/metrics"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/dss/pkg/scd/store"
+	"github.com/interuss/stacktrace"
+	"github.com/jonboulle/clockwork"
+)
+
+// GarbageCollector deletes the strategic conflict detection entities which
+// expired more than a TTL ago, in transactions of up to a batch size of
+// entities each.
+type GarbageCollector struct {
+	transactor store.Transactor
+	ttl        time.Duration
+	batchSize  int
+	clock      clockwork.Clock
+}
+
+func NewGarbageCollector(transactor store.Transactor, ttl time.Duration, batchSize int) *GarbageCollector {
+	return &GarbageCollector{
+		transactor: transactor,
+		ttl:        ttl,
+		batchSize:  batchSize,
+		clock:      clockwork.NewRealClock(),
+	}
+}
+
+// DeleteSCDExpiredRecords deletes the expired operational intents, then the
+// expired subscriptions which are no longer referenced by an operational
+// intent, then the expired constraints.
+func (gc *GarbageCollector) DeleteSCDExpiredRecords(ctx context.Context) error {
+	threshold := gc.clock.Now().Add(-gc.ttl)
+
+	if err := gc.DeleteExpiredOperationalIntents(ctx, threshold); err != nil {
+		return stacktrace.Propagate(err,
+			"Failed to delete SCD expired records")
+	}
+	if err := gc.DeleteExpiredSubscriptions(ctx, threshold); err != nil {
+		return stacktrace.Propagate(err,
+			"Failed to delete SCD expired records")
+	}
+	if err := gc.DeleteExpiredConstraints(ctx, threshold); err != nil {
+		return stacktrace.Propagate(err,
+			"Failed to delete SCD expired records")
+	}
+
+	return nil
+}
+
+func (gc *GarbageCollector) DeleteExpiredOperationalIntents(ctx context.Context, threshold time.Time) error {
+	return gc.deleteInBatches(ctx, "operational_intent", func(ctx context.Context, r repos.Repository) (int, error) {
+		expiredOpIntents, err := r.ListExpiredOperationalIntents(ctx, threshold, gc.batchSize)
+		if err != nil {
+			return 0, stacktrace.Propagate(err,
+				"Failed to list expired operational intents")
+		}
+		for _, opIntent := range expiredOpIntents {
+			if err := r.DeleteOperationalIntent(ctx, opIntent.ID); err != nil {
+				return 0, stacktrace.Propagate(err,
+					"Failed to delete operational intent")
+			}
+		}
+		return len(expiredOpIntents), nil
+	})
+}
+
+func (gc *GarbageCollector) DeleteExpiredSubscriptions(ctx context.Context, threshold time.Time) error {
+	return gc.deleteInBatches(ctx, "subscription", func(ctx context.Context, r repos.Repository) (int, error) {
+		// Subscriptions referenced by an operational intent, such as the
+		// implicit subscription of an operational intent which has not
+		// expired yet, are not listed.
+		expiredSubs, err := r.ListExpiredSubscriptions(ctx, threshold, gc.batchSize)
+		if err != nil {
+			return 0, stacktrace.Propagate(err,
+				"Failed to list expired subscriptions")
+		}
+		for _, sub := range expiredSubs {
+			if err := r.DeleteSubscription(ctx, sub.ID); err != nil {
+				return 0, stacktrace.Propagate(err,
+					"Failed to delete subscription")
+			}
+		}
+		return len(expiredSubs), nil
+	})
+}
+
+func (gc *GarbageCollector) DeleteExpiredConstraints(ctx context.Context, threshold time.Time) error {
+	return gc.deleteInBatches(ctx, "constraint", func(ctx context.Context, r repos.Repository) (int, error) {
+		expiredConstraints, err := r.ListExpiredConstraints(ctx, threshold, gc.batchSize)
+		if err != nil {
+			return 0, stacktrace.Propagate(err,
+				"Failed to list expired constraints")
+		}
+		for _, constraint := range expiredConstraints {
+			if err := r.DeleteConstraint(ctx, constraint.ID); err != nil {
+				return 0, stacktrace.Propagate(err,
+					"Failed to delete constraint")
+			}
+		}
+		return len(expiredConstraints), nil
+	})
+}
+
+// deleteInBatches runs deleteBatch in successive transactions until it
+// deletes fewer entities than the batch size.
+func (gc *GarbageCollector) deleteInBatches(ctx context.Context, entity string, deleteBatch func(context.Context, repos.Repository) (int, error)) error {
+	for {
+		var deleted int
+		err := gc.transactor.Transact(ctx, func(ctx context.Context, r repos.Repository) (err error) {
+			deleted, err = deleteBatch(ctx, r)
+			return err
+		})
+		if err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		metrics.GarbageCollected("scd", entity, deleted)
+		if deleted < gc.batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cockroach
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteSCDExpiredRecords(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpStore(ctx, t)
+	)
+	require.NotNil(t, store)
+	defer tearDownStore()
+
+	r, err := store.Interact(ctx)
+	require.NoError(t, err)
+
+	// sub1 expired but is still referenced by an operational intent which has
+	// not, while sub2 and the constraint expired and are not referenced.
+	for _, sub := range []*scdmodels.Subscription{sub1, sub2, sub3} {
+		_, err = r.UpsertSubscription(ctx, sub)
+		require.NoError(t, err)
+	}
+	current := *oi3
+	current.SubscriptionID = &sub1ID
+	_, err = r.UpsertOperationalIntent(ctx, &current)
+	require.NoError(t, err)
+	_, err = r.UpsertConstraint(ctx, &scdmodels.Constraint{
+		ID:        oi1ID,
+		Manager:   "unittest",
+		Version:   1,
+		StartTime: &start1,
+		EndTime:   &end1,
+		Cells:     cells,
+	})
+	require.NoError(t, err)
+
+	gc := NewGarbageCollector(store, 2*time.Hour, 1)
+	gc.clock = fakeClock
+	fakeClock.Advance(time.Date(2024, time.September, 16, 16, 0, 0, 0, time.UTC).Sub(fakeClock.Now()))
+	require.NoError(t, gc.DeleteSCDExpiredRecords(ctx))
+
+	for id, kept := range map[models.ID]bool{sub1ID: true, sub2ID: false, sub3ID: true} {
+		sub, err := r.GetSubscription(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, kept, sub != nil, "subscription %s", id)
+	}
+	oi, err := r.GetOperationalIntent(ctx, oi3ID)
+	require.NoError(t, err)
+	require.NotNil(t, oi)
+	_, err = r.GetConstraint(ctx, oi1ID)
+	require.Error(t, err)
+}
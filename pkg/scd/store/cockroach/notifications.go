@@ -0,0 +1,35 @@
+package cockroach
+
+import (
+	"context"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
+	notificationsc "github.com/interuss/dss/pkg/notifications/cockroach"
+	dsssql "github.com/interuss/dss/pkg/sql"
+)
+
+// EnqueueNotifications implements repos.Notifications.EnqueueNotifications.
+func (r *repo) EnqueueNotifications(ctx context.Context, ns []*notifications.Notification) error {
+	return notificationsc.NewOutbox(r.q).Enqueue(ctx, ns)
+}
+
+func (s *Store) outbox() *notificationsc.Outbox {
+	return notificationsc.NewOutbox(dsssql.WithTracing(s.db.Pool))
+}
+
+// ClaimNotifications implements notifications.Outbox.
+func (s *Store) ClaimNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*notifications.Notification, error) {
+	return s.outbox().ClaimNotifications(ctx, now, lease, limit)
+}
+
+// DeleteNotification implements notifications.Outbox.
+func (s *Store) DeleteNotification(ctx context.Context, id dssmodels.ID) error {
+	return s.outbox().DeleteNotification(ctx, id)
+}
+
+// RescheduleNotification implements notifications.Outbox.
+func (s *Store) RescheduleNotification(ctx context.Context, n *notifications.Notification) error {
+	return s.outbox().RescheduleNotification(ctx, n)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/geo/s2"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
@@ -126,8 +127,18 @@ func (s *repo) fetchOperationalIntents(ctx context.Context, q dsssql.Queryable,
 		}
 	}
 
+	ids := make([]dssmodels.ID, len(payload))
+	for i, op := range payload {
+		ids[i] = op.ID
+	}
+	volumes, err := operationVolumes.fetch(ctx, q, ids)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Operation volumes")
+	}
+
 	for _, op := range payload {
 		op.UssAvailability = ussAvailabilities[op.Manager]
+		op.Volumes = volumes[op.ID]
 	}
 
 	return payload, nil
@@ -196,13 +207,14 @@ func (s *repo) DeleteOperationalIntent(ctx context.Context, id dssmodels.ID) err
 func (s *repo) UpsertOperationalIntent(ctx context.Context, operation *scdmodels.OperationalIntent) (*scdmodels.OperationalIntent, error) {
 	var (
 		upsertOperationsQuery = fmt.Sprintf(`
-			UPSERT INTO
+			INSERT INTO
 				scd_operations
 				(%s)
 			VALUES
 				($1, $2, $3, $4, $5, $6, $7, $8, $9, transaction_timestamp(), $10, $11, $12, $13)
+			%s
 			RETURNING
-				%s`, operationFieldsWithoutPrefix, operationFieldsWithPrefix)
+				%s`, operationFieldsWithoutPrefix, dsssql.OnConflictUpdate("id", operationFieldsWithIndices[:]), operationFieldsWithPrefix)
 	)
 
 	cids := make([]int64, len(operation.Cells))
@@ -236,6 +248,7 @@ func (s *repo) UpsertOperationalIntent(ctx context.Context, operation *scdmodels
 		pastOVNs = append(pastOVNs, pastOVN.String())
 	}
 
+	volumes := operation.Volumes
 	operation, err = s.fetchOperationalIntent(ctx, s.q, upsertOperationsQuery,
 		opid,
 		operation.Manager,
@@ -255,10 +268,15 @@ func (s *repo) UpsertOperationalIntent(ctx context.Context, operation *scdmodels
 		return nil, stacktrace.Propagate(err, "Error fetching Operation")
 	}
 
+	if err := operationVolumes.replace(ctx, s.q, operation.ID, volumes); err != nil {
+		return nil, stacktrace.Propagate(err, "Error storing Operation volumes")
+	}
+	operation.Volumes = volumes
+
 	return operation, nil
 }
 
-func (s *repo) searchOperationalIntents(ctx context.Context, q dsssql.Queryable, v4d *dssmodels.Volume4D) ([]*scdmodels.OperationalIntent, error) {
+func (s *repo) searchOperationalIntents(ctx context.Context, q dsssql.Queryable, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.OperationalIntent, error) {
 	var (
 		operationsIntersectingVolumeQuery = fmt.Sprintf(`
 			SELECT
@@ -275,7 +293,9 @@ func (s *repo) searchOperationalIntents(ctx context.Context, q dsssql.Queryable,
 				COALESCE(scd_operations.ends_at >= $4, true)
 			AND
 				COALESCE(scd_operations.starts_at <= $5, true)
-			LIMIT $6`, operationFieldsWithPrefix)
+			AND
+				%s
+			LIMIT $6`, operationFieldsWithPrefix, operationVolumes.intersectsCondition("scd_operations", 1, 2, 3, 4, 5))
 	)
 
 	if v4d.SpatialVolume == nil || v4d.SpatialVolume.Footprint == nil {
@@ -296,7 +316,7 @@ func (s *repo) searchOperationalIntents(ctx context.Context, q dsssql.Queryable,
 		v4d.SpatialVolume.AltitudeHi,
 		v4d.StartTime,
 		v4d.EndTime,
-		dssmodels.MaxResultLimit,
+		limit,
 	)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Operations")
@@ -306,8 +326,8 @@ func (s *repo) searchOperationalIntents(ctx context.Context, q dsssql.Queryable,
 }
 
 // SearchOperations implements repos.Operation.SearchOperations.
-func (s *repo) SearchOperationalIntents(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.OperationalIntent, error) {
-	return s.searchOperationalIntents(ctx, s.q, v4d)
+func (s *repo) SearchOperationalIntents(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.OperationalIntent, error) {
+	return s.searchOperationalIntents(ctx, s.q, v4d, limit)
 }
 
 // GetDependentOperations implements repos.Operation.GetDependentOperations.
@@ -342,9 +362,9 @@ func (s *repo) GetDependentOperationalIntents(ctx context.Context, subscriptionI
 	return dependentOps, nil
 }
 
-// ListExpiredOperationalIntents lists all operational intents older than the threshold.
+// ListExpiredOperationalIntents lists up to "limit" operational intents older than the threshold.
 // Their age is determined by their end time, or by their last update time if they do not have an end time.
-func (s *repo) ListExpiredOperationalIntents(ctx context.Context, threshold time.Time) ([]*scdmodels.OperationalIntent, error) {
+func (s *repo) ListExpiredOperationalIntents(ctx context.Context, threshold time.Time, limit int) ([]*scdmodels.OperationalIntent, error) {
 	expiredOpIntentsQuery := fmt.Sprintf(`
         SELECT
             %s
@@ -359,7 +379,7 @@ func (s *repo) ListExpiredOperationalIntents(ctx context.Context, threshold time
 	result, err := s.fetchOperationalIntents(
 		ctx, s.q, expiredOpIntentsQuery,
 		threshold,
-		dssmodels.MaxResultLimit,
+		limit,
 	)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Operations")
@@ -367,3 +387,68 @@ func (s *repo) ListExpiredOperationalIntents(ctx context.Context, threshold time
 
 	return result, nil
 }
+
+// CountActiveOperationalIntentsByManager implements repos.OperationalIntent.CountActiveOperationalIntentsByManager.
+func (s *repo) CountActiveOperationalIntentsByManager(ctx context.Context, manager dssmodels.Manager, excluded dssmodels.ID) (int, error) {
+	var query = `
+      SELECT
+        COUNT(*)
+      FROM
+        scd_operations
+      WHERE
+        owner = $1
+      AND
+        COALESCE(ends_at >= $2, true)
+      AND
+        COALESCE(id <> $3, true)`
+
+	uid, err := excludedPgUUID(excluded)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+
+	row := s.q.QueryRow(ctx, query, manager, s.clock.Now(), uid)
+	var ret int
+	err = row.Scan(&ret)
+	return ret, stacktrace.Propagate(err, "Error scanning Operation count row")
+}
+
+// ActiveOperationalIntentCellsByManager implements repos.OperationalIntent.ActiveOperationalIntentCellsByManager.
+func (s *repo) ActiveOperationalIntentCellsByManager(ctx context.Context, manager dssmodels.Manager, excluded dssmodels.ID) (s2.CellUnion, error) {
+	var query = `
+      SELECT DISTINCT
+        unnest(cells)
+      FROM
+        scd_operations
+      WHERE
+        owner = $1
+      AND
+        COALESCE(ends_at >= $2, true)
+      AND
+        COALESCE(id <> $3, true)`
+
+	uid, err := excludedPgUUID(excluded)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+
+	rows, err := s.q.Query(ctx, query, manager, s.clock.Now(), uid)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	var cells s2.CellUnion
+	for rows.Next() {
+		var cid int64
+		if err := rows.Scan(&cid); err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning Operation cell")
+		}
+		cells = append(cells, s2.CellID(cid))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	cells.Normalize()
+	return cells, nil
+}
@@ -128,7 +128,7 @@ func TestListExpiredOperationalIntents(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			threshold := testCase.timeRef.Add(-testCase.ttl)
-			expired, err := r.ListExpiredOperationalIntents(ctx, threshold)
+			expired, err := r.ListExpiredOperationalIntents(ctx, threshold, models.MaxResultLimit)
 			require.NoError(t, err)
 
 			expiredIDs := make([]models.ID, 0, len(expired))
@@ -3,16 +3,18 @@ package cockroach
 import (
 	"context"
 
-	"github.com/cockroachdb/cockroach-go/v2/crdb"
-	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbpgxv5"
 	"github.com/coreos/go-semver/semver"
 	"github.com/interuss/dss/pkg/datastore"
 	"github.com/interuss/dss/pkg/datastore/flags"
+	"github.com/interuss/dss/pkg/metrics"
 	"github.com/interuss/dss/pkg/scd/repos"
 	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/stacktrace"
 	"github.com/jackc/pgx/v5"
 	"github.com/jonboulle/clockwork"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -76,20 +78,27 @@ func (s *Store) CheckCurrentMajorSchemaVersion(ctx context.Context) error {
 // Interact implements store.Interactor interface.
 func (s *Store) Interact(_ context.Context) (repos.Repository, error) {
 	return &repo{
-		q:     s.db.Pool,
+		q:     dsssql.WithTracing(s.db.Pool),
 		clock: s.clock,
 	}, nil
 }
 
 // Transact implements store.Transactor interface.
 func (s *Store) Transact(ctx context.Context, f func(context.Context, repos.Repository) error) error {
-	ctx = crdb.WithMaxRetries(ctx, flags.ConnectParameters().MaxRetries)
-	return crdbpgx.ExecuteTx(ctx, s.db.Pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+	ctx, span := tracing.Start(ctx, "scd.Transact")
+	attempts := 0
+	err := s.db.ExecuteTx(ctx, flags.ConnectParameters().MaxRetries, func(tx pgx.Tx) error {
+		if attempts++; attempts > 1 {
+			metrics.TransactionRetried("scd")
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempts)))
+		}
 		return f(ctx, &repo{
-			q:     tx,
+			q:     dsssql.WithTracing(tx),
 			clock: s.clock,
 		})
 	})
+	tracing.End(span, err)
+	return err
 }
 
 // Close closes the underlying DB connection.
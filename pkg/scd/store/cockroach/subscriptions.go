@@ -10,12 +10,13 @@ import (
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 	dsssql "github.com/interuss/dss/pkg/sql"
 	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/golang/geo/s2"
 )
 
 var (
-	subscriptionFieldsWithIndices   [12]string
+	subscriptionFieldsWithIndices   [15]string
 	subscriptionFieldsWithPrefix    string
 	subscriptionFieldsWithoutPrefix string
 )
@@ -34,12 +35,15 @@ func init() {
 	subscriptionFieldsWithIndices[9] = "ends_at"
 	subscriptionFieldsWithIndices[10] = "cells"
 	subscriptionFieldsWithIndices[11] = "updated_at"
+	subscriptionFieldsWithIndices[12] = "altitude_lower"
+	subscriptionFieldsWithIndices[13] = "altitude_upper"
+	subscriptionFieldsWithIndices[14] = "footprint"
 
 	subscriptionFieldsWithoutPrefix = strings.Join(
 		subscriptionFieldsWithIndices[:], ",",
 	)
 
-	withPrefix := make([]string, 12)
+	withPrefix := make([]string, len(subscriptionFieldsWithIndices))
 	for idx, field := range subscriptionFieldsWithIndices {
 		withPrefix[idx] = "scd_subscriptions." + field
 	}
@@ -102,6 +106,7 @@ func (c *repo) fetchSubscriptions(ctx context.Context, q dsssql.Queryable, query
 			s         = new(scdmodels.Subscription)
 			updatedAt time.Time
 			version   int
+			footprint []byte
 		)
 		err = rows.Scan(
 			&s.ID,
@@ -116,10 +121,17 @@ func (c *repo) fetchSubscriptions(ctx context.Context, q dsssql.Queryable, query
 			&s.EndTime,
 			&cids,
 			&updatedAt,
+			&s.AltitudeLo,
+			&s.AltitudeHi,
+			&footprint,
 		)
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "Error scanning Subscription row")
 		}
+		s.Footprint, err = dssmodels.DecodeFootprint(footprint)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error decoding footprint of Subscription %s", s.ID)
+		}
 		s.Version = scdmodels.NewOVNFromTime(updatedAt, s.ID.String())
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "Error generating Subscription version")
@@ -187,13 +199,14 @@ func (c *repo) pushSubscription(ctx context.Context, q dsssql.Queryable, s *scdm
 			WHERE
 				id = $1
 		)
-		UPSERT INTO
+		INSERT INTO
 		  scd_subscriptions
 		  (%s)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, transaction_timestamp())
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, transaction_timestamp(), $12, $13, $14)
+		%s
 		RETURNING
-			%s`, subscriptionFieldsWithoutPrefix, subscriptionFieldsWithPrefix)
+			%s`, subscriptionFieldsWithoutPrefix, dsssql.OnConflictUpdate("id", subscriptionFieldsWithIndices[:]), subscriptionFieldsWithPrefix)
 	)
 
 	cids := make([]int64, len(s.Cells))
@@ -209,6 +222,10 @@ func (c *repo) pushSubscription(ctx context.Context, q dsssql.Queryable, s *scdm
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
+	footprint, err := dssmodels.EncodeFootprint(s.Footprint)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to encode footprint")
+	}
 	s, err = c.fetchSubscription(ctx, q, upsertQuery,
 		id,
 		s.Manager,
@@ -220,7 +237,10 @@ func (c *repo) pushSubscription(ctx context.Context, q dsssql.Queryable, s *scdm
 		s.ImplicitSubscription,
 		s.StartTime,
 		s.EndTime,
-		cids)
+		cids,
+		s.AltitudeLo,
+		s.AltitudeHi,
+		footprint)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Subscription from upsert query")
 	}
@@ -281,7 +301,7 @@ func (c *repo) DeleteSubscription(ctx context.Context, id dssmodels.ID) error {
 }
 
 // Implements SubscriptionStore.SearchSubscriptions
-func (c *repo) SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.Subscription, error) {
+func (c *repo) SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.Subscription, error) {
 	var (
 		query = fmt.Sprintf(`
 			SELECT
@@ -294,6 +314,10 @@ func (c *repo) SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D)
 					COALESCE(starts_at <= $3, true)
 				AND
 					COALESCE(ends_at >= $2, true)
+				AND
+					COALESCE(altitude_upper >= $5, true)
+				AND
+					COALESCE(altitude_lower <= $6, true)
 				LIMIT $4`, subscriptionFieldsWithPrefix)
 	)
 
@@ -309,7 +333,8 @@ func (c *repo) SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D)
 	}
 
 	subscriptions, err := c.fetchSubscriptions(
-		ctx, c.q, query, dsssql.CellUnionToCellIds(cells), v4d.StartTime, v4d.EndTime, dssmodels.MaxResultLimit)
+		ctx, c.q, query, dsssql.CellUnionToCellIds(cells), v4d.StartTime, v4d.EndTime, limit,
+		v4d.SpatialVolume.AltitudeLo, v4d.SpatialVolume.AltitudeHi)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Unable to fetch Subscriptions")
 	}
@@ -378,24 +403,31 @@ func (c *repo) LockSubscriptionsOnCells(ctx context.Context, cells s2.CellUnion)
 	return nil
 }
 
-// ListExpiredSubscriptions lists all subscriptions older than the threshold.
+// ListExpiredSubscriptions lists up to "limit" subscriptions older than the threshold.
 // Their age is determined by their end time, or by their update time if they do not have an end time.
-func (c *repo) ListExpiredSubscriptions(ctx context.Context, threshold time.Time) ([]*scdmodels.Subscription, error) {
+// Subscriptions still referenced by an operational intent are not listed, as
+// deleting them would cascade to the operational intent.
+func (c *repo) ListExpiredSubscriptions(ctx context.Context, threshold time.Time, limit int) ([]*scdmodels.Subscription, error) {
 	expiredSubsQuery := fmt.Sprintf(`
         SELECT
             %s
         FROM
             scd_subscriptions
         WHERE
-            scd_subscriptions.ends_at IS NOT NULL AND scd_subscriptions.ends_at <= $1
-            OR
-            scd_subscriptions.ends_at IS NULL AND scd_subscriptions.updated_at <= $1 -- use last update time as reference if there is no end time
+            (
+                scd_subscriptions.ends_at IS NOT NULL AND scd_subscriptions.ends_at <= $1
+                OR
+                scd_subscriptions.ends_at IS NULL AND scd_subscriptions.updated_at <= $1 -- use last update time as reference if there is no end time
+            )
+            AND NOT EXISTS (
+                SELECT 1 FROM scd_operations WHERE scd_operations.subscription_id = scd_subscriptions.id
+            )
         LIMIT $2`, subscriptionFieldsWithPrefix)
 
 	subscriptions, err := c.fetchSubscriptions(
 		ctx, c.q, expiredSubsQuery,
 		threshold,
-		dssmodels.MaxResultLimit,
+		limit,
 	)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Unable to fetch Subscriptions")
@@ -404,3 +436,45 @@ func (c *repo) ListExpiredSubscriptions(ctx context.Context, threshold time.Time
 	return subscriptions, nil
 
 }
+
+// MaxSubscriptionCountInCellsByManager counts how many active subscriptions
+// the manager has in each one of these cells, and returns the number of
+// subscriptions in the cell with the highest number of subscriptions.
+func (c *repo) MaxSubscriptionCountInCellsByManager(ctx context.Context, cells s2.CellUnion, manager dssmodels.Manager, excluded dssmodels.ID) (int, error) {
+	var query = `
+    SELECT
+      COALESCE(MAX(subscriptions_per_cell_id), 0)
+    FROM (
+      SELECT
+        COUNT(*) AS subscriptions_per_cell_id
+      FROM (
+      	SELECT unnest(cells) as cell_id
+      	FROM scd_subscriptions
+      	WHERE owner = $1
+      		AND COALESCE(ends_at >= $2, true)
+      		AND COALESCE(id <> $4, true)
+      ) AS subscription_cells
+      WHERE
+        cell_id = ANY($3)
+      GROUP BY cell_id
+    ) AS counts`
+
+	uid, err := excludedPgUUID(excluded)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+
+	row := c.q.QueryRow(ctx, query, manager, c.clock.Now(), dsssql.CellUnionToCellIds(cells), uid)
+	var ret int
+	err = row.Scan(&ret)
+	return ret, stacktrace.Propagate(err, "Error scanning subscription count row")
+}
+
+// excludedPgUUID converts the ID of an entity excluded from a query to a
+// pgtype.UUID, nil if no entity is excluded.
+func excludedPgUUID(excluded dssmodels.ID) (*pgtype.UUID, error) {
+	if excluded == "" {
+		return nil, nil
+	}
+	return excluded.PgUUID()
+}
@@ -0,0 +1,128 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/interuss/dss/pkg/geo"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+)
+
+// volumesTable identifies the table holding the individual volumes of the
+// entities of a table.
+type volumesTable struct {
+	// name is the name of the volumes table.
+	name string
+	// entityColumn is the column of the volumes table referencing the entity.
+	entityColumn string
+}
+
+var (
+	operationVolumes  = volumesTable{name: "scd_operation_volumes", entityColumn: "operation_id"}
+	constraintVolumes = volumesTable{name: "scd_constraint_volumes", entityColumn: "constraint_id"}
+)
+
+// intersectsCondition returns a SQL condition true for the rows of
+// entityTable with a volume intersecting the cells $cells, the altitudes
+// [$altitudeLo, $altitudeHi] and the time range [$startTime, $endTime], or
+// with no volume at all since they predate the storage of individual volumes
+// and are only known by the union of their volumes.
+func (t volumesTable) intersectsCondition(entityTable string, cells, altitudeLo, altitudeHi, startTime, endTime int) string {
+	return fmt.Sprintf(`(
+				NOT EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.%[2]s = %[3]s.id)
+				OR EXISTS (
+					SELECT 1 FROM %[1]s AS v
+					WHERE v.%[2]s = %[3]s.id
+					AND v.cells && $%[4]d
+					AND COALESCE(v.altitude_upper >= $%[5]d, true)
+					AND COALESCE(v.altitude_lower <= $%[6]d, true)
+					AND COALESCE(v.ends_at >= $%[7]d, true)
+					AND COALESCE(v.starts_at <= $%[8]d, true)))`,
+		t.name, t.entityColumn, entityTable, cells, altitudeLo, altitudeHi, startTime, endTime)
+}
+
+// fetch returns the volumes of the entities identified by ids, by entity ID.
+func (t volumesTable) fetch(ctx context.Context, q dsssql.Queryable, ids []dssmodels.ID) (map[dssmodels.ID][]scdmodels.Volume, error) {
+	result := map[dssmodels.ID][]scdmodels.Volume{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			%[1]s, altitude_lower, altitude_upper, starts_at, ends_at, cells, footprint
+		FROM
+			%[2]s
+		WHERE
+			%[1]s = ANY($1)
+		ORDER BY
+			%[1]s, volume_index`, t.entityColumn, t.name)
+
+	uids := make([]string, len(ids))
+	for i, id := range ids {
+		uids[i] = id.String()
+	}
+	rows, err := q.Query(ctx, query, uids)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id        dssmodels.ID
+			v         scdmodels.Volume
+			cids      []int64
+			footprint []byte
+		)
+		if err := rows.Scan(&id, &v.AltitudeLower, &v.AltitudeUpper, &v.StartTime, &v.EndTime, &cids, &footprint); err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning volume row")
+		}
+		v.Cells = geo.CellUnionFromInt64(cids)
+		v.Footprint, err = dssmodels.DecodeFootprint(footprint)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error decoding footprint of a volume of %s", id)
+		}
+		result[id] = append(result[id], v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return result, nil
+}
+
+// replace replaces the volumes of the entity identified by id with volumes.
+func (t volumesTable) replace(ctx context.Context, q dsssql.Queryable, id dssmodels.ID, volumes []scdmodels.Volume) error {
+	deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE %s = $1`, t.name, t.entityColumn)
+	insertQuery := fmt.Sprintf(`
+		INSERT INTO
+			%s
+			(%s, volume_index, altitude_lower, altitude_upper, starts_at, ends_at, cells, footprint)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)`, t.name, t.entityColumn)
+
+	uid, err := id.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if _, err := q.Exec(ctx, deleteQuery, uid); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", deleteQuery)
+	}
+	for i, v := range volumes {
+		cids, err := dsssql.CellUnionToCellIdsWithValidation(v.Cells)
+		if err != nil {
+			return stacktrace.Propagate(err, "Failed to convert cells of volume %d", i)
+		}
+		footprint, err := dssmodels.EncodeFootprint(v.Footprint)
+		if err != nil {
+			return stacktrace.Propagate(err, "Failed to encode footprint of volume %d", i)
+		}
+		if _, err := q.Exec(ctx, insertQuery, uid, i, v.AltitudeLower, v.AltitudeUpper, v.StartTime, v.EndTime, cids, footprint); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", insertQuery)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/interuss/dss/pkg/audit"
+)
+
+// AppendAuditRecord implements repos.Audit.AppendAuditRecord.
+func (r *repo) AppendAuditRecord(ctx context.Context, rec *audit.Record) error {
+	return r.view(func(t *tables, now time.Time) error {
+		t.audit.Append(now, rec)
+		return nil
+	})
+}
+
+// QueryAuditRecords implements audit.Log.
+func (s *Store) QueryAuditRecords(ctx context.Context, filter audit.Filter) ([]*audit.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.audit.Query(filter), nil
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// availabilityRecord holds the persisted fields of a USS availability.
+type availabilityRecord struct {
+	availability scdmodels.UssAvailabilityState
+	updatedAt    time.Time
+}
+
+func (r *availabilityRecord) toModel(uss dssmodels.Manager) *scdmodels.UssAvailabilityStatus {
+	return &scdmodels.UssAvailabilityStatus{
+		Uss:          uss,
+		Availability: r.availability,
+		Version:      scdmodels.NewOVNFromTime(r.updatedAt, uss.String()),
+	}
+}
+
+// Implements repos.UssAvailability.UpsertAvailability
+func (u *repo) UpsertUssAvailability(ctx context.Context, s *scdmodels.UssAvailabilityStatus) (*scdmodels.UssAvailabilityStatus, error) {
+	var result *scdmodels.UssAvailabilityStatus
+	err := u.view(func(t *tables, now time.Time) error {
+		r := &availabilityRecord{availability: s.Availability, updatedAt: now}
+		t.availabilities[s.Uss] = r
+		result = r.toModel(s.Uss)
+		return nil
+	})
+	return result, err
+}
+
+// GetUssAvailability returns the Availability status identified by "ussID".
+func (u *repo) GetUssAvailability(ctx context.Context, ussID dssmodels.Manager) (*scdmodels.UssAvailabilityStatus, error) {
+	var result *scdmodels.UssAvailabilityStatus
+	err := u.view(func(t *tables, _ time.Time) error {
+		r, ok := t.availabilities[ussID]
+		if !ok {
+			return pgx.ErrNoRows
+		}
+		result = r.toModel(ussID)
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/interuss/dss/pkg/geo"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgx/v5"
+)
+
+// constraintRecord holds the persisted fields of a constraint.
+type constraintRecord struct {
+	constraint scdmodels.Constraint
+	updatedAt  time.Time
+}
+
+func newConstraintRecord(c *scdmodels.Constraint, updatedAt time.Time) *constraintRecord {
+	return &constraintRecord{
+		constraint: scdmodels.Constraint{
+			ID:            c.ID,
+			Manager:       c.Manager,
+			Version:       c.Version,
+			StartTime:     copyTime(c.StartTime),
+			EndTime:       copyTime(c.EndTime),
+			USSBaseURL:    c.USSBaseURL,
+			AltitudeLower: copyFloat(c.AltitudeLower),
+			AltitudeUpper: copyFloat(c.AltitudeUpper),
+			Cells:         copyCells(c.Cells),
+			Volumes:       copyVolumes(c.Volumes),
+		},
+		updatedAt: updatedAt,
+	}
+}
+
+func (r *constraintRecord) clone() *constraintRecord {
+	return newConstraintRecord(&r.constraint, r.updatedAt)
+}
+
+// toModel returns a copy of the constraint held by r.
+func (r *constraintRecord) toModel() *scdmodels.Constraint {
+	c := r.clone().constraint
+	c.OVN = scdmodels.NewOVNFromTime(r.updatedAt, c.ID.String())
+	return &c
+}
+
+// Implements scd.repos.Constraint.GetConstraint
+func (c *repo) GetConstraint(ctx context.Context, id dssmodels.ID) (*scdmodels.Constraint, error) {
+	var result *scdmodels.Constraint
+	err := c.view(func(t *tables, _ time.Time) error {
+		r, ok := t.constraints[id]
+		if !ok {
+			return pgx.ErrNoRows
+		}
+		result = r.toModel()
+		return nil
+	})
+	return result, err
+}
+
+// Implements scd.repos.Constraint.UpsertConstraint
+func (c *repo) UpsertConstraint(ctx context.Context, s *scdmodels.Constraint) (*scdmodels.Constraint, error) {
+	for _, cell := range s.Cells {
+		if err := geo.ValidateCell(cell); err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to validate cells")
+		}
+	}
+
+	var result *scdmodels.Constraint
+	err := c.view(func(t *tables, now time.Time) error {
+		r := newConstraintRecord(s, now)
+		t.constraints[s.ID] = r
+		result = r.toModel()
+		return nil
+	})
+	return result, err
+}
+
+// Implements scd.repos.Constraint.DeleteConstraint
+func (c *repo) DeleteConstraint(ctx context.Context, id dssmodels.ID) error {
+	return c.view(func(t *tables, _ time.Time) error {
+		if _, ok := t.constraints[id]; !ok {
+			return pgx.ErrNoRows
+		}
+		delete(t.constraints, id)
+		return nil
+	})
+}
+
+// ListExpiredConstraints lists up to "limit" constraints older than the threshold.
+// Their age is determined by their end time, or by their last update time if they do not have an end time.
+func (c *repo) ListExpiredConstraints(ctx context.Context, threshold time.Time, limit int) ([]*scdmodels.Constraint, error) {
+	var result []*scdmodels.Constraint
+	err := c.view(func(t *tables, _ time.Time) error {
+		for _, r := range t.constraints {
+			reference := r.updatedAt
+			if r.constraint.EndTime != nil {
+				reference = *r.constraint.EndTime
+			}
+			if !reference.After(threshold) && len(result) < limit {
+				result = append(result, r.toModel())
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Implements scd.repos.Constraint.SearchConstraints
+func (c *repo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.Constraint, error) {
+	cells, err := v4d.CalculateSpatialCovering()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Could not calculate spatial covering")
+	}
+
+	result := []*scdmodels.Constraint{}
+	if len(cells) == 0 {
+		return result, nil
+	}
+
+	err = c.view(func(t *tables, _ time.Time) error {
+		var records []*constraintRecord
+		for _, r := range t.constraints {
+			if overlaps(r.constraint.Cells, cells) &&
+				notAfter(r.constraint.StartTime, v4d.EndTime) &&
+				notAfter(v4d.StartTime, r.constraint.EndTime) &&
+				notAbove(v4d.SpatialVolume.AltitudeLo, r.constraint.AltitudeUpper) &&
+				notAbove(r.constraint.AltitudeLower, v4d.SpatialVolume.AltitudeHi) &&
+				scdmodels.IntersectsAny(r.constraint.Volumes, v4d, cells) {
+				records = append(records, r)
+			}
+		}
+		sort.Slice(records, func(i, j int) bool { return records[i].constraint.ID < records[j].constraint.ID })
+		if len(records) > limit {
+			records = records[:limit]
+		}
+		for _, r := range records {
+			result = append(result, r.toModel())
+		}
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+)
+
+func copyDSSReport(report *scdmodels.DSSReport) *scdmodels.DSSReport {
+	c := *report
+	c.Report = append(c.Report[:0:0], report.Report...)
+	return &c
+}
+
+// InsertDSSReport implements repos.DSSReport.InsertDSSReport.
+func (r *repo) InsertDSSReport(ctx context.Context, report *scdmodels.DSSReport) (*scdmodels.DSSReport, error) {
+	var result *scdmodels.DSSReport
+	err := r.view(func(t *tables, now time.Time) error {
+		stored := copyDSSReport(report)
+		stored.ReceivedAt = now
+		t.dssReports[stored.ID] = stored
+		result = copyDSSReport(stored)
+		return nil
+	})
+	return result, err
+}
+
+// GetDSSReport implements repos.DSSReport.GetDSSReport.
+func (r *repo) GetDSSReport(ctx context.Context, id dssmodels.ID) (*scdmodels.DSSReport, error) {
+	var result *scdmodels.DSSReport
+	err := r.view(func(t *tables, _ time.Time) error {
+		if report, ok := t.dssReports[id]; ok {
+			result = copyDSSReport(report)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// ListDSSReports implements repos.DSSReport.ListDSSReports.
+func (r *repo) ListDSSReports(ctx context.Context, reporter dssmodels.Manager, earliest *time.Time, latest *time.Time, limit int) ([]*scdmodels.DSSReport, error) {
+	var result []*scdmodels.DSSReport
+	err := r.view(func(t *tables, _ time.Time) error {
+		for _, report := range t.dssReports {
+			switch {
+			case reporter != "" && report.Reporter != reporter:
+				continue
+			case earliest != nil && report.ReceivedAt.Before(*earliest):
+				continue
+			case latest != nil && report.ReceivedAt.After(*latest):
+				continue
+			}
+			result = append(result, copyDSSReport(report))
+		}
+		return nil
+	})
+	sort.Slice(result, func(i, j int) bool { return result[i].ReceivedAt.After(result[j].ReceivedAt) })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, err
+}
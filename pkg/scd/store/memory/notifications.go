@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/notifications"
+)
+
+// EnqueueNotifications implements repos.Notifications.EnqueueNotifications.
+func (r *repo) EnqueueNotifications(ctx context.Context, ns []*notifications.Notification) error {
+	return r.view(func(t *tables, now time.Time) error {
+		t.notifications.Enqueue(now, ns)
+		return nil
+	})
+}
+
+// ClaimNotifications implements notifications.Outbox.
+func (s *Store) ClaimNotifications(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*notifications.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.notifications.Claim(now, lease, limit), nil
+}
+
+// DeleteNotification implements notifications.Outbox.
+func (s *Store) DeleteNotification(ctx context.Context, id dssmodels.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.notifications.Delete(id)
+	return nil
+}
+
+// RescheduleNotification implements notifications.Outbox.
+func (s *Store) RescheduleNotification(ctx context.Context, n *notifications.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.notifications.Reschedule(n)
+	return nil
+}
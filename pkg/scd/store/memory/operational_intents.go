@@ -0,0 +1,217 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/golang/geo/s2"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+)
+
+// operationalIntentRecord holds the persisted fields of an operational intent.
+type operationalIntentRecord struct {
+	oi scdmodels.OperationalIntent
+	// ussRequestedOVN is the OVN requested by the managing USS, if any.
+	ussRequestedOVN scdmodels.OVN
+	updatedAt       time.Time
+}
+
+func newOperationalIntentRecord(o *scdmodels.OperationalIntent, updatedAt time.Time) *operationalIntentRecord {
+	var subscriptionID *dssmodels.ID
+	if o.SubscriptionID != nil {
+		id := *o.SubscriptionID
+		subscriptionID = &id
+	}
+	return &operationalIntentRecord{
+		oi: scdmodels.OperationalIntent{
+			ID:             o.ID,
+			Manager:        o.Manager,
+			Version:        o.Version,
+			State:          o.State,
+			PastOVNs:       append([]scdmodels.OVN{}, o.PastOVNs...),
+			StartTime:      copyTime(o.StartTime),
+			EndTime:        copyTime(o.EndTime),
+			USSBaseURL:     o.USSBaseURL,
+			SubscriptionID: subscriptionID,
+			AltitudeLower:  copyFloat(o.AltitudeLower),
+			AltitudeUpper:  copyFloat(o.AltitudeUpper),
+			Cells:          copyCells(o.Cells),
+			Volumes:        copyVolumes(o.Volumes),
+		},
+		ussRequestedOVN: o.OVN,
+		updatedAt:       updatedAt,
+	}
+}
+
+func (r *operationalIntentRecord) clone() *operationalIntentRecord {
+	c := newOperationalIntentRecord(&r.oi, r.updatedAt)
+	c.ussRequestedOVN = r.ussRequestedOVN
+	return c
+}
+
+// toModel returns a copy of the operational intent held by r, without its USS
+// availability.
+func (r *operationalIntentRecord) toModel() *scdmodels.OperationalIntent {
+	o := newOperationalIntentRecord(&r.oi, r.updatedAt).oi
+	o.OVN = r.ussRequestedOVN
+	// If the managing USS has not requested a specific OVN, a default
+	// DSS-generated OVN based on the last update time is used.
+	if o.OVN == "" {
+		o.OVN = scdmodels.NewOVNFromTime(r.updatedAt, o.ID.String())
+	}
+	return &o
+}
+
+// operationalIntents returns copies of records ordered by ID, limited to
+// limit entries and with their USS availability set.
+func operationalIntents(t *tables, records []*operationalIntentRecord, limit int) []*scdmodels.OperationalIntent {
+	sort.Slice(records, func(i, j int) bool { return records[i].oi.ID < records[j].oi.ID })
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	result := make([]*scdmodels.OperationalIntent, len(records))
+	for i, r := range records {
+		result[i] = r.toModel()
+		result[i].UssAvailability = scdmodels.UssAvailabilityStateUnknown
+		if a, ok := t.availabilities[r.oi.Manager]; ok {
+			result[i].UssAvailability = a.availability
+		}
+	}
+	return result
+}
+
+// GetOperationalIntent implements repos.OperationalIntent.GetOperationalIntent.
+func (s *repo) GetOperationalIntent(ctx context.Context, id dssmodels.ID) (*scdmodels.OperationalIntent, error) {
+	var result *scdmodels.OperationalIntent
+	err := s.view(func(t *tables, _ time.Time) error {
+		if r, ok := t.operationalIntents[id]; ok {
+			result = operationalIntents(t, []*operationalIntentRecord{r}, 1)[0]
+		}
+		return nil
+	})
+	return result, err
+}
+
+// DeleteOperationalIntent implements repos.OperationalIntent.DeleteOperationalIntent.
+func (s *repo) DeleteOperationalIntent(ctx context.Context, id dssmodels.ID) error {
+	return s.view(func(t *tables, _ time.Time) error {
+		if _, ok := t.operationalIntents[id]; !ok {
+			return stacktrace.NewError("Could not delete Operation that does not exist")
+		}
+		delete(t.operationalIntents, id)
+		return nil
+	})
+}
+
+// UpsertOperationalIntent implements repos.OperationalIntent.UpsertOperationalIntent.
+func (s *repo) UpsertOperationalIntent(ctx context.Context, operation *scdmodels.OperationalIntent) (*scdmodels.OperationalIntent, error) {
+	var result *scdmodels.OperationalIntent
+	err := s.view(func(t *tables, now time.Time) error {
+		r := newOperationalIntentRecord(operation, now)
+		t.operationalIntents[operation.ID] = r
+		result = operationalIntents(t, []*operationalIntentRecord{r}, 1)[0]
+		return nil
+	})
+	return result, err
+}
+
+// SearchOperationalIntents implements repos.OperationalIntent.SearchOperationalIntents.
+func (s *repo) SearchOperationalIntents(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.OperationalIntent, error) {
+	if v4d.SpatialVolume == nil || v4d.SpatialVolume.Footprint == nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing geospatial footprint for query")
+	}
+	cells, err := v4d.SpatialVolume.Footprint.CalculateCovering()
+	if err != nil {
+		return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Failed to calculate footprint covering")
+	}
+	if len(cells) == 0 {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing cell IDs for query")
+	}
+
+	var result []*scdmodels.OperationalIntent
+	err = s.view(func(t *tables, _ time.Time) error {
+		var records []*operationalIntentRecord
+		for _, r := range t.operationalIntents {
+			if overlaps(r.oi.Cells, cells) &&
+				notAbove(v4d.SpatialVolume.AltitudeLo, r.oi.AltitudeUpper) &&
+				notAbove(r.oi.AltitudeLower, v4d.SpatialVolume.AltitudeHi) &&
+				notAfter(v4d.StartTime, r.oi.EndTime) &&
+				notAfter(r.oi.StartTime, v4d.EndTime) &&
+				scdmodels.IntersectsAny(r.oi.Volumes, v4d, cells) {
+				records = append(records, r)
+			}
+		}
+		result = operationalIntents(t, records, limit)
+		return nil
+	})
+	return result, err
+}
+
+// GetDependentOperationalIntents implements repos.OperationalIntent.GetDependentOperationalIntents.
+func (s *repo) GetDependentOperationalIntents(ctx context.Context, subscriptionID dssmodels.ID) ([]dssmodels.ID, error) {
+	var dependentOps []dssmodels.ID
+	err := s.view(func(t *tables, _ time.Time) error {
+		for id, r := range t.operationalIntents {
+			if r.oi.SubscriptionID != nil && *r.oi.SubscriptionID == subscriptionID {
+				dependentOps = append(dependentOps, id)
+			}
+		}
+		return nil
+	})
+	sort.Slice(dependentOps, func(i, j int) bool { return dependentOps[i] < dependentOps[j] })
+	return dependentOps, err
+}
+
+// ListExpiredOperationalIntents lists up to "limit" operational intents older than the threshold.
+// Their age is determined by their end time, or by their last update time if they do not have an end time.
+func (s *repo) ListExpiredOperationalIntents(ctx context.Context, threshold time.Time, limit int) ([]*scdmodels.OperationalIntent, error) {
+	var result []*scdmodels.OperationalIntent
+	err := s.view(func(t *tables, _ time.Time) error {
+		var records []*operationalIntentRecord
+		for _, r := range t.operationalIntents {
+			reference := r.updatedAt
+			if r.oi.EndTime != nil {
+				reference = *r.oi.EndTime
+			}
+			if !reference.After(threshold) && len(records) < limit {
+				records = append(records, r)
+			}
+		}
+		result = operationalIntents(t, records, limit)
+		return nil
+	})
+	return result, err
+}
+
+// CountActiveOperationalIntentsByManager implements repos.OperationalIntent.CountActiveOperationalIntentsByManager.
+func (s *repo) CountActiveOperationalIntentsByManager(ctx context.Context, manager dssmodels.Manager, excluded dssmodels.ID) (int, error) {
+	count := 0
+	err := s.view(func(t *tables, now time.Time) error {
+		for id, r := range t.operationalIntents {
+			if id != excluded && r.oi.Manager == manager && notAfter(&now, r.oi.EndTime) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// ActiveOperationalIntentCellsByManager implements repos.OperationalIntent.ActiveOperationalIntentCellsByManager.
+func (s *repo) ActiveOperationalIntentCellsByManager(ctx context.Context, manager dssmodels.Manager, excluded dssmodels.ID) (s2.CellUnion, error) {
+	var cells s2.CellUnion
+	err := s.view(func(t *tables, now time.Time) error {
+		for id, r := range t.operationalIntents {
+			if id != excluded && r.oi.Manager == manager && notAfter(&now, r.oi.EndTime) {
+				cells = append(cells, r.oi.Cells...)
+			}
+		}
+		return nil
+	})
+	cells.Normalize()
+	return cells, err
+}
@@ -0,0 +1,235 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/s2"
+	auditm "github.com/interuss/dss/pkg/audit/memory"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	notificationsm "github.com/interuss/dss/pkg/notifications/memory"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/jonboulle/clockwork"
+)
+
+var (
+	// DefaultClock is what is used as the Store's clock.
+	DefaultClock = clockwork.NewRealClock()
+)
+
+// tables holds the content of the store.
+type tables struct {
+	operationalIntents map[dssmodels.ID]*operationalIntentRecord
+	subscriptions      map[dssmodels.ID]*subscriptionRecord
+	constraints        map[dssmodels.ID]*constraintRecord
+	availabilities     map[dssmodels.Manager]*availabilityRecord
+	dssReports         map[dssmodels.ID]*scdmodels.DSSReport
+	notifications      notificationsm.Queue
+	audit              auditm.Trail
+}
+
+func newTables() *tables {
+	return &tables{
+		operationalIntents: map[dssmodels.ID]*operationalIntentRecord{},
+		subscriptions:      map[dssmodels.ID]*subscriptionRecord{},
+		constraints:        map[dssmodels.ID]*constraintRecord{},
+		availabilities:     map[dssmodels.Manager]*availabilityRecord{},
+		dssReports:         map[dssmodels.ID]*scdmodels.DSSReport{},
+	}
+}
+
+// clone returns a deep copy of t.
+func (t *tables) clone() *tables {
+	c := newTables()
+	for id, r := range t.operationalIntents {
+		c.operationalIntents[id] = r.clone()
+	}
+	for id, r := range t.subscriptions {
+		c.subscriptions[id] = r.clone()
+	}
+	for id, r := range t.constraints {
+		c.constraints[id] = r.clone()
+	}
+	for id, r := range t.availabilities {
+		rc := *r
+		c.availabilities[id] = &rc
+	}
+	for id, report := range t.dssReports {
+		c.dssReports[id] = copyDSSReport(report)
+	}
+	c.notifications = t.notifications.Clone()
+	c.audit = t.audit.Clone()
+	return c
+}
+
+// repo is an implementation of repos.Repo keeping its data in memory.
+type repo struct {
+	store *Store
+	clock clockwork.Clock
+
+	// tx holds the tables of the ongoing transaction, nil outside of a
+	// transaction.
+	tx *tables
+	// now is the timestamp of the ongoing transaction.
+	now time.Time
+}
+
+// Store is an implementation of an scd.Store keeping its data in memory.
+//
+// Transactions are serialized: a transaction holds the store lock for its
+// whole duration, operates on a copy of the data and only publishes its
+// changes when it succeeds. This gives the same isolation guarantees as the
+// SELECT ... FOR UPDATE statements used by the SQL-backed stores.
+type Store struct {
+	mu    sync.Mutex
+	data  *tables
+	clock clockwork.Clock
+}
+
+// NewStore returns an empty Store instance.
+func NewStore() *Store {
+	return &Store{
+		data:  newTables(),
+		clock: DefaultClock,
+	}
+}
+
+// Interact implements store.Interactor interface.
+func (s *Store) Interact(_ context.Context) (repos.Repository, error) {
+	return &repo{
+		store: s,
+		clock: s.clock,
+	}, nil
+}
+
+// Transact implements store.Transactor interface. Changes are discarded if f
+// returns an error or panics.
+func (s *Store) Transact(ctx context.Context, f func(context.Context, repos.Repository) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := &repo{
+		store: s,
+		clock: s.clock,
+		tx:    s.data.clone(),
+		now:   timestamp(s.clock),
+	}
+	if err := f(ctx, r); err != nil {
+		return err
+	}
+	s.data = r.tx
+	return nil
+}
+
+// Close drops the data held by the store.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = newTables()
+	return nil
+}
+
+// view runs f against the tables visible to r, locking the store when r is
+// not part of a transaction. f receives the time to use as the statement
+// timestamp.
+func (r *repo) view(f func(t *tables, now time.Time) error) error {
+	if r.tx != nil {
+		return f(r.tx, r.now)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return f(r.store.data, timestamp(r.clock))
+}
+
+// timestamp returns the current time of clock with the precision of a
+// database timestamp.
+func timestamp(clock clockwork.Clock) time.Time {
+	return clock.Now().UTC().Truncate(time.Microsecond)
+}
+
+// overlaps returns true if a and b share at least one cell, like the && array
+// operator does.
+func overlaps(a, b s2.CellUnion) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	set := make(map[s2.CellID]struct{}, len(a))
+	for _, c := range a {
+		set[c] = struct{}{}
+	}
+	for _, c := range b {
+		if _, ok := set[c]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// notAfter returns false only if both a and b are set and a is after b,
+// mirroring COALESCE(a <= b, true).
+func notAfter(a, b *time.Time) bool {
+	return a == nil || b == nil || !a.After(*b)
+}
+
+// notAbove returns false only if both a and b are set and a is above b,
+// mirroring COALESCE(a <= b, true).
+func notAbove(a, b *float32) bool {
+	return a == nil || b == nil || *a <= *b
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func copyFloat(f *float32) *float32 {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	return &c
+}
+
+func copyVolumes(volumes []scdmodels.Volume) []scdmodels.Volume {
+	if volumes == nil {
+		return nil
+	}
+	c := make([]scdmodels.Volume, len(volumes))
+	for i, v := range volumes {
+		c[i] = scdmodels.Volume{
+			StartTime:     copyTime(v.StartTime),
+			EndTime:       copyTime(v.EndTime),
+			AltitudeLower: copyFloat(v.AltitudeLower),
+			AltitudeUpper: copyFloat(v.AltitudeUpper),
+			Cells:         copyCells(v.Cells),
+			Footprint:     copyFootprint(v.Footprint),
+		}
+	}
+	return c
+}
+
+func copyCells(cells s2.CellUnion) s2.CellUnion {
+	if cells == nil {
+		return nil
+	}
+	return append(s2.CellUnion{}, cells...)
+}
+
+// copyFootprint returns a copy of footprint as the SQL-backed stores persist
+// it, i.e. nil for footprints that have no compact form.
+func copyFootprint(footprint dssmodels.Geometry) dssmodels.Geometry {
+	data, err := dssmodels.EncodeFootprint(footprint)
+	if err != nil {
+		return nil
+	}
+	result, err := dssmodels.DecodeFootprint(data)
+	if err != nil {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,494 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/interuss/dss/pkg/audit"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/dss/pkg/scd/store"
+	"github.com/jackc/pgx/v5"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// Ensure the struct conforms to the interface
+	_ store.Store = &Store{}
+
+	oiID  = dssmodels.ID("00000185-e36d-40be-8d38-beca6ca30000")
+	subID = dssmodels.ID("78ea3fe8-71c2-4f5c-9b44-9c02f5563c6f")
+
+	cells = s2.CellUnion{
+		s2.CellID(int64(8768904281496485888)),
+		s2.CellID(int64(8768904178417270784)),
+	}
+
+	start                   = time.Date(2024, time.August, 14, 15, 48, 36, 0, time.UTC)
+	end                     = start.Add(time.Hour)
+	altLow, altHigh float32 = 84, 169
+)
+
+func setUpStore() (*Store, clockwork.FakeClock) {
+	clock := clockwork.NewFakeClockAt(start)
+	s := NewStore()
+	s.clock = clock
+	return s, clock
+}
+
+func newOperationalIntent() *scdmodels.OperationalIntent {
+	return &scdmodels.OperationalIntent{
+		ID:             oiID,
+		Manager:        "unittest",
+		Version:        1,
+		State:          scdmodels.OperationalIntentStateAccepted,
+		StartTime:      &start,
+		EndTime:        &end,
+		USSBaseURL:     "https://dummy.uss",
+		SubscriptionID: &subID,
+		AltitudeLower:  &altLow,
+		AltitudeUpper:  &altHigh,
+		Cells:          cells,
+	}
+}
+
+func TestTransactRollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setUpStore()
+
+	errAbort := errors.New("abort")
+	err := s.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+		_, err := r.UpsertOperationalIntent(ctx, newOperationalIntent())
+		require.NoError(t, err)
+		return errAbort
+	})
+	require.Equal(t, errAbort, err)
+
+	r, err := s.Interact(ctx)
+	require.NoError(t, err)
+	oi, err := r.GetOperationalIntent(ctx, oiID)
+	require.NoError(t, err)
+	require.Nil(t, oi)
+
+	require.Panics(t, func() {
+		_ = s.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			_, err := r.UpsertOperationalIntent(ctx, newOperationalIntent())
+			require.NoError(t, err)
+			panic("abort")
+		})
+	})
+	oi, err = r.GetOperationalIntent(ctx, oiID)
+	require.NoError(t, err)
+	require.Nil(t, oi)
+}
+
+func TestOperationalIntentOVNAndAvailability(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setUpStore()
+	r, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	oi, err := r.UpsertOperationalIntent(ctx, newOperationalIntent())
+	require.NoError(t, err)
+	require.Equal(t, scdmodels.NewOVNFromTime(clock.Now(), oiID.String()), oi.OVN)
+	require.Equal(t, scdmodels.UssAvailabilityStateUnknown, oi.UssAvailability)
+
+	_, err = r.UpsertUssAvailability(ctx, &scdmodels.UssAvailabilityStatus{
+		Uss:          "unittest",
+		Availability: scdmodels.UssAvailabilityStateDown,
+	})
+	require.NoError(t, err)
+
+	requested := newOperationalIntent()
+	requested.OVN = "requested"
+	_, err = r.UpsertOperationalIntent(ctx, requested)
+	require.NoError(t, err)
+
+	oi, err = r.GetOperationalIntent(ctx, oiID)
+	require.NoError(t, err)
+	require.Equal(t, scdmodels.OVN("requested"), oi.OVN)
+	require.Equal(t, scdmodels.UssAvailabilityStateDown, oi.UssAvailability)
+
+	_, err = r.GetUssAvailability(ctx, "unknown")
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestSearchOperationalIntents(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setUpStore()
+	r, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	_, err = r.UpsertOperationalIntent(ctx, newOperationalIntent())
+	require.NoError(t, err)
+
+	var (
+		geometry   = dssmodels.GeometryFunc(func() (s2.CellUnion, error) { return cells[:1], nil })
+		above      = altHigh + 1
+		afterEnd   = end.Add(time.Minute)
+		beforeTime = start.Add(-time.Minute)
+	)
+	for _, tc := range []struct {
+		name  string
+		v4d   *dssmodels.Volume4D
+		found int
+	}{
+		{"matching", &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: geometry}}, 1},
+		{"above", &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: geometry, AltitudeLo: &above}}, 0},
+		{"later", &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: geometry}, StartTime: &afterEnd}, 0},
+		{"earlier", &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: geometry}, EndTime: &beforeTime}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ois, err := r.SearchOperationalIntents(ctx, tc.v4d, dssmodels.MaxResultLimit)
+			require.NoError(t, err)
+			require.Len(t, ois, tc.found)
+		})
+	}
+
+	_, err = r.SearchOperationalIntents(ctx, &dssmodels.Volume4D{}, dssmodels.MaxResultLimit)
+	require.Error(t, err)
+}
+
+func TestSearchOperationalIntentsPerVolume(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setUpStore()
+	r, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	// The operational intent climbs from the first cell at low altitude during
+	// the first half hour to the second cell at high altitude afterwards.
+	var (
+		middle         = start.Add(30 * time.Minute)
+		midAlt float32 = (altLow + altHigh) / 2
+		oi             = newOperationalIntent()
+	)
+	oi.Volumes = []scdmodels.Volume{
+		{StartTime: &start, EndTime: &middle, AltitudeLower: &altLow, AltitudeUpper: &midAlt, Cells: cells[:1]},
+		{StartTime: &middle, EndTime: &end, AltitudeLower: &midAlt, AltitudeUpper: &altHigh, Cells: cells[1:]},
+	}
+	_, err = r.UpsertOperationalIntent(ctx, oi)
+	require.NoError(t, err)
+
+	stored, err := r.GetOperationalIntent(ctx, oiID)
+	require.NoError(t, err)
+	require.Equal(t, oi.Volumes, stored.Volumes)
+
+	var (
+		first     = dssmodels.GeometryFunc(func() (s2.CellUnion, error) { return cells[:1], nil })
+		second    = dssmodels.GeometryFunc(func() (s2.CellUnion, error) { return cells[1:], nil })
+		high      = midAlt + 1
+		lateStart = middle.Add(time.Minute)
+		earlyEnd  = middle.Add(-time.Minute)
+	)
+	for _, tc := range []struct {
+		name  string
+		v4d   *dssmodels.Volume4D
+		found int
+	}{
+		{"first volume", &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: first}, EndTime: &earlyEnd}, 1},
+		{"second volume", &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: second, AltitudeLo: &high}}, 1},
+		{"first cell later", &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: first}, StartTime: &lateStart}, 0},
+		{"first cell higher", &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: first, AltitudeLo: &high}}, 0},
+		{"second cell earlier", &dssmodels.Volume4D{SpatialVolume: &dssmodels.Volume3D{Footprint: second}, EndTime: &earlyEnd}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ois, err := r.SearchOperationalIntents(ctx, tc.v4d, dssmodels.MaxResultLimit)
+			require.NoError(t, err)
+			require.Len(t, ois, tc.found)
+		})
+	}
+}
+
+func TestReferencedSubscriptionsDoNotExpire(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setUpStore()
+	r, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	_, err = r.UpsertSubscription(ctx, &scdmodels.Subscription{
+		ID:                   subID,
+		Manager:              "unittest",
+		StartTime:            &start,
+		EndTime:              &end,
+		ImplicitSubscription: true,
+		Cells:                cells,
+	})
+	require.NoError(t, err)
+	oi := newOperationalIntent()
+	oi.SubscriptionID = &subID
+	_, err = r.UpsertOperationalIntent(ctx, oi)
+	require.NoError(t, err)
+
+	expired, err := r.ListExpiredSubscriptions(ctx, end, dssmodels.MaxResultLimit)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+
+	require.NoError(t, r.DeleteOperationalIntent(ctx, oiID))
+	expired, err = r.ListExpiredSubscriptions(ctx, end, dssmodels.MaxResultLimit)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	expired, err = r.ListExpiredSubscriptions(ctx, end, 0)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+}
+
+func TestSubscriptionsAndConstraints(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setUpStore()
+	r, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	sub, err := r.UpsertSubscription(ctx, &scdmodels.Subscription{
+		ID:         subID,
+		Manager:    "unittest",
+		StartTime:  &start,
+		EndTime:    &end,
+		AltitudeHi: &altHigh,
+		Cells:      cells,
+	})
+	require.NoError(t, err)
+	require.Equal(t, altHigh, *sub.AltitudeHi)
+	require.Nil(t, sub.AltitudeLo)
+	require.Equal(t, scdmodels.NewOVNFromTime(clock.Now(), subID.String()), sub.Version)
+
+	indices, err := r.IncrementNotificationIndices(ctx, []dssmodels.ID{subID})
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, indices)
+	_, err = r.IncrementNotificationIndices(ctx, []dssmodels.ID{oiID})
+	require.Error(t, err)
+
+	expired, err := r.ListExpiredSubscriptions(ctx, end, dssmodels.MaxResultLimit)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	require.NoError(t, r.DeleteSubscription(ctx, subID))
+	require.Error(t, r.DeleteSubscription(ctx, subID))
+
+	_, err = r.GetConstraint(ctx, oiID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = r.UpsertConstraint(ctx, &scdmodels.Constraint{
+		ID:        oiID,
+		Manager:   "unittest",
+		StartTime: &start,
+		EndTime:   &end,
+		Cells:     cells,
+	})
+	require.NoError(t, err)
+	constraints, err := r.SearchConstraints(ctx, &dssmodels.Volume4D{
+		SpatialVolume: &dssmodels.Volume3D{
+			Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) { return cells, nil }),
+		},
+	}, dssmodels.MaxResultLimit)
+	require.NoError(t, err)
+	require.Len(t, constraints, 1)
+	expiredConstraints, err := r.ListExpiredConstraints(ctx, end, dssmodels.MaxResultLimit)
+	require.NoError(t, err)
+	require.Len(t, expiredConstraints, 1)
+	expiredConstraints, err = r.ListExpiredConstraints(ctx, start, dssmodels.MaxResultLimit)
+	require.NoError(t, err)
+	require.Empty(t, expiredConstraints)
+	require.NoError(t, r.DeleteConstraint(ctx, oiID))
+	require.ErrorIs(t, r.DeleteConstraint(ctx, oiID), pgx.ErrNoRows)
+}
+
+func TestAltitudeAwareSearches(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setUpStore()
+	r, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	_, err = r.UpsertSubscription(ctx, &scdmodels.Subscription{
+		ID:         subID,
+		Manager:    "unittest",
+		StartTime:  &start,
+		EndTime:    &end,
+		AltitudeLo: &altLow,
+		AltitudeHi: &altHigh,
+		Cells:      cells,
+	})
+	require.NoError(t, err)
+	_, err = r.UpsertConstraint(ctx, &scdmodels.Constraint{
+		ID:            oiID,
+		Manager:       "unittest",
+		StartTime:     &start,
+		EndTime:       &end,
+		AltitudeLower: &altLow,
+		AltitudeUpper: &altHigh,
+		Cells:         cells,
+	})
+	require.NoError(t, err)
+
+	var (
+		geometry         = dssmodels.GeometryFunc(func() (s2.CellUnion, error) { return cells[:1], nil })
+		above            = altHigh + 1
+		below            = altLow - 1
+		inside   float32 = (altLow + altHigh) / 2
+	)
+	for _, tc := range []struct {
+		name  string
+		v3d   *dssmodels.Volume3D
+		found int
+	}{
+		{"unbounded", &dssmodels.Volume3D{Footprint: geometry}, 1},
+		{"overlapping", &dssmodels.Volume3D{Footprint: geometry, AltitudeLo: &inside, AltitudeHi: &above}, 1},
+		{"above", &dssmodels.Volume3D{Footprint: geometry, AltitudeLo: &above}, 0},
+		{"below", &dssmodels.Volume3D{Footprint: geometry, AltitudeHi: &below}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			subs, err := r.SearchSubscriptions(ctx, &dssmodels.Volume4D{SpatialVolume: tc.v3d}, dssmodels.MaxResultLimit)
+			require.NoError(t, err)
+			require.Len(t, subs, tc.found)
+			constraints, err := r.SearchConstraints(ctx, &dssmodels.Volume4D{SpatialVolume: tc.v3d}, dssmodels.MaxResultLimit)
+			require.NoError(t, err)
+			require.Len(t, constraints, tc.found)
+		})
+	}
+}
+
+func TestManagerCounts(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setUpStore()
+	r, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	_, err = r.UpsertOperationalIntent(ctx, newOperationalIntent())
+	require.NoError(t, err)
+	_, err = r.UpsertSubscription(ctx, &scdmodels.Subscription{
+		ID:        subID,
+		Manager:   "unittest",
+		StartTime: &start,
+		EndTime:   &end,
+		Cells:     cells,
+	})
+	require.NoError(t, err)
+
+	active, err := r.CountActiveOperationalIntentsByManager(ctx, "unittest", "")
+	require.NoError(t, err)
+	require.Equal(t, 1, active)
+	active, err = r.CountActiveOperationalIntentsByManager(ctx, "unittest", oiID)
+	require.NoError(t, err)
+	require.Equal(t, 0, active)
+	active, err = r.CountActiveOperationalIntentsByManager(ctx, "other", "")
+	require.NoError(t, err)
+	require.Equal(t, 0, active)
+	covered, err := r.ActiveOperationalIntentCellsByManager(ctx, "unittest", "")
+	require.NoError(t, err)
+	require.ElementsMatch(t, cells, covered)
+	covered, err = r.ActiveOperationalIntentCellsByManager(ctx, "unittest", oiID)
+	require.NoError(t, err)
+	require.Empty(t, covered)
+
+	count, err := r.MaxSubscriptionCountInCellsByManager(ctx, cells[:1], "unittest", "")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	count, err = r.MaxSubscriptionCountInCellsByManager(ctx, cells[:1], "unittest", subID)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	clock.Advance(2 * time.Hour)
+	active, err = r.CountActiveOperationalIntentsByManager(ctx, "unittest", "")
+	require.NoError(t, err)
+	require.Equal(t, 0, active)
+	covered, err = r.ActiveOperationalIntentCellsByManager(ctx, "unittest", "")
+	require.NoError(t, err)
+	require.Empty(t, covered)
+	count, err = r.MaxSubscriptionCountInCellsByManager(ctx, cells, "unittest", "")
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func TestAuditTrail(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setUpStore()
+
+	appendRecord := func(manager string, commit bool) {
+		err := s.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			require.NoError(t, r.AppendAuditRecord(ctx, audit.NewRecord(audit.EntityOperationalIntent, oiID, manager, "", "ovn", cells)))
+			if !commit {
+				return errors.New("abort")
+			}
+			return nil
+		})
+		require.Equal(t, commit, err == nil)
+	}
+	appendRecord("uss1", true)
+	clock.Advance(time.Minute)
+	appendRecord("uss2", false)
+	appendRecord("uss2", true)
+
+	all, err := s.QueryAuditRecords(ctx, audit.Filter{})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, "uss1", all[0].Manager)
+	require.Equal(t, audit.OperationCreate, all[0].Operation)
+	require.Equal(t, start, all[0].RecordedAt)
+	require.Equal(t, cells, all[0].Cells)
+
+	byManager, err := s.QueryAuditRecords(ctx, audit.Filter{Manager: "uss2"})
+	require.NoError(t, err)
+	require.Len(t, byManager, 1)
+	require.Equal(t, start.Add(time.Minute), byManager[0].RecordedAt)
+
+	byTime, err := s.QueryAuditRecords(ctx, audit.Filter{EntityID: oiID, To: start.Add(time.Second)})
+	require.NoError(t, err)
+	require.Len(t, byTime, 1)
+	require.Equal(t, "uss1", byTime[0].Manager)
+}
+
+func TestDSSReports(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setUpStore()
+
+	insert := func(id dssmodels.ID, reporter dssmodels.Manager) {
+		err := s.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			_, err := r.InsertDSSReport(ctx, &scdmodels.DSSReport{ID: id, Reporter: reporter, Report: []byte(`{"problem":"none"}`)})
+			return err
+		})
+		require.NoError(t, err)
+		clock.Advance(time.Minute)
+	}
+	firstID := dssmodels.ID("11111111-1111-4111-8111-111111111111")
+	secondID := dssmodels.ID("22222222-2222-4222-8222-222222222222")
+	insert(firstID, "uss1")
+	insert(secondID, "uss2")
+
+	r, err := s.Interact(ctx)
+	require.NoError(t, err)
+
+	report, err := r.GetDSSReport(ctx, firstID)
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	require.Equal(t, dssmodels.Manager("uss1"), report.Reporter)
+	require.Equal(t, start, report.ReceivedAt)
+	require.JSONEq(t, `{"problem":"none"}`, string(report.Report))
+
+	missing, err := r.GetDSSReport(ctx, oiID)
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	all, err := r.ListDSSReports(ctx, "", nil, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, secondID, all[0].ID)
+	require.Equal(t, firstID, all[1].ID)
+
+	byReporter, err := r.ListDSSReports(ctx, "uss1", nil, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, byReporter, 1)
+	require.Equal(t, firstID, byReporter[0].ID)
+
+	earliest := start.Add(time.Second)
+	byTime, err := r.ListDSSReports(ctx, "", &earliest, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, byTime, 1)
+	require.Equal(t, secondID, byTime[0].ID)
+
+	limited, err := r.ListDSSReports(ctx, "", nil, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, limited, 1)
+	require.Equal(t, secondID, limited[0].ID)
+}
@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/golang/geo/s2"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+)
+
+// subscriptionRecord holds the persisted fields of a subscription.
+type subscriptionRecord struct {
+	sub       scdmodels.Subscription
+	updatedAt time.Time
+}
+
+func newSubscriptionRecord(s *scdmodels.Subscription, updatedAt time.Time) *subscriptionRecord {
+	return &subscriptionRecord{
+		sub: scdmodels.Subscription{
+			ID:                          s.ID,
+			NotificationIndex:           s.NotificationIndex,
+			Manager:                     s.Manager,
+			StartTime:                   copyTime(s.StartTime),
+			EndTime:                     copyTime(s.EndTime),
+			AltitudeLo:                  copyFloat(s.AltitudeLo),
+			AltitudeHi:                  copyFloat(s.AltitudeHi),
+			USSBaseURL:                  s.USSBaseURL,
+			NotifyForOperationalIntents: s.NotifyForOperationalIntents,
+			NotifyForConstraints:        s.NotifyForConstraints,
+			ImplicitSubscription:        s.ImplicitSubscription,
+			Cells:                       copyCells(s.Cells),
+			Footprint:                   copyFootprint(s.Footprint),
+		},
+		updatedAt: updatedAt,
+	}
+}
+
+func (r *subscriptionRecord) clone() *subscriptionRecord {
+	return newSubscriptionRecord(&r.sub, r.updatedAt)
+}
+
+// toModel returns a copy of the subscription held by r.
+func (r *subscriptionRecord) toModel() *scdmodels.Subscription {
+	s := r.clone().sub
+	s.Version = scdmodels.NewOVNFromTime(r.updatedAt, s.ID.String())
+	return &s
+}
+
+// subscriptions returns copies of records ordered by ID, limited to
+// limit entries.
+func subscriptions(records []*subscriptionRecord, limit int) []*scdmodels.Subscription {
+	sort.Slice(records, func(i, j int) bool { return records[i].sub.ID < records[j].sub.ID })
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	result := make([]*scdmodels.Subscription, len(records))
+	for i, r := range records {
+		result[i] = r.toModel()
+	}
+	return result
+}
+
+// GetSubscription returns the subscription identified by "id".
+func (c *repo) GetSubscription(ctx context.Context, id dssmodels.ID) (*scdmodels.Subscription, error) {
+	var result *scdmodels.Subscription
+	err := c.view(func(t *tables, _ time.Time) error {
+		if r, ok := t.subscriptions[id]; ok {
+			result = r.toModel()
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Implements repos.Subscription.UpsertSubscription
+func (c *repo) UpsertSubscription(ctx context.Context, s *scdmodels.Subscription) (*scdmodels.Subscription, error) {
+	var result *scdmodels.Subscription
+	err := c.view(func(t *tables, now time.Time) error {
+		r := newSubscriptionRecord(s, now)
+		t.subscriptions[s.ID] = r
+		result = r.toModel()
+		return nil
+	})
+	return result, err
+}
+
+// DeleteSubscription deletes the subscription identified by "id".
+func (c *repo) DeleteSubscription(ctx context.Context, id dssmodels.ID) error {
+	return c.view(func(t *tables, _ time.Time) error {
+		if _, ok := t.subscriptions[id]; !ok {
+			return stacktrace.NewError("Attempted to delete non-existent Subscription")
+		}
+		delete(t.subscriptions, id)
+		return nil
+	})
+}
+
+// Implements SubscriptionStore.SearchSubscriptions
+func (c *repo) SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D, limit int) ([]*scdmodels.Subscription, error) {
+	cells, err := v4d.CalculateSpatialCovering()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Could not calculate spatial covering")
+	}
+	if len(cells) == 0 {
+		return nil, nil
+	}
+
+	var result []*scdmodels.Subscription
+	err = c.view(func(t *tables, _ time.Time) error {
+		var records []*subscriptionRecord
+		for _, r := range t.subscriptions {
+			if overlaps(r.sub.Cells, cells) &&
+				notAfter(r.sub.StartTime, v4d.EndTime) &&
+				notAfter(v4d.StartTime, r.sub.EndTime) &&
+				notAbove(v4d.SpatialVolume.AltitudeLo, r.sub.AltitudeHi) &&
+				notAbove(r.sub.AltitudeLo, v4d.SpatialVolume.AltitudeHi) {
+				records = append(records, r)
+			}
+		}
+		result = subscriptions(records, limit)
+		return nil
+	})
+	return result, err
+}
+
+// Implements scd.repos.Subscription.IncrementNotificationIndices
+func (c *repo) IncrementNotificationIndices(ctx context.Context, subscriptionIds []dssmodels.ID) ([]int, error) {
+	var indices []int
+	err := c.view(func(t *tables, _ time.Time) error {
+		for _, id := range subscriptionIds {
+			r, ok := t.subscriptions[id]
+			if !ok {
+				continue
+			}
+			r.sub.NotificationIndex++
+			indices = append(indices, r.sub.NotificationIndex)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(indices) != len(subscriptionIds) {
+		return nil, stacktrace.NewError(
+			"Expected %d notification_index results when incrementing but got %d instead",
+			len(subscriptionIds), len(indices))
+	}
+
+	return indices, nil
+}
+
+// LockSubscriptionsOnCells is a no-op: transactions already hold an exclusive
+// lock on the whole store.
+func (c *repo) LockSubscriptionsOnCells(ctx context.Context, cells s2.CellUnion) error {
+	return nil
+}
+
+// ListExpiredSubscriptions lists up to "limit" subscriptions older than the threshold.
+// Their age is determined by their end time, or by their update time if they do not have an end time.
+// Subscriptions still referenced by an operational intent are not listed.
+func (c *repo) ListExpiredSubscriptions(ctx context.Context, threshold time.Time, limit int) ([]*scdmodels.Subscription, error) {
+	var result []*scdmodels.Subscription
+	err := c.view(func(t *tables, _ time.Time) error {
+		referenced := map[dssmodels.ID]bool{}
+		for _, r := range t.operationalIntents {
+			if r.oi.SubscriptionID != nil {
+				referenced[*r.oi.SubscriptionID] = true
+			}
+		}
+
+		var records []*subscriptionRecord
+		for id, r := range t.subscriptions {
+			reference := r.updatedAt
+			if r.sub.EndTime != nil {
+				reference = *r.sub.EndTime
+			}
+			if !reference.After(threshold) && !referenced[id] && len(records) < limit {
+				records = append(records, r)
+			}
+		}
+		result = subscriptions(records, limit)
+		return nil
+	})
+	return result, err
+}
+
+// MaxSubscriptionCountInCellsByManager counts how many active subscriptions
+// the manager has in each one of these cells, and returns the number of
+// subscriptions in the cell with the highest number of subscriptions.
+func (c *repo) MaxSubscriptionCountInCellsByManager(ctx context.Context, cells s2.CellUnion, manager dssmodels.Manager, excluded dssmodels.ID) (int, error) {
+	wanted := make(map[s2.CellID]struct{}, len(cells))
+	for _, cell := range cells {
+		wanted[cell] = struct{}{}
+	}
+
+	max := 0
+	err := c.view(func(t *tables, now time.Time) error {
+		counts := map[s2.CellID]int{}
+		for id, r := range t.subscriptions {
+			if id == excluded || r.sub.Manager != manager || !notAfter(&now, r.sub.EndTime) {
+				continue
+			}
+			for _, cell := range r.sub.Cells {
+				if _, ok := wanted[cell]; !ok {
+					continue
+				}
+				counts[cell]++
+				if counts[cell] > max {
+					max = counts[cell]
+				}
+			}
+		}
+		return nil
+	})
+	return max, err
+}
@@ -7,11 +7,13 @@ import (
 	"github.com/golang/geo/s2"
 	"github.com/interuss/dss/pkg/api"
 	restapi "github.com/interuss/dss/pkg/api/scdv1"
+	"github.com/interuss/dss/pkg/audit"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	"github.com/interuss/dss/pkg/geo"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/stacktrace"
 	"github.com/jonboulle/clockwork"
 )
@@ -22,6 +24,9 @@ var (
 
 func (a *Server) CreateSubscription(ctx context.Context, req *restapi.CreateSubscriptionRequest,
 ) restapi.CreateSubscriptionResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.CreateSubscription")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.CreateSubscriptionResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -48,6 +53,8 @@ func (a *Server) CreateSubscription(ctx context.Context, req *restapi.CreateSubs
 			return restapi.CreateSubscriptionResponseSet{Response409: errResp}
 		case dsserr.BadRequest, dsserr.NotFound:
 			return restapi.CreateSubscriptionResponseSet{Response400: errResp}
+		case dsserr.Exhausted:
+			return restapi.CreateSubscriptionResponseSet{Response429: errResp}
 		default:
 			return restapi.CreateSubscriptionResponseSet{Response500: &api.InternalServerErrorBody{
 				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
@@ -59,6 +66,9 @@ func (a *Server) CreateSubscription(ctx context.Context, req *restapi.CreateSubs
 
 func (a *Server) UpdateSubscription(ctx context.Context, req *restapi.UpdateSubscriptionRequest,
 ) restapi.UpdateSubscriptionResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.UpdateSubscription")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.UpdateSubscriptionResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -85,6 +95,8 @@ func (a *Server) UpdateSubscription(ctx context.Context, req *restapi.UpdateSubs
 			return restapi.UpdateSubscriptionResponseSet{Response409: errResp}
 		case dsserr.BadRequest, dsserr.NotFound:
 			return restapi.UpdateSubscriptionResponseSet{Response400: errResp}
+		case dsserr.Exhausted:
+			return restapi.UpdateSubscriptionResponseSet{Response429: errResp}
 		default:
 			return restapi.UpdateSubscriptionResponseSet{Response500: &api.InternalServerErrorBody{
 				ErrorMessage: *dsserr.Handle(ctx, stacktrace.Propagate(err, "Got an unexpected error"))}}
@@ -97,6 +109,9 @@ func (a *Server) UpdateSubscription(ctx context.Context, req *restapi.UpdateSubs
 // PutSubscription creates a single subscription.
 func (a *Server) PutSubscription(ctx context.Context, manager string, subscriptionid restapi.SubscriptionID, version string, params *restapi.PutSubscriptionParameters,
 ) (*restapi.PutSubscriptionResponse, error) {
+	ctx, span := tracing.Start(ctx, "scd.PutSubscription")
+	defer span.End()
+
 	// Retrieve Subscription ID
 	id, err := dssmodels.IDFromString(string(subscriptionid))
 
@@ -136,6 +151,7 @@ func (a *Server) PutSubscription(ctx context.Context, manager string, subscripti
 		AltitudeLo: extents.SpatialVolume.AltitudeLo,
 		AltitudeHi: extents.SpatialVolume.AltitudeHi,
 		Cells:      cells,
+		Footprint:  extents.SpatialVolume.Footprint,
 
 		USSBaseURL: string(params.UssBaseUrl),
 	}
@@ -209,6 +225,15 @@ func (a *Server) PutSubscription(ctx context.Context, manager string, subscripti
 			}
 		}
 
+		// Enforce the quota of the manager in the cells of the Subscription
+		quotaCells := subreq.Cells
+		if len(quotaCells) == 0 && old != nil {
+			quotaCells = old.Cells
+		}
+		if err := a.Quotas.For(subreq.Manager).checkSubscription(ctx, r, subreq.Manager, subreq.ID, quotaCells); err != nil {
+			return stacktrace.Propagate(err, "Subscription exceeds the quota of manager")
+		}
+
 		// Store Subscription model
 		sub, err := r.UpsertSubscription(ctx, subreq)
 		if err != nil {
@@ -217,6 +242,13 @@ func (a *Server) PutSubscription(ctx context.Context, manager string, subscripti
 		if sub == nil {
 			return stacktrace.NewError("UpsertSubscription returned no Subscription for ID: %s", id)
 		}
+		var oldVersion string
+		if old != nil {
+			oldVersion = old.Version.String()
+		}
+		if err := auditChange(ctx, r, audit.EntitySCDSubscription, sub.ID, sub.Manager, oldVersion, sub.Version.String(), sub.Cells); err != nil {
+			return err
+		}
 
 		// Find relevant Operations
 		var relevantOperations []*scdmodels.OperationalIntent
@@ -231,10 +263,13 @@ func (a *Server) PutSubscription(ctx context.Context, manager string, subscripti
 						return sub.Cells, nil
 					}),
 				},
-			})
+			}, dssmodels.MaxResultLimit+1)
 			if err != nil {
 				return stacktrace.Propagate(err, "Could not search Operations in repo")
 			}
+			if err := dssmodels.CheckCompleteResults(ops, "OperationalIntents"); err != nil {
+				return stacktrace.Propagate(err, "Could not find all relevant Operations")
+			}
 			relevantOperations = ops
 		}
 
@@ -262,10 +297,13 @@ func (a *Server) PutSubscription(ctx context.Context, manager string, subscripti
 
 		if sub.NotifyForConstraints {
 			// Query relevant Constraints
-			constraints, err := r.SearchConstraints(ctx, extents)
+			constraints, err := r.SearchConstraints(ctx, extents, dssmodels.MaxResultLimit+1)
 			if err != nil {
 				return stacktrace.Propagate(err, "Could not search Constraints in repo")
 			}
+			if err := dssmodels.CheckCompleteResults(constraints, "Constraints"); err != nil {
+				return stacktrace.Propagate(err, "Could not find all relevant Constraints")
+			}
 
 			// Attach Constraints to response
 			constraintRefs := make([]restapi.ConstraintReference, 0, len(constraints))
@@ -296,6 +334,9 @@ func (a *Server) PutSubscription(ctx context.Context, manager string, subscripti
 // GetSubscription returns a single subscription for the given ID.
 func (a *Server) GetSubscription(ctx context.Context, req *restapi.GetSubscriptionRequest,
 ) restapi.GetSubscriptionResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.GetSubscription")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.GetSubscriptionResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -376,6 +417,9 @@ func (a *Server) GetSubscription(ctx context.Context, req *restapi.GetSubscripti
 // QuerySubscriptions queries existing subscriptions in the given bounds.
 func (a *Server) QuerySubscriptions(ctx context.Context, req *restapi.QuerySubscriptionsRequest,
 ) restapi.QuerySubscriptionsResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.QuerySubscriptions")
+	defer span.End()
+
 	nowMarker := time.Now()
 	if req.Auth.Error != nil {
 		resp := restapi.QuerySubscriptionsResponseSet{}
@@ -411,10 +455,11 @@ func (a *Server) QuerySubscriptions(ctx context.Context, req *restapi.QuerySubsc
 	var response *restapi.QuerySubscriptionsResponse
 	action := func(ctx context.Context, r repos.Repository) (err error) {
 		// Perform search query on Store
-		subs, err := r.SearchSubscriptions(ctx, vol4)
+		subs, err := r.SearchSubscriptions(ctx, vol4, a.resultLimit()+1)
 		if err != nil {
 			return stacktrace.Propagate(err, "Error searching Subscriptions in repo")
 		}
+		subs = api.TruncateResults(ctx, subs, a.resultLimit())
 
 		// Return response to client
 		response = &restapi.QuerySubscriptionsResponse{
@@ -466,6 +511,9 @@ func (a *Server) QuerySubscriptions(ctx context.Context, req *restapi.QuerySubsc
 // DeleteSubscription deletes a single subscription for a given ID.
 func (a *Server) DeleteSubscription(ctx context.Context, req *restapi.DeleteSubscriptionRequest,
 ) restapi.DeleteSubscriptionResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.DeleteSubscription")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.DeleteSubscriptionResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -525,6 +573,9 @@ func (a *Server) DeleteSubscription(ctx context.Context, req *restapi.DeleteSubs
 		if err != nil {
 			return stacktrace.Propagate(err, "Could not delete Subscription from repo")
 		}
+		if err := auditChange(ctx, r, audit.EntitySCDSubscription, old.ID, old.Manager, old.Version.String(), "", old.Cells); err != nil {
+			return err
+		}
 
 		// Convert deleted Subscription to REST
 		p, err := old.ToRest(dependentOps)
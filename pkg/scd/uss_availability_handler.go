@@ -10,6 +10,7 @@ import (
 	dssmodels "github.com/interuss/dss/pkg/models"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/dss/pkg/tracing"
 	"github.com/interuss/stacktrace"
 	"github.com/jackc/pgx/v5"
 )
@@ -25,6 +26,9 @@ func GetDefaultAvailabilityResponse(id dssmodels.Manager) *restapi.UssAvailabili
 
 func (a *Server) GetUssAvailability(ctx context.Context, req *restapi.GetUssAvailabilityRequest,
 ) restapi.GetUssAvailabilityResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.GetUssAvailability")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.GetUssAvailabilityResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -74,6 +78,9 @@ func (a *Server) GetUssAvailability(ctx context.Context, req *restapi.GetUssAvai
 
 func (a *Server) SetUssAvailability(ctx context.Context, req *restapi.SetUssAvailabilityRequest,
 ) restapi.SetUssAvailabilityResponseSet {
+	ctx, span := tracing.Start(ctx, "scd.SetUssAvailability")
+	defer span.End()
+
 	if req.Auth.Error != nil {
 		resp := restapi.SetUssAvailabilityResponseSet{}
 		setAuthError(ctx, stacktrace.Propagate(req.Auth.Error, "Auth failed"), &resp.Response401, &resp.Response403, &resp.Response500)
@@ -0,0 +1,71 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/interuss/dss/pkg/tracing"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedQueryable is a Queryable creating a span for every statement it
+// issues.
+type tracedQueryable struct {
+	next Queryable
+}
+
+// WithTracing returns a Queryable issuing its statements through q, each of
+// them in its own span.
+func WithTracing(q Queryable) Queryable {
+	return &tracedQueryable{next: q}
+}
+
+func startStatement(ctx context.Context, operation string, query string) (context.Context, func(error)) {
+	ctx, span := tracing.Start(ctx, "sql."+operation, attribute.String("db.query.text", query))
+	return ctx, func(err error) { tracing.End(span, err) }
+}
+
+// tracedRows ends the span of its query when closed.
+type tracedRows struct {
+	pgx.Rows
+	end  func(error)
+	done bool
+}
+
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	if !r.done {
+		r.done = true
+		r.end(r.Rows.Err())
+	}
+}
+
+// Query implements Queryable. The span ends when the returned rows are closed.
+func (t *tracedQueryable) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	ctx, end := startStatement(ctx, "Query", query)
+	rows, err := t.next.Query(ctx, query, args...)
+	if err != nil {
+		end(err)
+		return nil, err
+	}
+	return &tracedRows{Rows: rows, end: end}, nil
+}
+
+// QueryRow implements Queryable. The span ends once the query returns, so
+// that it does not depend on the row being scanned; errors are only reported
+// by the Scan of the returned row and are not recorded in the span.
+func (t *tracedQueryable) QueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	ctx, end := startStatement(ctx, "QueryRow", query)
+	row := t.next.QueryRow(ctx, query, args...)
+	end(nil)
+	return row
+}
+
+// Exec implements Queryable.
+func (t *tracedQueryable) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, end := startStatement(ctx, "Exec", query)
+	tag, err := t.next.Exec(ctx, query, args...)
+	end(err)
+	return tag, err
+}
@@ -1,6 +1,9 @@
 package sql
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/interuss/dss/pkg/geo"
 	"github.com/interuss/stacktrace"
 
@@ -33,3 +36,16 @@ func ForUpdate(forUpdate bool) string {
 	}
 	return ""
 }
+
+// OnConflictUpdate returns the clause turning an INSERT of fields into an
+// upsert on key. Unlike UPSERT, it is supported by PostgreSQL as well as by
+// CockroachDB.
+func OnConflictUpdate(key string, fields []string) string {
+	assignments := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field != key {
+			assignments = append(assignments, fmt.Sprintf("%[1]s = excluded.%[1]s", field))
+		}
+	}
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", key, strings.Join(assignments, ", "))
+}
@@ -0,0 +1,4 @@
+// Package tls bundles up the TLS configuration of the servers of the DSS, whose
+// certificate and trusted client certificate authorities are reloaded from
+// their files on rotation.
+package tls
@@ -0,0 +1,193 @@
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/interuss/stacktrace"
+	"go.uber.org/zap"
+)
+
+// Configuration bundles up the files configuring a TLS server.
+type Configuration struct {
+	// CertFile and KeyFile are the paths of the PEM files holding the
+	// certificate chain and private key of the server.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile, if set, is the path of the PEM file holding the
+	// certificate authorities verifying client certificates. Clients may then
+	// present a certificate, which is rejected if it cannot be verified.
+	ClientCAFile string
+	// RequireClientCert rejects the clients which do not present a
+	// certificate; ClientCAFile must be set.
+	RequireClientCert bool
+	// ReloadInterval is the period at which the files are checked for
+	// modifications, DefaultReloadInterval if 0.
+	ReloadInterval time.Duration
+}
+
+// DefaultReloadInterval is the default period at which the files of a
+// Configuration are checked for modifications.
+const DefaultReloadInterval = time.Minute
+
+// reloader holds the TLS configuration built from the files of a
+// Configuration, and rebuilds it when any of these files is modified.
+type reloader struct {
+	configuration Configuration
+	logger        *zap.Logger
+
+	config atomic.Pointer[tls.Config]
+	// modTimes is only accessed by the goroutine checking the files.
+	modTimes []time.Time
+}
+
+// NewServerConfig returns the TLS configuration of a server according to
+// configuration. The files are checked every configuration.ReloadInterval
+// until ctx is done, and the certificate and client certificate authorities
+// are read again following a modification of their files, so that they can
+// be rotated without restarting the server; the previous ones are kept in use
+// as long as the modified files cannot be loaded.
+func NewServerConfig(ctx context.Context, configuration Configuration, logger *zap.Logger) (*tls.Config, error) {
+	r, err := newReloader(configuration, logger)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	interval := configuration.ReloadInterval
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.check()
+			}
+		}
+	}()
+
+	return r.serverConfig(), nil
+}
+
+// newReloader returns a reloader holding the configuration built from the
+// files of configuration.
+func newReloader(configuration Configuration, logger *zap.Logger) (*reloader, error) {
+	if configuration.CertFile == "" || configuration.KeyFile == "" {
+		return nil, stacktrace.NewError("Both a certificate file and a key file are required to serve TLS")
+	}
+	if configuration.RequireClientCert && configuration.ClientCAFile == "" {
+		return nil, stacktrace.NewError("A client certificate authorities file is required to require client certificates")
+	}
+
+	r := &reloader{configuration: configuration, logger: logger}
+	if err := r.reload(); err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	return r, nil
+}
+
+// nextProtos are the application protocols negotiated by the configurations,
+// HTTP/2 being preferred as it is by net/http. They are set on each
+// configuration returned by GetConfigForClient, which would otherwise only
+// negotiate HTTP/1.1.
+var nextProtos = []string{"h2", "http/1.1"}
+
+// serverConfig returns the TLS configuration of a server using the latest
+// configuration built by r.
+func (r *reloader) serverConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.config.Load(), nil
+		},
+	}
+}
+
+// files returns the paths of the files the configuration is built from.
+func (r *reloader) files() []string {
+	files := []string{r.configuration.CertFile, r.configuration.KeyFile}
+	if r.configuration.ClientCAFile != "" {
+		files = append(files, r.configuration.ClientCAFile)
+	}
+	return files
+}
+
+// check rebuilds the configuration if any of the files was modified.
+func (r *reloader) check() {
+	if !r.modified() {
+		return
+	}
+	if err := r.reload(); err != nil {
+		r.logger.Warn("Failed to reload TLS configuration, keeping the previous one", zap.Error(err))
+		return
+	}
+	r.logger.Info("Reloaded TLS configuration", zap.Strings("files", r.files()))
+}
+
+// modified returns whether any of the files was modified since the
+// configuration was last built.
+func (r *reloader) modified() bool {
+	for i, f := range r.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			// The file may be in the middle of its replacement.
+			continue
+		}
+		if !info.ModTime().Equal(r.modTimes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// reload builds the configuration from the files.
+func (r *reloader) reload() error {
+	files := r.files()
+	modTimes := make([]time.Time, len(files))
+	for i, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return stacktrace.Propagate(err, "Error reading %s", f)
+		}
+		modTimes[i] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.configuration.CertFile, r.configuration.KeyFile)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error loading certificate %s and key %s", r.configuration.CertFile, r.configuration.KeyFile)
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   nextProtos,
+	}
+
+	if r.configuration.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.configuration.ClientCAFile)
+		if err != nil {
+			return stacktrace.Propagate(err, "Error reading client certificate authorities file")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return stacktrace.NewError("No certificate found in %s", r.configuration.ClientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if r.configuration.RequireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	r.config.Store(config)
+	r.modTimes = modTimes
+	return nil
+}
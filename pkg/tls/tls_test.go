@@ -0,0 +1,237 @@
+package tls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// issue returns a certificate for name signed by parent (self-signed if nil),
+// along with its key.
+func issue(t *testing.T, name string, serial int64, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func writeKeyPair(t *testing.T, certFile, keyFile string, cert *x509.Certificate, key *ecdsa.PrivateKey, modTime time.Time) {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	writePEM(t, certFile, "CERTIFICATE", cert.Raw, modTime)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER, modTime)
+}
+
+func TestCertificateReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	ca, caKey := issue(t, "ca", 1, nil, nil)
+	modTime := time.Now().Add(-time.Minute)
+
+	cert, key := issue(t, "dss.example.com", 2, ca, caKey)
+	writeKeyPair(t, certFile, keyFile, cert, key, modTime)
+
+	r, err := newReloader(Configuration{CertFile: certFile, KeyFile: keyFile}, zap.NewNop())
+	require.NoError(t, err)
+	config := r.serverConfig()
+	served := func() int64 {
+		c, err := config.GetConfigForClient(nil)
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(c.Certificates[0].Certificate[0])
+		require.NoError(t, err)
+		return leaf.SerialNumber.Int64()
+	}
+	require.Equal(t, int64(2), served())
+
+	// A rotation in progress, with a key not matching the certificate yet,
+	// keeps the previous certificate in use.
+	rotated, rotatedKey := issue(t, "dss.example.com", 3, ca, caKey)
+	writePEM(t, certFile, "CERTIFICATE", rotated.Raw, modTime.Add(time.Second))
+	r.check()
+	require.Equal(t, int64(2), served())
+
+	writeKeyPair(t, certFile, keyFile, rotated, rotatedKey, modTime.Add(2*time.Second))
+	require.Equal(t, int64(2), served())
+	r.check()
+	require.Equal(t, int64(3), served())
+}
+
+func TestPeriodicReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	ca, caKey := issue(t, "ca", 1, nil, nil)
+	modTime := time.Now().Add(-time.Minute)
+
+	cert, key := issue(t, "dss.example.com", 2, ca, caKey)
+	writeKeyPair(t, certFile, keyFile, cert, key, modTime)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config, err := NewServerConfig(ctx, Configuration{CertFile: certFile, KeyFile: keyFile, ReloadInterval: 10 * time.Millisecond}, zap.NewNop())
+	require.NoError(t, err)
+
+	rotated, rotatedKey := issue(t, "dss.example.com", 3, ca, caKey)
+	writeKeyPair(t, certFile, keyFile, rotated, rotatedKey, modTime.Add(time.Second))
+	require.Eventually(t, func() bool {
+		c, err := config.GetConfigForClient(nil)
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(c.Certificates[0].Certificate[0])
+		require.NoError(t, err)
+		return leaf.SerialNumber.Int64() == 3
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHTTP2(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	ca, caKey := issue(t, "ca", 1, nil, nil)
+
+	cert, key := issue(t, "localhost", 2, ca, caKey)
+	writeKeyPair(t, certFile, keyFile, cert, key, time.Now().Add(-time.Minute))
+
+	config, err := NewServerConfig(context.Background(), Configuration{CertFile: certFile, KeyFile: keyFile}, zap.NewNop())
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.EnableHTTP2 = true
+	server.TLS = config
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: roots, ServerName: "localhost"},
+		ForceAttemptHTTP2: true,
+	}}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, 2, resp.ProtoMajor)
+}
+
+func TestClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	ca, caKey := issue(t, "ca", 1, nil, nil)
+	otherCA, otherCAKey := issue(t, "other ca", 2, nil, nil)
+	modTime := time.Now().Add(-time.Minute)
+
+	cert, key := issue(t, "localhost", 3, ca, caKey)
+	writeKeyPair(t, certFile, keyFile, cert, key, modTime)
+	writePEM(t, caFile, "CERTIFICATE", ca.Raw, modTime)
+
+	clientCert := func(ca *x509.Certificate, caKey *ecdsa.PrivateKey, name string) *tls.Certificate {
+		cert, key := issue(t, name, 4, ca, caKey)
+		return &tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key}
+	}
+
+	var tests = []struct {
+		name     string
+		require  bool
+		cert     *tls.Certificate
+		ok       bool
+		identity string
+	}{
+		{"optional without certificate", false, nil, true, ""},
+		{"optional with certificate", false, clientCert(ca, caKey, "uss1.example.com"), true, "uss1.example.com"},
+		{"optional with untrusted certificate", false, clientCert(otherCA, otherCAKey, "uss1.example.com"), false, ""},
+		{"required without certificate", true, nil, false, ""},
+		{"required with certificate", true, clientCert(ca, caKey, "uss2.example.com"), true, "uss2.example.com"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := NewServerConfig(context.Background(), Configuration{
+				CertFile:          certFile,
+				KeyFile:           keyFile,
+				ClientCAFile:      caFile,
+				RequireClientCert: test.require,
+			}, zap.NewNop())
+			require.NoError(t, err)
+
+			var identity string
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if len(r.TLS.VerifiedChains) > 0 {
+					identity = r.TLS.VerifiedChains[0][0].Subject.CommonName
+				}
+			}))
+			server.TLS = config
+			server.StartTLS()
+			defer server.Close()
+
+			roots := x509.NewCertPool()
+			roots.AddCert(ca)
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+				RootCAs:    roots,
+				ServerName: "localhost",
+				// The certificate is presented even if it was not issued by the
+				// certificate authorities accepted by the server.
+				GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+					if test.cert == nil {
+						return &tls.Certificate{}, nil
+					}
+					return test.cert, nil
+				},
+			}}}
+			resp, err := client.Get(server.URL)
+			if !test.ok {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, test.identity, identity)
+		})
+	}
+}
+
+func TestMissingFiles(t *testing.T) {
+	_, err := NewServerConfig(context.Background(), Configuration{CertFile: "tls.crt"}, zap.NewNop())
+	require.Error(t, err)
+	_, err = NewServerConfig(context.Background(), Configuration{CertFile: "tls.crt", KeyFile: "tls.key", RequireClientCert: true}, zap.NewNop())
+	require.Error(t, err)
+	_, err = NewServerConfig(context.Background(), Configuration{CertFile: filepath.Join(t.TempDir(), "tls.crt"), KeyFile: filepath.Join(t.TempDir(), "tls.key")}, zap.NewNop())
+	require.Error(t, err)
+}
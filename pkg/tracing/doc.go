@@ -0,0 +1,6 @@
+// Package tracing configures OpenTelemetry distributed tracing for the DSS and
+// provides the helpers used to create spans across the service layers.
+//
+// Spans are created with the global TracerProvider, which is a no-op until
+// Configure installs an exporter.
+package tracing
@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/interuss/dss/pkg/api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusRecorder) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *statusRecorder) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// HTTPMiddleware installs an http.Handler creating a server span for every
+// request served by handler. The span continues the trace whose context is
+// carried by the incoming request headers, if any, and is named after the
+// path template of the api.Route that handled the request.
+func HTTPMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := otel.Tracer(instrumentationName).Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("client.address", r.RemoteAddr),
+			))
+		defer span.End()
+
+		srw := &statusRecorder{ResponseWriter: w}
+		r = api.WithRouteRecorder(r.WithContext(ctx))
+
+		handler.ServeHTTP(srw, r)
+
+		if route := api.MatchedRoute(r); route != nil {
+			span.SetName(fmt.Sprintf("%s %s", r.Method, route.Path))
+			span.SetAttributes(attribute.String("http.route", route.Path))
+		}
+		if srw.statusCode == 0 {
+			srw.statusCode = http.StatusOK
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", srw.statusCode))
+		if srw.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(srw.statusCode))
+		}
+	})
+}
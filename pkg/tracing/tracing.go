@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/interuss/dss/pkg/version"
+	"github.com/interuss/stacktrace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ExporterNone disables the export of spans.
+	ExporterNone = "none"
+	// ExporterStdout writes spans to the standard output.
+	ExporterStdout = "stdout"
+	// ExporterFile writes spans to a file.
+	ExporterFile = "file"
+	// ExporterOTLP sends spans to an OTLP/HTTP collector configured with the
+	// standard OTEL_EXPORTER_OTLP_* environment variables.
+	ExporterOTLP = "otlp"
+
+	instrumentationName = "github.com/interuss/dss"
+)
+
+// Configuration holds the parameters of the trace exporter.
+type Configuration struct {
+	// Exporter is one of ExporterNone, ExporterStdout, ExporterFile or
+	// ExporterOTLP.
+	Exporter string
+	// File is the path of the file spans are appended to with ExporterFile.
+	File string
+	// SamplingRatio is the fraction of new traces that are sampled. Traces
+	// started by a caller are sampled according to the caller's decision.
+	SamplingRatio float64
+	// ServiceName identifies the service emitting the spans.
+	ServiceName string
+}
+
+// Configure installs the global TracerProvider and trace context propagator
+// according to config. The returned function flushes pending spans and
+// releases the exporter's resources.
+func Configure(ctx context.Context, config Configuration) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+	switch config.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New()
+	case ExporterFile:
+		f, ferr := os.OpenFile(config.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if ferr != nil {
+			return nil, stacktrace.Propagate(ferr, "Error opening trace file %s", config.File)
+		}
+		closer = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, stacktrace.NewError("Unknown trace exporter %s", config.Exporter)
+	}
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error creating %s trace exporter", config.Exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", config.ServiceName),
+		attribute.String("service.version", version.Current().String()),
+	))
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error creating trace resource")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SamplingRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		if err := provider.Shutdown(ctx); err != nil {
+			return stacktrace.Propagate(err, "Error shutting down tracer provider")
+		}
+		if closer != nil {
+			return closer.Close()
+		}
+		return nil
+	}, nil
+}
+
+// Start creates a span named name as a child of the span in ctx, if any.
+// The caller must end the returned span.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends span, marking it as failed if err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}